@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_disbursements_status;
+
+ALTER TABLE disbursements
+    DROP CONSTRAINT IF EXISTS disbursements_status_check,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS failure_reason,
+    DROP COLUMN IF EXISTS status;
\ No newline at end of file
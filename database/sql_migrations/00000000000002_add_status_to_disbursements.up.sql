@@ -0,0 +1,12 @@
+ALTER TABLE disbursements
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN failure_reason TEXT,
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN processed_at TIMESTAMPTZ,
+    ADD COLUMN completed_at TIMESTAMPTZ,
+    ADD CONSTRAINT disbursements_status_check CHECK (
+        status IN ('PENDING', 'PROCESSING', 'SUCCESS', 'FAILED', 'CANCELLED', 'REVERSED')
+    );
+
+CREATE INDEX IF NOT EXISTS idx_disbursements_status ON disbursements (status);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE disbursements
+    DROP CONSTRAINT IF EXISTS disbursements_amount_positive_check,
+    DROP COLUMN IF EXISTS currency;
\ No newline at end of file
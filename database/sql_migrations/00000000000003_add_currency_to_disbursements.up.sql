@@ -0,0 +1,3 @@
+ALTER TABLE disbursements
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    ADD CONSTRAINT disbursements_amount_positive_check CHECK (amount > 0);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS uq_disbursements_idempotency_key;
+
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS idempotency_key;
\ No newline at end of file
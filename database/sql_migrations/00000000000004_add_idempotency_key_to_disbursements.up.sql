@@ -0,0 +1,6 @@
+ALTER TABLE disbursements
+    ADD COLUMN idempotency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_disbursements_idempotency_key
+    ON disbursements (idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
\ No newline at end of file
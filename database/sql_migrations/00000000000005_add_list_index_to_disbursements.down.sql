@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_disbursements_created_at_id;
\ No newline at end of file
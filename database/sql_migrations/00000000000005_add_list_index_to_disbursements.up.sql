@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_disbursements_created_at_id
+    ON disbursements (created_at DESC, id DESC);
\ No newline at end of file
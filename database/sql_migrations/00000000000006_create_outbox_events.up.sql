@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id UUID NOT NULL PRIMARY KEY,
+    message_key VARCHAR(255) NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    subtype VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished
+    ON outbox_events (created_at)
+    WHERE published_at IS NULL;
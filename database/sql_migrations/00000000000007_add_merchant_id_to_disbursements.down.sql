@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_disbursements_merchant_id_created_at_id;
+
+DROP INDEX IF EXISTS uq_disbursements_merchant_id_idempotency_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_disbursements_idempotency_key
+    ON disbursements (idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS merchant_id;
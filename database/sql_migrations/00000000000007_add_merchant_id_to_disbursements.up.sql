@@ -0,0 +1,12 @@
+ALTER TABLE disbursements
+    ADD COLUMN merchant_id VARCHAR(64);
+
+-- the idempotency key is unique per merchant
+DROP INDEX IF EXISTS uq_disbursements_idempotency_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_disbursements_merchant_id_idempotency_key
+    ON disbursements (merchant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_disbursements_merchant_id_created_at_id
+    ON disbursements (merchant_id, created_at DESC, id DESC);
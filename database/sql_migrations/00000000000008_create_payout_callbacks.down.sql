@@ -0,0 +1 @@
+DROP TABLE IF EXISTS payout_callbacks;
\ No newline at end of file
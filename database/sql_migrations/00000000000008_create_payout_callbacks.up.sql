@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS payout_callbacks(
+    provider VARCHAR(64) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    disbursement_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    failure_reason TEXT,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_callbacks_disbursement_id
+    ON payout_callbacks (disbursement_id);
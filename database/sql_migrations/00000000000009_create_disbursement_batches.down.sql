@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_disbursements_batch_id;
+
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS batch_id;
+
+DROP TABLE IF EXISTS disbursement_batches;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS disbursement_batches(
+    id UUID NOT NULL PRIMARY KEY,
+    merchant_id VARCHAR(64) NOT NULL,
+    item_count INTEGER NOT NULL,
+    idempotency_key VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE disbursements
+    ADD COLUMN batch_id UUID REFERENCES disbursement_batches (id);
+
+CREATE INDEX IF NOT EXISTS idx_disbursements_batch_id
+    ON disbursements (batch_id)
+    WHERE batch_id IS NOT NULL;
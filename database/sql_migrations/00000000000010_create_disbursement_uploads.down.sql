@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS disbursement_upload_rows;
+
+DROP TABLE IF EXISTS disbursement_uploads;
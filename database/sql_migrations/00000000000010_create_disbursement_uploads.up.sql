@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS disbursement_uploads(
+    id UUID NOT NULL PRIMARY KEY,
+    merchant_id VARCHAR(64) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    row_count INTEGER NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS disbursement_upload_rows(
+    upload_id UUID NOT NULL REFERENCES disbursement_uploads (id),
+    row_number INTEGER NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    amount DECIMAL NOT NULL,
+    currency CHAR(3) NOT NULL,
+    bank_code VARCHAR(11) NOT NULL,
+    account_number VARCHAR(20) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    disbursement_id UUID,
+    failure_reason TEXT,
+    PRIMARY KEY (upload_id, row_number),
+    UNIQUE (upload_id, reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_disbursement_upload_rows_status
+    ON disbursement_upload_rows (upload_id, status);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_scheduled_disbursements_due;
+
+DROP TABLE IF EXISTS scheduled_disbursements;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS scheduled_disbursements(
+    id UUID NOT NULL PRIMARY KEY,
+    merchant_id VARCHAR(64) NOT NULL,
+    amount DECIMAL NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    recurrence VARCHAR(255),
+    end_at TIMESTAMPTZ,
+    status VARCHAR(50) NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    run_count INTEGER NOT NULL DEFAULT 0,
+    last_failure_reason TEXT,
+    claimed_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- the scheduler only looks for active schedules that are due
+CREATE INDEX IF NOT EXISTS idx_scheduled_disbursements_due
+    ON scheduled_disbursements (next_run_at)
+    WHERE status = 'ACTIVE';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS disbursement_audit_logs;
+
+DROP TABLE IF EXISTS disbursement_reversals;
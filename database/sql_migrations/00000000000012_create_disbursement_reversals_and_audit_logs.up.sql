@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS disbursement_reversals(
+    id UUID NOT NULL PRIMARY KEY,
+    disbursement_id UUID NOT NULL UNIQUE REFERENCES disbursements (id),
+    merchant_id VARCHAR(64) NOT NULL,
+    amount DECIMAL NOT NULL,
+    currency CHAR(3) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS disbursement_audit_logs(
+    id UUID NOT NULL PRIMARY KEY,
+    disbursement_id UUID NOT NULL REFERENCES disbursements (id),
+    action VARCHAR(50) NOT NULL,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_disbursement_audit_logs_disbursement_id
+    ON disbursement_audit_logs (disbursement_id, created_at);
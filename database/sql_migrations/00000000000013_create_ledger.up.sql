@@ -0,0 +1,67 @@
+CREATE TABLE IF NOT EXISTS ledger_accounts(
+    code VARCHAR(128) NOT NULL PRIMARY KEY,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('ASSET', 'LIABILITY')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ledger_journal_entries(
+    id UUID NOT NULL PRIMARY KEY,
+    reference_id UUID NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_journal_entries_reference_id
+    ON ledger_journal_entries (reference_id);
+
+CREATE TABLE IF NOT EXISTS ledger_postings(
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    journal_entry_id UUID NOT NULL REFERENCES ledger_journal_entries (id),
+    account_code VARCHAR(128) NOT NULL REFERENCES ledger_accounts (code),
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('DEBIT', 'CREDIT')),
+    amount DECIMAL NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_journal_entry_id
+    ON ledger_postings (journal_entry_id);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_account
+    ON ledger_postings (account_code, currency);
+
+-- the postings of a journal entry are checked at commit, once all of them are written
+CREATE OR REPLACE FUNCTION ledger_check_journal_balanced() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM ledger_postings
+        WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency
+        HAVING SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % does not balance', NEW.journal_entry_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_ledger_postings_balanced
+    AFTER INSERT ON ledger_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_check_journal_balanced();
+
+-- the ledger is append only, a correction is a new journal entry
+CREATE OR REPLACE FUNCTION ledger_reject_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '% rows can not be changed', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_ledger_journal_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_journal_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+CREATE TRIGGER trg_ledger_postings_append_only
+    BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_disbursements_completed_at;
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_runs;
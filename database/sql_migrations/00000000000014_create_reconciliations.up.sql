@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS reconciliation_runs(
+    id UUID NOT NULL PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    line_count INT NOT NULL,
+    matched_count INT NOT NULL,
+    discrepancy_count INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS reconciliation_discrepancies(
+    run_id UUID NOT NULL REFERENCES reconciliation_runs (id),
+    position INT NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+    disbursement_id UUID,
+    merchant_id VARCHAR(64),
+    line_number INT,
+    expected_amount DECIMAL,
+    expected_currency CHAR(3),
+    settled_amount DECIMAL,
+    settled_currency CHAR(3),
+    detail TEXT NOT NULL,
+    PRIMARY KEY (run_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_merchant_id
+    ON reconciliation_discrepancies (run_id, merchant_id);
+
+-- the paid out disbursements of a settlement period are looked up by completed_at
+CREATE INDEX IF NOT EXISTS idx_disbursements_completed_at
+    ON disbursements (completed_at)
+    WHERE status IN ('SUCCESS', 'REVERSED');
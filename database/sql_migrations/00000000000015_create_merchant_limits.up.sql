@@ -0,0 +1,14 @@
+-- a NULL limit is not limited
+CREATE TABLE IF NOT EXISTS merchant_limits(
+    merchant_id VARCHAR(64) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    per_transaction DECIMAL CHECK (per_transaction >= 0),
+    daily_total DECIMAL CHECK (daily_total >= 0),
+    monthly_total DECIMAL CHECK (monthly_total >= 0),
+    max_count INT CHECK (max_count > 0),
+    count_window_seconds INT CHECK (count_window_seconds > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (merchant_id, currency),
+    CHECK ((max_count IS NULL) = (count_window_seconds IS NULL))
+);
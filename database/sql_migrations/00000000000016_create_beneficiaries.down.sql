@@ -0,0 +1,4 @@
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS beneficiary_id;
+
+DROP TABLE IF EXISTS beneficiaries;
\ No newline at end of file
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS beneficiaries(
+    id UUID NOT NULL PRIMARY KEY,
+    merchant_id VARCHAR(64) NOT NULL,
+    bank_code VARCHAR(11) NOT NULL,
+    account_number VARCHAR(20) NOT NULL,
+    holder_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- a deleted beneficiary is kept for the disbursements referencing it, so only the active ones must be unique
+CREATE UNIQUE INDEX IF NOT EXISTS idx_beneficiaries_merchant_id_bank_account
+    ON beneficiaries (merchant_id, bank_code, account_number)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_beneficiaries_merchant_id_created_at
+    ON beneficiaries (merchant_id, created_at, id)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE disbursements
+    ADD COLUMN beneficiary_id UUID REFERENCES beneficiaries (id);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_disbursements_approval_deadline;
+
+ALTER TABLE disbursements
+    DROP CONSTRAINT IF EXISTS disbursements_status_check,
+    ADD CONSTRAINT disbursements_status_check CHECK (
+        status IN ('PENDING', 'PROCESSING', 'SUCCESS', 'FAILED', 'CANCELLED', 'REVERSED')
+    ),
+    DROP COLUMN IF EXISTS approval_deadline,
+    DROP COLUMN IF EXISTS required_approvals;
+
+DROP TABLE IF EXISTS disbursement_approvals;
+
+DROP TABLE IF EXISTS merchant_approval_policies;
\ No newline at end of file
@@ -0,0 +1,40 @@
+-- approver_roles is the comma separated list of the roles allowed to approve
+CREATE TABLE IF NOT EXISTS merchant_approval_policies(
+    merchant_id VARCHAR(64) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    threshold DECIMAL NOT NULL CHECK (threshold >= 0),
+    approver_roles TEXT NOT NULL CHECK (approver_roles <> ''),
+    required_approvals INT NOT NULL CHECK (required_approvals > 0),
+    approval_window_seconds INT NOT NULL CHECK (approval_window_seconds > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (merchant_id, currency)
+);
+
+CREATE TABLE IF NOT EXISTS disbursement_approvals(
+    id UUID NOT NULL PRIMARY KEY,
+    disbursement_id UUID NOT NULL REFERENCES disbursements (id),
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    role VARCHAR(255),
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_disbursement_approvals_disbursement_id
+    ON disbursement_approvals (disbursement_id, created_at);
+
+-- a disbursement that needs no approval keeps required_approvals 0 and approval_deadline NULL
+ALTER TABLE disbursements
+    ADD COLUMN required_approvals INT NOT NULL DEFAULT 0,
+    ADD COLUMN approval_deadline TIMESTAMPTZ,
+    DROP CONSTRAINT IF EXISTS disbursements_status_check,
+    ADD CONSTRAINT disbursements_status_check CHECK (
+        status IN (
+            'AWAITING_APPROVAL', 'PENDING', 'PROCESSING', 'SUCCESS', 'FAILED', 'CANCELLED', 'REVERSED', 'REJECTED'
+        )
+    );
+
+CREATE INDEX IF NOT EXISTS idx_disbursements_approval_deadline
+    ON disbursements (approval_deadline)
+    WHERE status = 'AWAITING_APPROVAL';
@@ -0,0 +1,9 @@
+ALTER TABLE ledger_accounts
+    DROP CONSTRAINT IF EXISTS ledger_accounts_type_check,
+    ADD CONSTRAINT ledger_accounts_type_check CHECK (type IN ('ASSET', 'LIABILITY'));
+
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS fee_schedule_id,
+    DROP COLUMN IF EXISTS fee;
+
+DROP TABLE IF EXISTS fee_schedules;
@@ -0,0 +1,23 @@
+-- rules is the JSON array of the fee rules of the version, a version is never updated
+CREATE TABLE IF NOT EXISTS fee_schedules(
+    id UUID NOT NULL PRIMARY KEY,
+    merchant_id VARCHAR(64) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    version INT NOT NULL CHECK (version > 0),
+    effective_from TIMESTAMPTZ NOT NULL,
+    rules JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (merchant_id, currency, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fee_schedules_effective_from
+    ON fee_schedules (merchant_id, currency, effective_from);
+
+-- a disbursement of a merchant without fee schedule keeps fee 0 and fee_schedule_id NULL
+ALTER TABLE disbursements
+    ADD COLUMN fee DECIMAL NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    ADD COLUMN fee_schedule_id UUID REFERENCES fee_schedules (id);
+
+ALTER TABLE ledger_accounts
+    DROP CONSTRAINT IF EXISTS ledger_accounts_type_check,
+    ADD CONSTRAINT ledger_accounts_type_check CHECK (type IN ('ASSET', 'LIABILITY', 'REVENUE'));
@@ -0,0 +1,6 @@
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS funding_currency,
+    DROP COLUMN IF EXISTS fx_rate,
+    DROP COLUMN IF EXISTS fx_quote_id;
+
+DROP TABLE IF EXISTS fx_quotes;
@@ -0,0 +1,23 @@
+-- a quote locks the rate from source_currency into target_currency until expires_at,
+-- disbursement_id is set once a disbursement uses it and it can not be used again
+CREATE TABLE IF NOT EXISTS fx_quotes(
+    id UUID NOT NULL PRIMARY KEY,
+    merchant_id VARCHAR(64) NOT NULL,
+    source_currency CHAR(3) NOT NULL,
+    target_currency CHAR(3) NOT NULL CHECK (target_currency <> source_currency),
+    rate DECIMAL NOT NULL CHECK (rate > 0),
+    disbursement_id UUID UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- a disbursement funded in its own currency keeps fx_quote_id and fx_rate NULL
+ALTER TABLE disbursements
+    ADD COLUMN fx_quote_id UUID REFERENCES fx_quotes (id),
+    ADD COLUMN fx_rate DECIMAL CHECK (fx_rate > 0),
+    ADD COLUMN funding_currency CHAR(3);
+
+UPDATE disbursements SET funding_currency = currency;
+
+ALTER TABLE disbursements
+    ALTER COLUMN funding_currency SET NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS version;
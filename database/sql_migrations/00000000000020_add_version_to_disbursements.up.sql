@@ -0,0 +1,3 @@
+-- version is increased by every update, an update only applies over the version it loaded
+ALTER TABLE disbursements
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
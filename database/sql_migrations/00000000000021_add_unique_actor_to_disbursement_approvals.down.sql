@@ -0,0 +1,2 @@
+ALTER TABLE disbursement_approvals
+    DROP CONSTRAINT IF EXISTS disbursement_approvals_disbursement_id_actor_key;
@@ -0,0 +1,3 @@
+-- an actor does at most one step of the approval chain of a disbursement
+ALTER TABLE disbursement_approvals
+    ADD CONSTRAINT disbursement_approvals_disbursement_id_actor_key UNIQUE (disbursement_id, actor);
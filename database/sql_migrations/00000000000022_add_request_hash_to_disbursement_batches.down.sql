@@ -0,0 +1,2 @@
+ALTER TABLE disbursement_batches
+    DROP COLUMN IF EXISTS request_hash;
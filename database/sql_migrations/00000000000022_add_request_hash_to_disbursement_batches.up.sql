@@ -0,0 +1,3 @@
+-- request_hash is the hex SHA-256 of the requested items, NULL for the batches stored before
+ALTER TABLE disbursement_batches
+    ADD COLUMN request_hash CHAR(64);
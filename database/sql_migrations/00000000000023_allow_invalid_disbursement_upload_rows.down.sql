@@ -0,0 +1,12 @@
+DELETE FROM disbursement_upload_rows
+WHERE reference IS NULL OR amount IS NULL OR currency IS NULL OR bank_code IS NULL OR account_number IS NULL;
+
+DROP INDEX IF EXISTS idx_disbursement_upload_rows_reference;
+
+ALTER TABLE disbursement_upload_rows
+    ALTER COLUMN reference SET NOT NULL,
+    ALTER COLUMN amount SET NOT NULL,
+    ALTER COLUMN currency SET NOT NULL,
+    ALTER COLUMN bank_code SET NOT NULL,
+    ALTER COLUMN account_number SET NOT NULL,
+    ADD CONSTRAINT disbursement_upload_rows_upload_id_reference_key UNIQUE (upload_id, reference);
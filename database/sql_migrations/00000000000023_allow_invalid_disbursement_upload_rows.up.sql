@@ -0,0 +1,13 @@
+-- a row that could not be read is stored FAILED with the columns it could not fill left NULL,
+-- so only the rows to disburse need a unique reference
+ALTER TABLE disbursement_upload_rows
+    ALTER COLUMN reference DROP NOT NULL,
+    ALTER COLUMN amount DROP NOT NULL,
+    ALTER COLUMN currency DROP NOT NULL,
+    ALTER COLUMN bank_code DROP NOT NULL,
+    ALTER COLUMN account_number DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS disbursement_upload_rows_upload_id_reference_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursement_upload_rows_reference
+    ON disbursement_upload_rows (upload_id, reference)
+    WHERE failure_reason IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_disbursements_pending_created_at;
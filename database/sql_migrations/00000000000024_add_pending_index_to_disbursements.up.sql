@@ -0,0 +1,4 @@
+-- the scheduler sends the PENDING disbursements out oldest first
+CREATE INDEX IF NOT EXISTS idx_disbursements_pending_created_at
+    ON disbursements (created_at, id)
+    WHERE status = 'PENDING';
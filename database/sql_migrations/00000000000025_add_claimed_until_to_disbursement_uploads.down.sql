@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_disbursement_uploads_processing;
+
+ALTER TABLE disbursement_uploads
+    DROP COLUMN IF EXISTS claimed_until;
@@ -0,0 +1,7 @@
+-- the scheduler claims the processing uploads to disburse their pending rows
+ALTER TABLE disbursement_uploads
+    ADD COLUMN claimed_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_disbursement_uploads_processing
+    ON disbursement_uploads (created_at)
+    WHERE status = 'PROCESSING';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_disbursements_payout_claimed_until;
+
+ALTER TABLE disbursements
+    DROP COLUMN IF EXISTS payout_claimed_until;
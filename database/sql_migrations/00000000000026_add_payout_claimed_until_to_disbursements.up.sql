@@ -0,0 +1,7 @@
+-- a disbursement moved into PROCESSING is claimed until its payout is sent, a claim left to expire is sent again
+ALTER TABLE disbursements
+    ADD COLUMN payout_claimed_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_disbursements_payout_claimed_until
+    ON disbursements (payout_claimed_until)
+    WHERE status = 'PROCESSING' AND payout_claimed_until IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE disbursement_uploads
+    DROP COLUMN IF EXISTS created_by;
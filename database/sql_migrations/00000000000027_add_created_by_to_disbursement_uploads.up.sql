@@ -0,0 +1,4 @@
+-- the user who uploaded the file, the rows are disbursed as created by them so they can not approve them.
+-- null when the merchant uploaded the file itself
+ALTER TABLE disbursement_uploads
+    ADD COLUMN created_by VARCHAR(255);
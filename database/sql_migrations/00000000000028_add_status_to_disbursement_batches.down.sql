@@ -0,0 +1,5 @@
+ALTER TABLE disbursement_batches
+    DROP COLUMN IF EXISTS reservation_claimed_until;
+
+ALTER TABLE disbursement_batches
+    DROP COLUMN IF EXISTS status;
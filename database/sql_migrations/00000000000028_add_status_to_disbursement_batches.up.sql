@@ -0,0 +1,7 @@
+-- the batches stored before had their balance reserved in the same transaction, so they are ACCEPTED
+ALTER TABLE disbursement_batches
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'ACCEPTED';
+
+-- reservation_claimed_until is held by the request reserving the balance of a RESERVING batch
+ALTER TABLE disbursement_batches
+    ADD COLUMN reservation_claimed_until TIMESTAMPTZ;
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/layarda-durianpay/go-skeleton/internal/server"
 	"github.com/urfave/cli/v2"
@@ -27,6 +28,10 @@ func NewServiceApp() (cliApp *cli.App) {
 	cliApp.Commands = append(cliApp.Commands,
 		startServerCommand(),
 		startConsumerCommand(),
+		startOutboxRelayCommand(),
+		replayDLQCommand(),
+		startSchedulerCommand(),
+		reconcileSettlementCommand(),
 	)
 
 	return
@@ -57,3 +62,101 @@ func startConsumerCommand() (cmd *cli.Command) {
 
 	return
 }
+
+func startOutboxRelayCommand() (cmd *cli.Command) {
+	cmd = &cli.Command{
+		Name:  "relay",
+		Usage: "start outbox relay",
+		Action: func(c *cli.Context) error {
+			fmt.Println("acction start outbox relay")
+			return server.StartOutboxRelay()
+		},
+	}
+
+	return
+}
+
+func replayDLQCommand() (cmd *cli.Command) {
+	cmd = &cli.Command{
+		Name:  "dlq-replay",
+		Usage: "replay dead-letter messages back to the main topic",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "max number of messages to replay, 0 replays all",
+			},
+			&cli.DurationFlag{
+				Name:  "idle-timeout",
+				Usage: "stop when no message arrives within this duration",
+				Value: 10 * time.Second,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			fmt.Println("acction replay dead-letter messages")
+			return server.ReplayDisbursementDLQ(c.Int("limit"), c.Duration("idle-timeout"))
+		},
+	}
+
+	return
+}
+
+func startSchedulerCommand() (cmd *cli.Command) {
+	cmd = &cli.Command{
+		Name:  "scheduler",
+		Usage: "start scheduler of the scheduled disbursements, the uploads, the approval deadlines and the payouts",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:  "poll-interval",
+				Usage: "wait between looking for due schedules, processing uploads, expired approvals and pending payouts",
+				Value: 10 * time.Second,
+			},
+			&cli.IntFlag{
+				Name:  "batch-size",
+				Usage: "max number of schedules or uploads claimed, expired approvals rejected or payouts sent at once",
+				Value: 100,
+			},
+			&cli.DurationFlag{
+				Name:  "claim-timeout",
+				Usage: "how long claimed schedules, uploads and payouts are hidden from other schedulers, must cover running them",
+				Value: 5 * time.Minute,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			fmt.Println("acction start scheduler")
+			return server.StartScheduler(c.Duration("poll-interval"), c.Int("batch-size"), c.Duration("claim-timeout"))
+		},
+	}
+
+	return
+}
+
+func reconcileSettlementCommand() (cmd *cli.Command) {
+	cmd = &cli.Command{
+		Name:  "reconcile",
+		Usage: "reconcile a settlement report of the payout provider against the disbursements",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "provider",
+				Usage: "payout provider that sent the settlement report",
+				Value: "simulator",
+			},
+			&cli.StringFlag{
+				Name:     "file",
+				Usage:    "path of the settlement report",
+				Required: true,
+			},
+			&cli.TimestampFlag{
+				Name:     "date",
+				Usage:    "UTC day the settled disbursements were completed, as YYYY-MM-DD",
+				Layout:   time.DateOnly,
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			fmt.Println("acction reconcile settlement report")
+			return server.ReconcileSettlement(c.String("provider"), c.String("file"), *c.Timestamp("date"))
+		},
+	}
+
+	return
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 
 	"github.com/durianpay/dpay-common/logger"
@@ -68,6 +69,66 @@ func NewDisbursementServiceConfig() DisbursementServiceConfig {
 			Key:    "DISBURSEMENT_KAFKA_TOPIC",
 			Source: staticEnv,
 		},
+		{
+			Field:  &disbursementConfig.disbursementEventKafkaTopic,
+			Key:    "DISBURSEMENT_EVENT_KAFKA_TOPIC",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.disbursementDLQKafkaTopic,
+			Key:    "DISBURSEMENT_DLQ_KAFKA_TOPIC",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.limitDefaults,
+			Key:    "DISBURSEMENT_LIMIT_DEFAULTS",
+			Source: dynamicEnv,
+		},
+		{
+			Field:  &disbursementConfig.payoutProvider,
+			Key:    "PAYOUT_PROVIDER",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.payoutWebhookSecrets,
+			Key:    "PAYOUT_WEBHOOK_SECRETS",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.payoutSimulatorLatencyMs,
+			Key:    "PAYOUT_SIMULATOR_LATENCY_MS",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.payoutSimulatorFailurePercent,
+			Key:    "PAYOUT_SIMULATOR_FAILURE_PERCENT",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.payoutSimulatorCallbackDelayMs,
+			Key:    "PAYOUT_SIMULATOR_CALLBACK_DELAY_MS",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.nameInquiryProvider,
+			Key:    "NAME_INQUIRY_PROVIDER",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.jwtSecret,
+			Key:    "JWT_SECRET",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.snapMerchantJWTSecret,
+			Key:    "SNAP_MERCHANT_JWT_SECRET",
+			Source: staticEnv,
+		},
+		{
+			Field:  &disbursementConfig.internalUserJWTSecret,
+			Key:    "INTERNAL_USER_JWT_SECRET",
+			Source: staticEnv,
+		},
 	}
 
 	req := consul.InitVarRequest{
@@ -89,7 +150,27 @@ type disbursementServiceConfig struct {
 	enableConfigAllowTruncateAttributesOtel bool
 
 	// topic
-	disbursementKafkaTopic string
+	disbursementKafkaTopic      string
+	disbursementEventKafkaTopic string
+	disbursementDLQKafkaTopic   string
+
+	// limit
+	limitDefaults string
+
+	// payout
+	payoutProvider                 string
+	payoutWebhookSecrets           string
+	payoutSimulatorLatencyMs       int
+	payoutSimulatorFailurePercent  int
+	payoutSimulatorCallbackDelayMs int
+
+	// beneficiary
+	nameInquiryProvider string
+
+	// auth
+	jwtSecret             string
+	snapMerchantJWTSecret string
+	internalUserJWTSecret string
 }
 
 func (c disbursementServiceConfig) GetDisbursementDynamicConfig() string {
@@ -115,3 +196,74 @@ func (c disbursementServiceConfig) GetStartDebugServer() bool {
 func (c disbursementServiceConfig) GetDisbursementKafkaTopic() string {
 	return c.disbursementKafkaTopic
 }
+
+func (c disbursementServiceConfig) GetDisbursementEventKafkaTopic() string {
+	return c.disbursementEventKafkaTopic
+}
+
+func (c disbursementServiceConfig) GetDisbursementDLQKafkaTopic() string {
+	return c.disbursementDLQKafkaTopic
+}
+
+// GetDisbursementLimitDefaults returns the limits of the merchants without their own limits,
+// DISBURSEMENT_LIMIT_DEFAULTS is a JSON object of currency to limits and is reloaded on change
+func (c disbursementServiceConfig) GetDisbursementLimitDefaults() string {
+	return c.limitDefaults
+}
+
+func (c disbursementServiceConfig) GetPayoutProvider() string {
+	return c.payoutProvider
+}
+
+// GetPayoutWebhookSecret returns the secret the provider signs its callbacks with,
+// PAYOUT_WEBHOOK_SECRETS is a JSON object of provider name to secret
+func (c disbursementServiceConfig) GetPayoutWebhookSecret(provider string) string {
+	var secrets map[string]string
+
+	err := json.Unmarshal([]byte(c.payoutWebhookSecrets), &secrets)
+	if err != nil {
+		logger.Errorw(context.Background(), "invalid PAYOUT_WEBHOOK_SECRETS", "error", err.Error())
+		return ""
+	}
+
+	return secrets[provider]
+}
+
+func (c disbursementServiceConfig) GetPayoutSimulatorLatencyMs() int {
+	return c.payoutSimulatorLatencyMs
+}
+
+func (c disbursementServiceConfig) GetPayoutSimulatorFailurePercent() int {
+	return c.payoutSimulatorFailurePercent
+}
+
+func (c disbursementServiceConfig) GetPayoutSimulatorCallbackDelayMs() int {
+	return c.payoutSimulatorCallbackDelayMs
+}
+
+func (c disbursementServiceConfig) GetNameInquiryProvider() string {
+	return c.nameInquiryProvider
+}
+
+// GetMerchantTokenSecrets returns the secrets the bearer tokens of the merchants and their users are signed with,
+// the JWT_SECRET of the dashboard and the SNAP_MERCHANT_JWT_SECRET of the SNAP API. An unset secret is left out.
+func (c disbursementServiceConfig) GetMerchantTokenSecrets() [][]byte {
+	var secrets [][]byte
+	for _, secret := range []string{c.jwtSecret, c.snapMerchantJWTSecret} {
+		if secret != "" {
+			secrets = append(secrets, []byte(secret))
+		}
+	}
+
+	return secrets
+}
+
+// GetInternalUserTokenSecrets returns the secrets the bearer tokens of the internal users are signed with,
+// the INTERNAL_USER_JWT_SECRET of the admin dashboard. It is empty when the secret is unset.
+func (c disbursementServiceConfig) GetInternalUserTokenSecrets() [][]byte {
+	if c.internalUserJWTSecret == "" {
+		return nil
+	}
+
+	return [][]byte{[]byte(c.internalUserJWTSecret)}
+}
@@ -7,6 +7,17 @@ type DisbursementServiceConfig interface {
 	GetEnableConfigAllowTruncateAttributesOtel() bool
 	GetStartDebugServer() bool
 	GetDisbursementKafkaTopic() string
+	GetDisbursementEventKafkaTopic() string
+	GetDisbursementDLQKafkaTopic() string
+	GetDisbursementLimitDefaults() string
+	GetPayoutProvider() string
+	GetPayoutWebhookSecret(provider string) string
+	GetPayoutSimulatorLatencyMs() int
+	GetPayoutSimulatorFailurePercent() int
+	GetPayoutSimulatorCallbackDelayMs() int
+	GetNameInquiryProvider() string
+	GetMerchantTokenSecrets() [][]byte
+	GetInternalUserTokenSecrets() [][]byte
 }
 
 type GlobalConfig interface {
@@ -1 +1,18 @@
 package constants
+
+import "github.com/durianpay/dpay-common/constants"
+
+// MerchantIDKey is the context key the id of the authenticated merchant is put under, from the merchant_id claim
+// of the verified bearer token of the HTTP request or of the token the calling gRPC service forwards
+const MerchantIDKey constants.ContextKey = "merchant_id"
+
+// UserIDKey and UserRoleKey are the context keys the id and the role of the authenticated user of the merchant
+// are put under, from the user_id and user_role claims like MerchantIDKey, a merchant key has no user
+const (
+	UserIDKey   constants.ContextKey = "user_id"
+	UserRoleKey constants.ContextKey = "user_role"
+)
+
+// InternalUserIDKey is the context key the id of the authenticated internal user is put under, from the user_id
+// claim of a bearer token signed with the internal user secret. An internal user acts for no merchant.
+const InternalUserIDKey constants.ContextKey = "internal_user_id"
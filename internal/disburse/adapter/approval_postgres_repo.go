@@ -0,0 +1,261 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var getApprovalPolicyQuery = `SELECT merchant_id, currency, threshold, approver_roles, required_approvals,
+	approval_window_seconds
+FROM merchant_approval_policies
+WHERE merchant_id = $1 AND currency = $2`
+
+var setApprovalPolicyQuery = `INSERT INTO merchant_approval_policies (
+	merchant_id, currency, threshold, approver_roles, required_approvals, approval_window_seconds,
+	created_at, updated_at
+) VALUES (
+	:merchant_id, :currency, :threshold, :approver_roles, :required_approvals, :approval_window_seconds,
+	NOW(), NOW()
+) ON CONFLICT (merchant_id, currency) DO UPDATE SET
+	threshold = EXCLUDED.threshold,
+	approver_roles = EXCLUDED.approver_roles,
+	required_approvals = EXCLUDED.required_approvals,
+	approval_window_seconds = EXCLUDED.approval_window_seconds,
+	updated_at = NOW()`
+
+// addApprovalStepQuery ignores conflict on the disbursement id and actor, the caller checks the affected rows
+var addApprovalStepQuery = `INSERT INTO disbursement_approvals (
+	id, disbursement_id, action, actor, role, reason, created_at
+) VALUES (
+	:id, :disbursement_id, :action, :actor, :role, :reason, :created_at
+) ON CONFLICT (disbursement_id, actor) DO NOTHING`
+
+var listApprovalStepsQuery = `SELECT id, disbursement_id, action, actor, role, reason, created_at
+FROM disbursement_approvals
+WHERE disbursement_id = $1
+ORDER BY created_at, id`
+
+// approverRolesSeparator joins the approver roles into a single column, a role never contains it
+const approverRolesSeparator = ","
+
+type approvalPolicyModel struct {
+	MerchantID            string `db:"merchant_id"`
+	Currency              string `db:"currency"`
+	Threshold             string `db:"threshold"`
+	ApproverRoles         string `db:"approver_roles"`
+	RequiredApprovals     int    `db:"required_approvals"`
+	ApprovalWindowSeconds int64  `db:"approval_window_seconds"`
+}
+
+func newApprovalPolicyModel(merchantID string, p disburse.ApprovalPolicy) approvalPolicyModel {
+	return approvalPolicyModel{
+		MerchantID:            merchantID,
+		Currency:              p.Currency().String(),
+		Threshold:             p.Threshold().Decimal(),
+		ApproverRoles:         strings.Join(p.ApproverRoles(), approverRolesSeparator),
+		RequiredApprovals:     p.RequiredApprovals(),
+		ApprovalWindowSeconds: int64(p.Window() / time.Second),
+	}
+}
+
+func (m approvalPolicyModel) toDomain() (disburse.ApprovalPolicy, error) {
+	threshold, err := money.Parse(m.Threshold, m.Currency)
+	if err != nil {
+		return disburse.ApprovalPolicy{}, err
+	}
+
+	return disburse.UnmarshalApprovalPolicyFromDatabase(
+		threshold,
+		strings.Split(m.ApproverRoles, approverRolesSeparator),
+		m.RequiredApprovals,
+		time.Duration(m.ApprovalWindowSeconds)*time.Second,
+	), nil
+}
+
+type approvalStepModel struct {
+	ID             uuid.UUID      `db:"id"`
+	DisbursementID uuid.UUID      `db:"disbursement_id"`
+	Action         string         `db:"action"`
+	Actor          string         `db:"actor"`
+	Role           sql.NullString `db:"role"`
+	Reason         sql.NullString `db:"reason"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func newApprovalStepModel(s *disburse.ApprovalStep) approvalStepModel {
+	return approvalStepModel{
+		ID:             s.ID(),
+		DisbursementID: s.DisbursementID(),
+		Action:         s.Action().String(),
+		Actor:          s.Actor(),
+		Role: sql.NullString{
+			String: s.Role(),
+			Valid:  s.Role() != "",
+		},
+		Reason: sql.NullString{
+			String: s.Reason(),
+			Valid:  s.Reason() != "",
+		},
+		CreatedAt: s.CreatedAt(),
+	}
+}
+
+func (m approvalStepModel) toDomain() *disburse.ApprovalStep {
+	return disburse.UnmarshalApprovalStepFromDatabase(
+		m.ID,
+		m.DisbursementID,
+		disburse.ApprovalAction(m.Action),
+		m.Actor,
+		m.Role.String,
+		m.Reason.String,
+		m.CreatedAt,
+	)
+}
+
+type postgresApprovalRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresApprovalRepo) GetApprovalPolicy(
+	ctx context.Context,
+	merchantID string,
+	currency money.Currency,
+) (disburse.ApprovalPolicy, error) {
+	var model approvalPolicyModel
+
+	err := sqlx.GetContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&model,
+		getApprovalPolicyQuery,
+		merchantID,
+		currency.String(),
+	)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return disburse.ApprovalPolicy{}, errors.NewNotFoundError(
+			disburse.ErrApprovalPolicyNotFound,
+			disburse.ErrApprovalPolicyNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return disburse.ApprovalPolicy{}, errors.NewDatabaseError(
+			err,
+			"failed to get approval policy",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresApprovalRepo) SetApprovalPolicy(
+	ctx context.Context,
+	merchantID string,
+	policy disburse.ApprovalPolicy,
+) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(setApprovalPolicyQuery, newApprovalPolicyModel(merchantID, policy))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for set approval policy query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to set approval policy",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresApprovalRepo) AddApprovalStep(ctx context.Context, step *disburse.ApprovalStep) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(addApprovalStepQuery, newApprovalStepModel(step))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert approval step query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert disbursement approval step",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert disbursement approval step",
+			errors.DpayInternalError,
+		)
+	}
+
+	// a concurrent step of the same actor was saved first, the retry sees it in the chain
+	if affected == 0 {
+		return errors.NewConflictError(
+			disburse.ErrDisbursementConflict,
+			fmt.Sprintf("%s, disbursement %s", disburse.ErrDisbursementConflict.Error(), step.DisbursementID()),
+			errors.DpayConcurrentUpdate,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresApprovalRepo) ListApprovalSteps(
+	ctx context.Context,
+	disbursementID uuid.UUID,
+) ([]*disburse.ApprovalStep, error) {
+	var models []approvalStepModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, listApprovalStepsQuery, disbursementID)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list disbursement approval steps",
+			errors.DpayInternalError,
+		)
+	}
+
+	steps := make([]*disburse.ApprovalStep, 0, len(models))
+	for _, model := range models {
+		steps = append(steps, model.toDomain())
+	}
+
+	return steps, nil
+}
+
+func NewPostgresApprovalRepository(db sqlwrap.Database) disburse.ApprovalRepository {
+	return &postgresApprovalRepo{
+		db: db,
+	}
+}
@@ -0,0 +1,269 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var beneficiaryColumns = `id, merchant_id, bank_code, account_number, holder_name, created_at, updated_at, deleted_at`
+
+// createBeneficiaryQuery ignores conflict on the active bank account of the merchant, the caller checks the affected rows
+var createBeneficiaryQuery = `INSERT INTO beneficiaries (
+	id, merchant_id, bank_code, account_number, holder_name, created_at, updated_at, deleted_at
+) VALUES (
+	:id, :merchant_id, :bank_code, :account_number, :holder_name, :created_at, :updated_at, :deleted_at
+) ON CONFLICT DO NOTHING`
+
+var getBeneficiaryQuery = `SELECT ` + beneficiaryColumns + `
+FROM beneficiaries
+WHERE id = $1`
+
+// listBeneficiariesQuery uses bindvar ? since the conditions are appended dynamically, rebind before executing
+var listBeneficiariesQuery = `SELECT ` + beneficiaryColumns + `
+FROM beneficiaries
+WHERE deleted_at IS NULL`
+
+var listBeneficiariesOrderQuery = `ORDER BY created_at DESC, id DESC
+LIMIT ?`
+
+// updateBeneficiaryQuery skips the update when the bank account belongs to another active beneficiary
+// of the merchant, a beneficiary is never removed so no affected row means the bank account is taken
+var updateBeneficiaryQuery = `UPDATE beneficiaries SET
+	bank_code = :bank_code,
+	account_number = :account_number,
+	holder_name = :holder_name,
+	updated_at = :updated_at,
+	deleted_at = :deleted_at
+WHERE id = :id AND NOT EXISTS (
+	SELECT 1
+	FROM beneficiaries other
+	WHERE other.merchant_id = :merchant_id
+		AND other.bank_code = :bank_code
+		AND other.account_number = :account_number
+		AND other.id <> :id
+		AND other.deleted_at IS NULL
+)`
+
+type beneficiaryModel struct {
+	ID            uuid.UUID    `db:"id"`
+	MerchantID    string       `db:"merchant_id"`
+	BankCode      string       `db:"bank_code"`
+	AccountNumber string       `db:"account_number"`
+	HolderName    string       `db:"holder_name"`
+	CreatedAt     time.Time    `db:"created_at"`
+	UpdatedAt     time.Time    `db:"updated_at"`
+	DeletedAt     sql.NullTime `db:"deleted_at"`
+}
+
+func newBeneficiaryModel(b *disburse.Beneficiary) beneficiaryModel {
+	return beneficiaryModel{
+		ID:            b.ID(),
+		MerchantID:    b.MerchantID(),
+		BankCode:      b.BankAccount().BankCode(),
+		AccountNumber: b.BankAccount().AccountNumber(),
+		HolderName:    b.HolderName(),
+		CreatedAt:     b.CreatedAt(),
+		UpdatedAt:     b.UpdatedAt(),
+		DeletedAt: sql.NullTime{
+			Time:  b.DeletedAt(),
+			Valid: b.IsDeleted(),
+		},
+	}
+}
+
+func (m beneficiaryModel) toDomain() *disburse.Beneficiary {
+	return disburse.UnmarshalBeneficiaryFromDatabase(
+		m.ID,
+		m.MerchantID,
+		disburse.UnmarshalBankAccountFromDatabase(m.BankCode, m.AccountNumber),
+		m.HolderName,
+		m.CreatedAt,
+		m.UpdatedAt,
+		m.DeletedAt.Time,
+	)
+}
+
+type postgresBeneficiaryRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresBeneficiaryRepo) CreateBeneficiary(ctx context.Context, beneficiary *disburse.Beneficiary) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(createBeneficiaryQuery, newBeneficiaryModel(beneficiary))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert beneficiary query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert beneficiary",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert beneficiary",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrBeneficiaryAlreadyExists,
+			disburse.ErrBeneficiaryAlreadyExists.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresBeneficiaryRepo) GetBeneficiary(ctx context.Context, id uuid.UUID) (*disburse.Beneficiary, error) {
+	var model beneficiaryModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getBeneficiaryQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrBeneficiaryNotFound,
+			disburse.ErrBeneficiaryNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get beneficiary",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain(), nil
+}
+
+func (p *postgresBeneficiaryRepo) ListBeneficiaries(
+	ctx context.Context,
+	filter disburse.BeneficiaryFilter,
+) ([]*disburse.Beneficiary, error) {
+	qry, args := p.buildListBeneficiariesQuery(filter)
+
+	var models []beneficiaryModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, qry, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list beneficiaries",
+			errors.DpayInternalError,
+		)
+	}
+
+	beneficiaries := make([]*disburse.Beneficiary, 0, len(models))
+	for _, model := range models {
+		beneficiaries = append(beneficiaries, model.toDomain())
+	}
+
+	return beneficiaries, nil
+}
+
+func (p *postgresBeneficiaryRepo) UpdateBeneficiary(ctx context.Context, beneficiary *disburse.Beneficiary) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(updateBeneficiaryQuery, newBeneficiaryModel(beneficiary))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for update beneficiary query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to update beneficiary",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of update beneficiary",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrBeneficiaryAlreadyExists,
+			disburse.ErrBeneficiaryAlreadyExists.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresBeneficiaryRepo) buildListBeneficiariesQuery(filter disburse.BeneficiaryFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.MerchantID != "" {
+		conditions = append(conditions, "merchant_id = ?")
+		args = append(args, filter.MerchantID)
+	}
+
+	if filter.BankCode != "" {
+		conditions = append(conditions, "bank_code = ?")
+		args = append(args, filter.BankCode)
+	}
+
+	if filter.AccountNumber != "" {
+		conditions = append(conditions, "account_number = ?")
+		args = append(args, filter.AccountNumber)
+	}
+
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at, id) < (?, ?)")
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	qry := listBeneficiariesQuery
+	for _, condition := range conditions {
+		qry += "\n\tAND " + condition
+	}
+
+	qry += "\n" + listBeneficiariesOrderQuery
+	args = append(args, filter.Limit)
+
+	return p.db.Rebind(qry), args
+}
+
+func NewPostgresBeneficiaryRepository(db sqlwrap.Database) disburse.BeneficiaryRepository {
+	return &postgresBeneficiaryRepo{
+		db: db,
+	}
+}
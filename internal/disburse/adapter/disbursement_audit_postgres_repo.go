@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var addAuditEntryQuery = `INSERT INTO disbursement_audit_logs (
+	id, disbursement_id, action, from_status, to_status, actor, source, reason, created_at
+) VALUES (
+	:id, :disbursement_id, :action, :from_status, :to_status, :actor, :source, :reason, :created_at
+)`
+
+type auditEntryModel struct {
+	ID             uuid.UUID      `db:"id"`
+	DisbursementID uuid.UUID      `db:"disbursement_id"`
+	Action         string         `db:"action"`
+	FromStatus     string         `db:"from_status"`
+	ToStatus       string         `db:"to_status"`
+	Actor          string         `db:"actor"`
+	Source         string         `db:"source"`
+	Reason         sql.NullString `db:"reason"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func newAuditEntryModel(a *disburse.AuditEntry) auditEntryModel {
+	return auditEntryModel{
+		ID:             a.ID(),
+		DisbursementID: a.DisbursementID(),
+		Action:         a.Action().String(),
+		FromStatus:     a.FromStatus().String(),
+		ToStatus:       a.ToStatus().String(),
+		Actor:          a.Actor(),
+		Source:         a.Source(),
+		Reason: sql.NullString{
+			String: a.Reason(),
+			Valid:  a.Reason() != "",
+		},
+		CreatedAt: a.CreatedAt(),
+	}
+}
+
+type postgresAuditRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresAuditRepo) AddAuditEntry(ctx context.Context, entry *disburse.AuditEntry) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(addAuditEntryQuery, newAuditEntryModel(entry))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert audit entry query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert disbursement audit entry",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func NewPostgresAuditRepository(db sqlwrap.Database) disburse.AuditRepository {
+	return &postgresAuditRepo{
+		db: db,
+	}
+}
@@ -1,8 +1,248 @@
 package adapter
 
-import "github.com/google/uuid"
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
+	"github.com/samber/lo"
+)
 
 type disbursementModel struct {
-	ID     uuid.UUID `db:"id"`
-	Amount float64   `db:"amount"`
+	ID             uuid.UUID      `db:"id"`
+	MerchantID     sql.NullString `db:"merchant_id"`
+	Amount         string         `db:"amount"`
+	Currency       string         `db:"currency"`
+	Status         string         `db:"status"`
+	BatchID        uuid.NullUUID  `db:"batch_id"`
+	BeneficiaryID  uuid.NullUUID  `db:"beneficiary_id"`
+	IdempotencyKey sql.NullString `db:"idempotency_key"`
+	FailureReason  sql.NullString `db:"failure_reason"`
+
+	RequiredApprovals int          `db:"required_approvals"`
+	ApprovalDeadline  sql.NullTime `db:"approval_deadline"`
+
+	Fee           string        `db:"fee"`
+	FeeScheduleID uuid.NullUUID `db:"fee_schedule_id"`
+
+	FXQuoteID       uuid.NullUUID  `db:"fx_quote_id"`
+	FXRate          sql.NullString `db:"fx_rate"`
+	FundingCurrency string         `db:"funding_currency"`
+
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	ProcessedAt sql.NullTime `db:"processed_at"`
+	CompletedAt sql.NullTime `db:"completed_at"`
+	Version     int          `db:"version"`
+}
+
+func newDisbursementModel(d *disburse.Disbursement) disbursementModel {
+	return disbursementModel{
+		ID: d.ID(),
+		MerchantID: sql.NullString{
+			String: d.MerchantID(),
+			Valid:  d.MerchantID() != "",
+		},
+		Amount:   d.Amount().Decimal(),
+		Currency: d.Amount().Currency().String(),
+		Status:   d.Status().String(),
+		BatchID: uuid.NullUUID{
+			UUID:  d.BatchID(),
+			Valid: d.BatchID() != uuid.Nil,
+		},
+		BeneficiaryID: uuid.NullUUID{
+			UUID:  d.BeneficiaryID(),
+			Valid: d.BeneficiaryID() != uuid.Nil,
+		},
+		IdempotencyKey: sql.NullString{
+			String: d.IdempotencyKey(),
+			Valid:  d.IdempotencyKey() != "",
+		},
+		FailureReason: sql.NullString{
+			String: d.FailureReason(),
+			Valid:  d.FailureReason() != "",
+		},
+		RequiredApprovals: d.RequiredApprovals(),
+		ApprovalDeadline: sql.NullTime{
+			Time:  d.ApprovalDeadline(),
+			Valid: !d.ApprovalDeadline().IsZero(),
+		},
+		Fee: d.Fee().Decimal(),
+		FeeScheduleID: uuid.NullUUID{
+			UUID:  d.FeeScheduleID(),
+			Valid: d.FeeScheduleID() != uuid.Nil,
+		},
+		FXQuoteID: uuid.NullUUID{
+			UUID:  d.FXQuoteID(),
+			Valid: d.FXQuoteID() != uuid.Nil,
+		},
+		FXRate: sql.NullString{
+			String: d.FXRate().Decimal(),
+			Valid:  !d.FXRate().IsZero(),
+		},
+		FundingCurrency: d.FundingCurrency().String(),
+		CreatedAt:       d.CreatedAt(),
+		UpdatedAt:       d.UpdatedAt(),
+		ProcessedAt: sql.NullTime{
+			Time:  d.ProcessedAt(),
+			Valid: !d.ProcessedAt().IsZero(),
+		},
+		CompletedAt: sql.NullTime{
+			Time:  d.CompletedAt(),
+			Valid: !d.CompletedAt().IsZero(),
+		},
+		Version: d.Version(),
+	}
+}
+
+func (m disbursementModel) toDomain() (*disburse.Disbursement, error) {
+	amount, err := money.Parse(m.Amount, m.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := money.Parse(m.Fee, m.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var fxRate money.Rate
+	if m.FXRate.Valid {
+		fxRate, err = money.NewRate(money.Currency(m.FundingCurrency), amount.Currency(), m.FXRate.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return disburse.UnmarshalDisbursementFromDatabase(
+		m.ID,
+		m.MerchantID.String,
+		amount,
+		disburse.Status(m.Status),
+		m.BatchID.UUID,
+		m.BeneficiaryID.UUID,
+		m.IdempotencyKey.String,
+		m.FailureReason.String,
+		m.RequiredApprovals,
+		m.ApprovalDeadline.Time,
+		fee,
+		m.FeeScheduleID.UUID,
+		m.FXQuoteID.UUID,
+		fxRate,
+		m.CreatedAt,
+		m.UpdatedAt,
+		m.ProcessedAt.Time,
+		m.CompletedAt.Time,
+		m.Version,
+	)
+}
+
+type batchModel struct {
+	ID             uuid.UUID      `db:"id"`
+	MerchantID     string         `db:"merchant_id"`
+	ItemCount      int            `db:"item_count"`
+	Status         string         `db:"status"`
+	IdempotencyKey sql.NullString `db:"idempotency_key"`
+	RequestHash    sql.NullString `db:"request_hash"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func newBatchModel(b *disburse.Batch) batchModel {
+	return batchModel{
+		ID:         b.ID(),
+		MerchantID: b.MerchantID(),
+		ItemCount:  b.ItemCount(),
+		Status:     b.Status().String(),
+		IdempotencyKey: sql.NullString{
+			String: b.IdempotencyKey(),
+			Valid:  b.IdempotencyKey() != "",
+		},
+		RequestHash: sql.NullString{
+			String: b.RequestHash(),
+			Valid:  b.RequestHash() != "",
+		},
+		CreatedAt: b.CreatedAt(),
+	}
+}
+
+func (m batchModel) toDomain() *disburse.Batch {
+	return disburse.UnmarshalBatchFromDatabase(
+		m.ID,
+		m.MerchantID,
+		m.ItemCount,
+		disburse.BatchStatus(m.Status),
+		m.IdempotencyKey.String,
+		m.RequestHash.String,
+		m.CreatedAt,
+	)
+}
+
+type reversalModel struct {
+	ID             uuid.UUID      `db:"id"`
+	DisbursementID uuid.UUID      `db:"disbursement_id"`
+	MerchantID     string         `db:"merchant_id"`
+	Amount         string         `db:"amount"`
+	Currency       string         `db:"currency"`
+	Reason         sql.NullString `db:"reason"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func newReversalModel(r *disburse.Reversal) reversalModel {
+	return reversalModel{
+		ID:             r.ID(),
+		DisbursementID: r.DisbursementID(),
+		MerchantID:     r.MerchantID(),
+		Amount:         r.Amount().Decimal(),
+		Currency:       r.Amount().Currency().String(),
+		Reason: sql.NullString{
+			String: r.Reason(),
+			Valid:  r.Reason() != "",
+		},
+		CreatedAt: r.CreatedAt(),
+	}
+}
+
+func (m reversalModel) toDomain() (*disburse.Reversal, error) {
+	amount, err := money.Parse(m.Amount, m.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return disburse.UnmarshalReversalFromDatabase(
+		m.ID,
+		m.DisbursementID,
+		m.MerchantID,
+		amount,
+		m.Reason.String,
+		m.CreatedAt,
+	), nil
+}
+
+type statusCountModel struct {
+	Status string `db:"status"`
+	Count  int    `db:"count"`
+}
+
+func newDisbursementEvent(d *disburse.Disbursement) schema.DisbursementEvent {
+	return schema.DisbursementEvent{
+		ID:         d.ID().String(),
+		MerchantID: d.MerchantID(),
+		BatchID:    lo.Ternary(d.BatchID() != uuid.Nil, d.BatchID().String(), ""),
+		Amount:     d.Amount().Decimal(),
+		Currency:   d.Amount().Currency().String(),
+		Fee:        d.Fee().Decimal(),
+
+		FundingCurrency: d.FundingCurrency().String(),
+		FXRate:          lo.Ternary(d.FXRate().IsZero(), "", d.FXRate().Decimal()),
+
+		Status:        d.Status().String(),
+		FailureReason: d.FailureReason(),
+		CreatedAt:     d.CreatedAt(),
+		UpdatedAt:     d.UpdatedAt(),
+		ProcessedAt:   lo.EmptyableToPtr(d.ProcessedAt()),
+		CompletedAt:   lo.EmptyableToPtr(d.CompletedAt()),
+	}
 }
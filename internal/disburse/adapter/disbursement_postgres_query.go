@@ -1,3 +1,130 @@
 package adapter
 
-var createDisbursementQuery = "INSERT INTO disbursements (id, amount) VALUES (:id, :amount)"
+var disbursementColumns = `id, merchant_id, amount, currency, status, batch_id, beneficiary_id, idempotency_key,
+	failure_reason, required_approvals, approval_deadline, fee, fee_schedule_id, fx_quote_id, fx_rate, funding_currency,
+	created_at, updated_at, processed_at, completed_at, version`
+
+// createDisbursementQuery ignores conflict on id and idempotency key, the caller checks the affected rows
+var createDisbursementQuery = `INSERT INTO disbursements (
+	id, merchant_id, amount, currency, status, batch_id, beneficiary_id, idempotency_key, failure_reason,
+	required_approvals, approval_deadline, fee, fee_schedule_id, fx_quote_id, fx_rate, funding_currency, created_at,
+	updated_at, processed_at, completed_at, version
+) VALUES (
+	:id, :merchant_id, :amount, :currency, :status, :batch_id, :beneficiary_id, :idempotency_key, :failure_reason,
+	:required_approvals, :approval_deadline, :fee, :fee_schedule_id, :fx_quote_id, :fx_rate, :funding_currency, :created_at,
+	:updated_at, :processed_at, :completed_at, :version
+) ON CONFLICT DO NOTHING`
+
+// updateDisbursementQuery only saves over the version the disbursement was loaded at, no affected row means
+// another save came in between
+var updateDisbursementQuery = `UPDATE disbursements SET
+	status = :status,
+	failure_reason = :failure_reason,
+	updated_at = :updated_at,
+	processed_at = :processed_at,
+	completed_at = :completed_at,
+	version = version + 1
+WHERE id = :id AND version = :version`
+
+var getDisbursementQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements
+WHERE id = $1`
+
+var getDisbursementByIdempotencyKeyQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements
+WHERE merchant_id = $1 AND idempotency_key = $2`
+
+// listDisbursementsQuery uses bindvar ? since the conditions are appended dynamically, rebind before executing
+var listDisbursementsQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements`
+
+var listDisbursementsOrderQuery = `ORDER BY created_at DESC, id DESC
+LIMIT ?`
+
+// createReversalQuery ignores conflict on the disbursement id, the caller checks the affected rows
+var createReversalQuery = `INSERT INTO disbursement_reversals (
+	id, disbursement_id, merchant_id, amount, currency, reason, created_at
+) VALUES (
+	:id, :disbursement_id, :merchant_id, :amount, :currency, :reason, :created_at
+) ON CONFLICT DO NOTHING`
+
+var getReversalQuery = `SELECT id, disbursement_id, merchant_id, amount, currency, reason, created_at
+FROM disbursement_reversals
+WHERE disbursement_id = $1`
+
+// createBatchQuery ignores conflict on id, the caller checks the affected rows
+var createBatchQuery = `INSERT INTO disbursement_batches (
+	id, merchant_id, item_count, status, idempotency_key, request_hash, created_at
+) VALUES (
+	:id, :merchant_id, :item_count, :status, :idempotency_key, :request_hash, :created_at
+) ON CONFLICT DO NOTHING`
+
+var getBatchQuery = `SELECT id, merchant_id, item_count, status, idempotency_key, request_hash, created_at
+FROM disbursement_batches
+WHERE id = $1`
+
+// claimBatchReservationQuery takes over a claim that expired at $2, the request holding it is gone
+var claimBatchReservationQuery = `UPDATE disbursement_batches SET
+	reservation_claimed_until = $3
+WHERE id = $1 AND status = 'RESERVING' AND (reservation_claimed_until IS NULL OR reservation_claimed_until <= $2)`
+
+var completeBatchReservationQuery = `UPDATE disbursement_batches SET
+	status = $2,
+	reservation_claimed_until = NULL
+WHERE id = $1 AND status = 'RESERVING'`
+
+var countBatchItemsByStatusQuery = `SELECT status, COUNT(*) AS count
+FROM disbursements
+WHERE batch_id = $1
+GROUP BY status`
+
+// getDisbursementsByIDsQuery uses bindvar ? to be expanded by sqlx.In, rebind before executing
+var getDisbursementsByIDsQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements
+WHERE id IN (?)`
+
+// listExpiredApprovalsQuery skips the rows locked by another worker, so concurrent workers expire different ones
+var listExpiredApprovalsQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements
+WHERE status = 'AWAITING_APPROVAL' AND approval_deadline <= $1
+ORDER BY approval_deadline, id
+LIMIT $2
+FOR UPDATE SKIP LOCKED`
+
+// listDisbursementsToDispatchQuery skips the rows locked by another worker, so concurrent workers dispatch different ones,
+// the items of a batch wait until the balance of the whole batch is reserved
+var listDisbursementsToDispatchQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements
+WHERE status = 'PENDING'
+	AND (batch_id IS NULL OR batch_id IN (SELECT id FROM disbursement_batches WHERE status = 'ACCEPTED'))
+ORDER BY created_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED`
+
+var claimPayoutQuery = `UPDATE disbursements SET
+	payout_claimed_until = $2
+WHERE id = $1`
+
+// claimStalePayoutsQuery locks the rows with SKIP LOCKED so concurrent schedulers never claim the same payout,
+// a released claim is NULL and never stale since the provider has the payout and settles it through the callback
+var claimStalePayoutsQuery = `UPDATE disbursements SET
+	payout_claimed_until = $2
+WHERE id IN (
+	SELECT id
+	FROM disbursements
+	WHERE status = 'PROCESSING' AND payout_claimed_until < $1
+	ORDER BY payout_claimed_until, id
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING ` + disbursementColumns
+
+var releasePayoutClaimQuery = `UPDATE disbursements SET
+	payout_claimed_until = NULL
+WHERE id = $1`
+
+// listPaidOutDisbursementsQuery keeps a reversed disbursement since it was paid out before it was reversed
+var listPaidOutDisbursementsQuery = `SELECT ` + disbursementColumns + `
+FROM disbursements
+WHERE status IN ('SUCCESS', 'REVERSED') AND completed_at >= $1 AND completed_at < $2
+ORDER BY completed_at, id`
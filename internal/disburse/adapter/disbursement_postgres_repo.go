@@ -2,21 +2,41 @@ package adapter
 
 import (
 	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/outbox"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+	"github.com/samber/lo"
 )
 
 type postgresAgentRepo struct {
-	db sqlwrap.Database
+	db          sqlwrap.Database
+	manager     sqlwrap.ManagerInterface
+	outboxStore outbox.Store
+	ledgerRepo  ledger.Repository
 }
 
-func (p *postgresAgentRepo) CreateDisbursement(ctx context.Context, disbursement disburse.Disbursement) error {
-	qry, args, err := p.db.BindNamed(createDisbursementQuery, disbursementModel{
-		ID:     disbursement.ID,
-		Amount: disbursement.Amount,
+func (p *postgresAgentRepo) CreateDisbursement(ctx context.Context, disbursement *disburse.Disbursement) error {
+	return p.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return p.insertDisbursement(ctx, disbursement)
 	})
+}
+
+// insertDisbursement writes the disbursement with its created event, it must run inside a transaction
+func (p *postgresAgentRepo) insertDisbursement(ctx context.Context, disbursement *disburse.Disbursement) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(createDisbursementQuery, newDisbursementModel(disbursement))
 	if err != nil {
 		return errors.NewDatabaseError(
 			err,
@@ -25,7 +45,7 @@ func (p *postgresAgentRepo) CreateDisbursement(ctx context.Context, disbursement
 		)
 	}
 
-	_, err = p.db.ExecContext(ctx, qry, args...)
+	res, err := executor.ExecContext(ctx, qry, args...)
 	if err != nil {
 		return errors.NewDatabaseError(
 			err,
@@ -34,13 +54,708 @@ func (p *postgresAgentRepo) CreateDisbursement(ctx context.Context, disbursement
 		)
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert disbursement",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrDisbursementAlreadyExists,
+			disburse.ErrDisbursementAlreadyExists.Error(),
+			errors.DpayIdempotencyKeyReused,
+		)
+	}
+
+	err = p.addJournal(ctx, disbursement)
+	if err != nil {
+		return err
+	}
+
+	return p.addEvent(ctx, disbursement, schema.DisbursementCreatedSubType)
+}
+
+func (p *postgresAgentRepo) UpdateDisbursement(
+	ctx context.Context,
+	id uuid.UUID,
+	updateFn func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error),
+) error {
+	return p.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		disbursement, err := p.GetDisbursement(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// updateFn changes the loaded disbursement in place, so the status is kept before
+		fromStatus := disbursement.Status()
+
+		updated, err := updateFn(ctx, disbursement)
+		if err != nil {
+			return err
+		}
+
+		// nothing to save
+		if updated == nil {
+			return nil
+		}
+
+		executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+		qry, args, err := executor.BindNamed(updateDisbursementQuery, newDisbursementModel(updated))
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to bind named for update query",
+				errors.DpayInternalError,
+			)
+		}
+
+		res, err := executor.ExecContext(ctx, qry, args...)
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to update disbursement",
+				errors.DpayInternalError,
+			)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to get affected rows of update disbursement",
+				errors.DpayInternalError,
+			)
+		}
+
+		if affected == 0 {
+			return errors.NewConflictError(
+				disburse.ErrDisbursementConflict,
+				fmt.Sprintf("%s, disbursement %s", disburse.ErrDisbursementConflict.Error(), updated.ID()),
+				errors.DpayConcurrentUpdate,
+			)
+		}
+
+		// the journal entry and the event belong to a status change, e.g. an approval short of
+		// the required ones only bumps the version
+		if updated.Status() == fromStatus {
+			return nil
+		}
+
+		err = p.addJournal(ctx, updated)
+		if err != nil {
+			return err
+		}
+
+		return p.addEvent(ctx, updated, schema.DisbursementStatusChangedSubType)
+	})
+}
+
+// addJournal writes the ledger entry of the disbursement status, it must run inside the transaction of the change
+func (p *postgresAgentRepo) addJournal(ctx context.Context, disbursement *disburse.Disbursement) error {
+	entry, err := disbursement.Journal()
+	if err != nil {
+		return err
+	}
+
+	// the move does not change any balance
+	if entry == nil {
+		return nil
+	}
+
+	return p.ledgerRepo.AddJournalEntry(ctx, entry)
+}
+
+// addEvent writes the disbursement event to the outbox, it must run inside the transaction of the change
+func (p *postgresAgentRepo) addEvent(
+	ctx context.Context,
+	disbursement *disburse.Disbursement,
+	subType string,
+) error {
+	msg, err := outbox.NewMessage(
+		disbursement.ID().String(),
+		schema.DisbursementEventType,
+		subType,
+		newDisbursementEvent(disbursement),
+	)
+	if err != nil {
+		return errors.NewDpayError(
+			err,
+			"failed to build disbursement event",
+			errors.DpayInternalError,
+		)
+	}
+
+	return p.outboxStore.Add(ctx, msg)
+}
+
+func (p *postgresAgentRepo) GetDisbursement(ctx context.Context, id uuid.UUID) (*disburse.Disbursement, error) {
+	var model disbursementModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getDisbursementQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			"disbursement not found",
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get disbursement",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresAgentRepo) GetDisbursementByIdempotencyKey(
+	ctx context.Context,
+	merchantID string,
+	idempotencyKey string,
+) (*disburse.Disbursement, error) {
+	var model disbursementModel
+
+	err := sqlx.GetContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&model,
+		getDisbursementByIdempotencyKeyQuery,
+		merchantID,
+		idempotencyKey,
+	)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			"disbursement not found",
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get disbursement by idempotency key",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresAgentRepo) ListDisbursements(
+	ctx context.Context,
+	filter disburse.ListFilter,
+) ([]*disburse.Disbursement, error) {
+	qry, args, err := p.buildListDisbursementsQuery(filter)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to build list disbursements query",
+			errors.DpayInternalError,
+		)
+	}
+
+	var models []disbursementModel
+
+	err = sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, qry, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list disbursements",
+			errors.DpayInternalError,
+		)
+	}
+
+	disbursements := make([]*disburse.Disbursement, 0, len(models))
+	for _, model := range models {
+		disbursement, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		disbursements = append(disbursements, disbursement)
+	}
+
+	return disbursements, nil
+}
+
+// getDisbursementsByIDsChunk keeps the IN list of a single query well below the bind parameter limit
+const getDisbursementsByIDsChunk = 1000
+
+func (p *postgresAgentRepo) GetDisbursementsByIDs(
+	ctx context.Context,
+	ids []uuid.UUID,
+) ([]*disburse.Disbursement, error) {
+	disbursements := make([]*disburse.Disbursement, 0, len(ids))
+
+	for _, chunk := range lo.Chunk(ids, getDisbursementsByIDsChunk) {
+		qry, args, err := sqlx.In(getDisbursementsByIDsQuery, chunk)
+		if err != nil {
+			return nil, errors.NewDatabaseError(
+				err,
+				"failed to build get disbursements by ids query",
+				errors.DpayInternalError,
+			)
+		}
+
+		var models []disbursementModel
+
+		err = sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, p.db.Rebind(qry), args...)
+		if err != nil {
+			return nil, errors.NewDatabaseError(
+				err,
+				"failed to get disbursements by ids",
+				errors.DpayInternalError,
+			)
+		}
+
+		for _, model := range models {
+			disbursement, err := model.toDomain()
+			if err != nil {
+				return nil, err
+			}
+
+			disbursements = append(disbursements, disbursement)
+		}
+	}
+
+	return disbursements, nil
+}
+
+// ListExpiredApprovals locks the returned rows until the transaction of ctx ends, it must run inside a transaction
+func (p *postgresAgentRepo) ListExpiredApprovals(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*disburse.Disbursement, error) {
+	var models []disbursementModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, listExpiredApprovalsQuery, now, limit)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list expired approvals",
+			errors.DpayInternalError,
+		)
+	}
+
+	disbursements := make([]*disburse.Disbursement, 0, len(models))
+	for _, model := range models {
+		disbursement, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		disbursements = append(disbursements, disbursement)
+	}
+
+	return disbursements, nil
+}
+
+// ListDisbursementsToDispatch locks the returned rows until the transaction of ctx ends, it must run inside
+// a transaction
+func (p *postgresAgentRepo) ListDisbursementsToDispatch(ctx context.Context, limit int) ([]*disburse.Disbursement, error) {
+	var models []disbursementModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, listDisbursementsToDispatchQuery, limit)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list disbursements to dispatch",
+			errors.DpayInternalError,
+		)
+	}
+
+	disbursements := make([]*disburse.Disbursement, 0, len(models))
+	for _, model := range models {
+		disbursement, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		disbursements = append(disbursements, disbursement)
+	}
+
+	return disbursements, nil
+}
+
+func (p *postgresAgentRepo) ClaimPayout(ctx context.Context, id uuid.UUID, claimUntil time.Time) error {
+	_, err := sqlwrap.ExecutorFromContext(ctx, p.db).ExecContext(ctx, claimPayoutQuery, id, claimUntil)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to claim disbursement payout",
+			errors.DpayInternalError,
+		)
+	}
+
 	return nil
 }
 
+func (p *postgresAgentRepo) ClaimStalePayouts(
+	ctx context.Context,
+	now time.Time,
+	claimUntil time.Time,
+	limit int,
+) ([]*disburse.Disbursement, error) {
+	var models []disbursementModel
+
+	err := sqlx.SelectContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&models,
+		claimStalePayoutsQuery,
+		now,
+		claimUntil,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to claim stale disbursement payouts",
+			errors.DpayInternalError,
+		)
+	}
+
+	disbursements := make([]*disburse.Disbursement, 0, len(models))
+	for _, model := range models {
+		disbursement, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		disbursements = append(disbursements, disbursement)
+	}
+
+	return disbursements, nil
+}
+
+func (p *postgresAgentRepo) ReleasePayoutClaim(ctx context.Context, id uuid.UUID) error {
+	_, err := sqlwrap.ExecutorFromContext(ctx, p.db).ExecContext(ctx, releasePayoutClaimQuery, id)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to release disbursement payout claim",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresAgentRepo) ListPaidOutDisbursements(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) ([]*disburse.Disbursement, error) {
+	var models []disbursementModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, listPaidOutDisbursementsQuery, from, to)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list paid out disbursements",
+			errors.DpayInternalError,
+		)
+	}
+
+	disbursements := make([]*disburse.Disbursement, 0, len(models))
+	for _, model := range models {
+		disbursement, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		disbursements = append(disbursements, disbursement)
+	}
+
+	return disbursements, nil
+}
+
+func (p *postgresAgentRepo) CreateReversal(ctx context.Context, reversal *disburse.Reversal) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(createReversalQuery, newReversalModel(reversal))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert reversal query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert disbursement reversal",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert disbursement reversal",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrDisbursementAlreadyReversed,
+			disburse.ErrDisbursementAlreadyReversed.Error(),
+			errors.DpayInvalidStatusTransition,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresAgentRepo) GetReversal(ctx context.Context, disbursementID uuid.UUID) (*disburse.Reversal, error) {
+	var model reversalModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getReversalQuery, disbursementID)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			"disbursement reversal not found",
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get disbursement reversal",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresAgentRepo) CreateBatch(
+	ctx context.Context,
+	batch *disburse.Batch,
+	items []*disburse.Disbursement,
+) error {
+	return p.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+		qry, args, err := executor.BindNamed(createBatchQuery, newBatchModel(batch))
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to bind named for insert batch query",
+				errors.DpayInternalError,
+			)
+		}
+
+		res, err := executor.ExecContext(ctx, qry, args...)
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to insert disbursement batch",
+				errors.DpayInternalError,
+			)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to get affected rows of insert disbursement batch",
+				errors.DpayInternalError,
+			)
+		}
+
+		if affected == 0 {
+			return errors.NewUnprocessableEntityError(
+				disburse.ErrBatchAlreadyExists,
+				disburse.ErrBatchAlreadyExists.Error(),
+				errors.DpayIdempotencyKeyReused,
+			)
+		}
+
+		for _, item := range items {
+			err = p.insertDisbursement(ctx, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *postgresAgentRepo) GetBatch(ctx context.Context, id uuid.UUID) (*disburse.Batch, error) {
+	var model batchModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getBatchQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			"disbursement batch not found",
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get disbursement batch",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain(), nil
+}
+
+func (p *postgresAgentRepo) ClaimBatchReservation(
+	ctx context.Context,
+	id uuid.UUID,
+	now time.Time,
+	claimUntil time.Time,
+) (bool, error) {
+	res, err := sqlwrap.ExecutorFromContext(ctx, p.db).ExecContext(ctx, claimBatchReservationQuery, id, now, claimUntil)
+	if err != nil {
+		return false, errors.NewDatabaseError(
+			err,
+			"failed to claim disbursement batch reservation",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of claim disbursement batch reservation",
+			errors.DpayInternalError,
+		)
+	}
+
+	return affected > 0, nil
+}
+
+func (p *postgresAgentRepo) CompleteBatchReservation(
+	ctx context.Context,
+	id uuid.UUID,
+	status disburse.BatchStatus,
+) error {
+	_, err := sqlwrap.ExecutorFromContext(ctx, p.db).ExecContext(ctx, completeBatchReservationQuery, id, status.String())
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to complete disbursement batch reservation",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresAgentRepo) CountBatchItemsByStatus(
+	ctx context.Context,
+	batchID uuid.UUID,
+) (map[disburse.Status]int, error) {
+	var models []statusCountModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, countBatchItemsByStatusQuery, batchID)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to count disbursement batch items",
+			errors.DpayInternalError,
+		)
+	}
+
+	counts := make(map[disburse.Status]int, len(models))
+	for _, model := range models {
+		counts[disburse.Status(model.Status)] = model.Count
+	}
+
+	return counts, nil
+}
+
+func (p *postgresAgentRepo) buildListDisbursementsQuery(filter disburse.ListFilter) (string, []any, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.MerchantID != "" {
+		conditions = append(conditions, "merchant_id = ?")
+		args = append(args, filter.MerchantID)
+	}
+
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status IN (?)")
+		args = append(args, filter.Statuses)
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.CreatedFrom)
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, filter.CreatedTo)
+	}
+
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "currency = ?", "amount >= ?")
+		args = append(args, filter.MinAmount.Currency().String(), filter.MinAmount.Decimal())
+	}
+
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "currency = ?", "amount <= ?")
+		args = append(args, filter.MaxAmount.Currency().String(), filter.MaxAmount.Decimal())
+	}
+
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at, id) < (?, ?)")
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	qry := listDisbursementsQuery
+	if len(conditions) > 0 {
+		qry += "\nWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	qry += "\n" + listDisbursementsOrderQuery
+	args = append(args, filter.Limit)
+
+	// expand the IN (?) with the statuses
+	qry, args, err := sqlx.In(qry, args...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return p.db.Rebind(qry), args, nil
+}
+
 func NewPostgresDisbursementRepository(
 	db sqlwrap.Database,
+	manager sqlwrap.ManagerInterface,
+	outboxStore outbox.Store,
+	ledgerRepo ledger.Repository,
 ) disburse.DisburseRepository {
 	return &postgresAgentRepo{
-		db: db,
+		db:          db,
+		manager:     manager,
+		outboxStore: outboxStore,
+		ledgerRepo:  ledgerRepo,
 	}
 }
@@ -0,0 +1,408 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+	"github.com/samber/lo"
+)
+
+// uploadRowsInsertChunk keeps a single insert of upload rows below the postgres limit of bind parameters
+const uploadRowsInsertChunk = 1000
+
+var createUploadQuery = `INSERT INTO disbursement_uploads (
+	id, merchant_id, created_by, file_name, row_count, status, created_at, updated_at, completed_at
+) VALUES (
+	:id, :merchant_id, :created_by, :file_name, :row_count, :status, :created_at, :updated_at, :completed_at
+)`
+
+var createUploadRowsQuery = `INSERT INTO disbursement_upload_rows (
+	upload_id, row_number, reference, amount, currency, bank_code, account_number,
+	status, disbursement_id, failure_reason
+) VALUES (
+	:upload_id, :row_number, :reference, :amount, :currency, :bank_code, :account_number,
+	:status, :disbursement_id, :failure_reason
+)`
+
+var getUploadQuery = `SELECT id, merchant_id, created_by, file_name, row_count, status, created_at, updated_at, completed_at
+FROM disbursement_uploads
+WHERE id = $1`
+
+// claimProcessingUploadsQuery locks the rows with SKIP LOCKED so concurrent schedulers never claim the same upload,
+// the claim is kept in claimed_until so the row lock does not have to be held while the rows are disbursed
+var claimProcessingUploadsQuery = `UPDATE disbursement_uploads SET
+	claimed_until = $2
+WHERE id IN (
+	SELECT id
+	FROM disbursement_uploads
+	WHERE status = 'PROCESSING'
+		AND (claimed_until IS NULL OR claimed_until < $1)
+	ORDER BY created_at
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING id, merchant_id, created_by, file_name, row_count, status, created_at, updated_at, completed_at`
+
+var updateUploadRowQuery = `UPDATE disbursement_upload_rows SET
+	status = :status,
+	disbursement_id = :disbursement_id,
+	failure_reason = :failure_reason
+WHERE upload_id = :upload_id AND row_number = :row_number`
+
+var completeUploadQuery = `UPDATE disbursement_uploads SET
+	status = :status,
+	updated_at = :updated_at,
+	completed_at = :completed_at
+WHERE id = :id`
+
+var countUploadRowsByStatusQuery = `SELECT status, COUNT(*) AS count
+FROM disbursement_upload_rows
+WHERE upload_id = $1
+GROUP BY status`
+
+var listUploadRowsQuery = `SELECT upload_id, row_number, reference, amount, currency, bank_code, account_number,
+	status, disbursement_id, failure_reason
+FROM disbursement_upload_rows
+WHERE upload_id = $1 AND status = $2
+ORDER BY row_number`
+
+type uploadModel struct {
+	ID          uuid.UUID      `db:"id"`
+	MerchantID  string         `db:"merchant_id"`
+	CreatedBy   sql.NullString `db:"created_by"`
+	FileName    string         `db:"file_name"`
+	RowCount    int            `db:"row_count"`
+	Status      string         `db:"status"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+	CompletedAt sql.NullTime   `db:"completed_at"`
+}
+
+func newUploadModel(u *disburse.Upload) uploadModel {
+	return uploadModel{
+		ID:         u.ID(),
+		MerchantID: u.MerchantID(),
+		CreatedBy:  sql.NullString{String: u.CreatedBy(), Valid: u.CreatedBy() != ""},
+		FileName:   u.FileName(),
+		RowCount:   u.RowCount(),
+		Status:     u.Status().String(),
+		CreatedAt:  u.CreatedAt(),
+		UpdatedAt:  u.UpdatedAt(),
+		CompletedAt: sql.NullTime{
+			Time:  u.CompletedAt(),
+			Valid: !u.CompletedAt().IsZero(),
+		},
+	}
+}
+
+func (m uploadModel) toDomain() *disburse.Upload {
+	return disburse.UnmarshalUploadFromDatabase(
+		m.ID,
+		m.MerchantID,
+		m.CreatedBy.String,
+		m.FileName,
+		m.RowCount,
+		disburse.UploadStatus(m.Status),
+		m.CreatedAt,
+		m.UpdatedAt,
+		m.CompletedAt.Time,
+	)
+}
+
+// uploadRowModel keeps the reference, the amount and the bank account null for a row that could not be read
+type uploadRowModel struct {
+	UploadID       uuid.UUID      `db:"upload_id"`
+	RowNumber      int            `db:"row_number"`
+	Reference      sql.NullString `db:"reference"`
+	Amount         sql.NullString `db:"amount"`
+	Currency       sql.NullString `db:"currency"`
+	BankCode       sql.NullString `db:"bank_code"`
+	AccountNumber  sql.NullString `db:"account_number"`
+	Status         string         `db:"status"`
+	DisbursementID uuid.NullUUID  `db:"disbursement_id"`
+	FailureReason  sql.NullString `db:"failure_reason"`
+}
+
+func newUploadRowModel(uploadID uuid.UUID, r *disburse.UploadRow) uploadRowModel {
+	hasAmount := r.Amount().Currency() != ""
+	hasBankAccount := r.BankAccount().BankCode() != ""
+
+	return uploadRowModel{
+		UploadID:      uploadID,
+		RowNumber:     r.Number(),
+		Reference:     sql.NullString{String: r.Reference(), Valid: r.Reference() != ""},
+		Amount:        sql.NullString{String: r.Amount().Decimal(), Valid: hasAmount},
+		Currency:      sql.NullString{String: r.Amount().Currency().String(), Valid: hasAmount},
+		BankCode:      sql.NullString{String: r.BankAccount().BankCode(), Valid: hasBankAccount},
+		AccountNumber: sql.NullString{String: r.BankAccount().AccountNumber(), Valid: hasBankAccount},
+		Status:        r.Status().String(),
+		DisbursementID: uuid.NullUUID{
+			UUID:  r.DisbursementID(),
+			Valid: r.DisbursementID() != uuid.Nil,
+		},
+		FailureReason: sql.NullString{
+			String: r.FailureReason(),
+			Valid:  r.FailureReason() != "",
+		},
+	}
+}
+
+func (m uploadRowModel) toDomain() (*disburse.UploadRow, error) {
+	var amount money.Money
+	if m.Amount.Valid {
+		var err error
+
+		amount, err = money.Parse(m.Amount.String, m.Currency.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return disburse.UnmarshalUploadRowFromDatabase(
+		m.RowNumber,
+		m.Reference.String,
+		amount,
+		disburse.UnmarshalBankAccountFromDatabase(m.BankCode.String, m.AccountNumber.String),
+		disburse.UploadRowStatus(m.Status),
+		m.DisbursementID.UUID,
+		m.FailureReason.String,
+	), nil
+}
+
+type postgresUploadRepo struct {
+	db      sqlwrap.Database
+	manager sqlwrap.ManagerInterface
+}
+
+func (p *postgresUploadRepo) CreateUpload(
+	ctx context.Context,
+	upload *disburse.Upload,
+	rows []*disburse.UploadRow,
+) error {
+	return p.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+		qry, args, err := executor.BindNamed(createUploadQuery, newUploadModel(upload))
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to bind named for insert upload query",
+				errors.DpayInternalError,
+			)
+		}
+
+		_, err = executor.ExecContext(ctx, qry, args...)
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to insert disbursement upload",
+				errors.DpayInternalError,
+			)
+		}
+
+		models := lo.Map(rows, func(row *disburse.UploadRow, _ int) uploadRowModel {
+			return newUploadRowModel(upload.ID(), row)
+		})
+
+		for _, chunk := range lo.Chunk(models, uploadRowsInsertChunk) {
+			qry, args, err := executor.BindNamed(createUploadRowsQuery, chunk)
+			if err != nil {
+				return errors.NewDatabaseError(
+					err,
+					"failed to bind named for insert upload rows query",
+					errors.DpayInternalError,
+				)
+			}
+
+			_, err = executor.ExecContext(ctx, qry, args...)
+			if err != nil {
+				return errors.NewDatabaseError(
+					err,
+					"failed to insert disbursement upload rows",
+					errors.DpayInternalError,
+				)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *postgresUploadRepo) GetUpload(ctx context.Context, id uuid.UUID) (*disburse.Upload, error) {
+	var model uploadModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getUploadQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			"disbursement upload not found",
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get disbursement upload",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain(), nil
+}
+
+func (p *postgresUploadRepo) ClaimProcessingUploads(
+	ctx context.Context,
+	now time.Time,
+	claimUntil time.Time,
+	limit int,
+) ([]*disburse.Upload, error) {
+	var models []uploadModel
+
+	err := sqlx.SelectContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&models,
+		claimProcessingUploadsQuery,
+		now,
+		claimUntil,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to claim processing disbursement uploads",
+			errors.DpayInternalError,
+		)
+	}
+
+	return lo.Map(models, func(model uploadModel, _ int) *disburse.Upload {
+		return model.toDomain()
+	}), nil
+}
+
+func (p *postgresUploadRepo) UpdateUploadRow(
+	ctx context.Context,
+	uploadID uuid.UUID,
+	row *disburse.UploadRow,
+) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(updateUploadRowQuery, newUploadRowModel(uploadID, row))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for update upload row query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to update disbursement upload row",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresUploadRepo) CompleteUpload(ctx context.Context, upload *disburse.Upload) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(completeUploadQuery, newUploadModel(upload))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for complete upload query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to complete disbursement upload",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresUploadRepo) CountUploadRowsByStatus(
+	ctx context.Context,
+	uploadID uuid.UUID,
+) (map[disburse.UploadRowStatus]int, error) {
+	var models []statusCountModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, countUploadRowsByStatusQuery, uploadID)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to count disbursement upload rows",
+			errors.DpayInternalError,
+		)
+	}
+
+	counts := make(map[disburse.UploadRowStatus]int, len(models))
+	for _, model := range models {
+		counts[disburse.UploadRowStatus(model.Status)] = model.Count
+	}
+
+	return counts, nil
+}
+
+func (p *postgresUploadRepo) ListUploadRows(
+	ctx context.Context,
+	uploadID uuid.UUID,
+	status disburse.UploadRowStatus,
+) ([]*disburse.UploadRow, error) {
+	var models []uploadRowModel
+
+	err := sqlx.SelectContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&models,
+		listUploadRowsQuery,
+		uploadID,
+		status.String(),
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list disbursement upload rows",
+			errors.DpayInternalError,
+		)
+	}
+
+	rows := make([]*disburse.UploadRow, 0, len(models))
+	for _, model := range models {
+		row, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func NewPostgresUploadRepository(db sqlwrap.Database, manager sqlwrap.ManagerInterface) disburse.UploadRepository {
+	return &postgresUploadRepo{
+		db:      db,
+		manager: manager,
+	}
+}
@@ -0,0 +1,289 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var feeScheduleColumns = `id, merchant_id, currency, version, effective_from, rules, created_at`
+
+var getEffectiveFeeScheduleQuery = `SELECT ` + feeScheduleColumns + `
+FROM fee_schedules
+WHERE merchant_id = $1 AND currency = $2 AND effective_from <= $3
+ORDER BY version DESC
+LIMIT 1`
+
+var getLatestFeeScheduleQuery = `SELECT ` + feeScheduleColumns + `
+FROM fee_schedules
+WHERE merchant_id = $1 AND currency = $2
+ORDER BY version DESC
+LIMIT 1`
+
+// createFeeScheduleQuery ignores conflict on the version, the caller checks the affected rows
+var createFeeScheduleQuery = `INSERT INTO fee_schedules (
+	id, merchant_id, currency, version, effective_from, rules, created_at
+) VALUES (
+	:id, :merchant_id, :currency, :version, :effective_from, :rules, :created_at
+) ON CONFLICT DO NOTHING`
+
+type feeScheduleModel struct {
+	ID            uuid.UUID `db:"id"`
+	MerchantID    string    `db:"merchant_id"`
+	Currency      string    `db:"currency"`
+	Version       int       `db:"version"`
+	EffectiveFrom time.Time `db:"effective_from"`
+	Rules         []byte    `db:"rules"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// feeRuleModel is a rule in the rules JSON column, the amounts are exact decimals in major units
+// and empty when not set
+type feeRuleModel struct {
+	BankCode string         `json:"bank_code,omitempty"`
+	Type     string         `json:"type"`
+	Flat     string         `json:"flat,omitempty"`
+	Rate     int64          `json:"rate,omitempty"`
+	Tiers    []feeTierModel `json:"tiers,omitempty"`
+	Min      string         `json:"min,omitempty"`
+	Max      string         `json:"max,omitempty"`
+}
+
+type feeTierModel struct {
+	UpTo string `json:"up_to,omitempty"`
+	Flat string `json:"flat,omitempty"`
+	Rate int64  `json:"rate,omitempty"`
+}
+
+func newFeeScheduleModel(s *disburse.FeeSchedule) (feeScheduleModel, error) {
+	rules := make([]feeRuleModel, 0, len(s.Rules()))
+	for _, rule := range s.Rules() {
+		tiers := make([]feeTierModel, 0, len(rule.Tiers()))
+		for _, tier := range rule.Tiers() {
+			tiers = append(tiers, feeTierModel{
+				UpTo: optionalDecimal(tier.UpTo()),
+				Flat: optionalDecimal(tier.Flat()),
+				Rate: tier.Rate(),
+			})
+		}
+
+		rules = append(rules, feeRuleModel{
+			BankCode: rule.BankCode(),
+			Type:     rule.Type().String(),
+			Flat:     optionalDecimal(rule.Flat()),
+			Rate:     rule.Rate(),
+			Tiers:    tiers,
+			Min:      optionalDecimal(rule.Min()),
+			Max:      optionalDecimal(rule.Max()),
+		})
+	}
+
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return feeScheduleModel{}, errors.NewDpayError(
+			err,
+			"failed to marshal fee schedule rules",
+			errors.DpayInternalError,
+		)
+	}
+
+	return feeScheduleModel{
+		ID:            s.ID(),
+		MerchantID:    s.MerchantID(),
+		Currency:      s.Currency().String(),
+		Version:       s.Version(),
+		EffectiveFrom: s.EffectiveFrom(),
+		Rules:         raw,
+		CreatedAt:     s.CreatedAt(),
+	}, nil
+}
+
+func (m feeScheduleModel) toDomain() (*disburse.FeeSchedule, error) {
+	currency, err := money.ParseCurrency(m.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []feeRuleModel
+
+	err = json.Unmarshal(m.Rules, &models)
+	if err != nil {
+		return nil, errors.NewDpayError(
+			err,
+			"failed to unmarshal fee schedule rules",
+			errors.DpayInternalError,
+		)
+	}
+
+	rules := make([]disburse.FeeRule, 0, len(models))
+	for _, rule := range models {
+		tiers := make([]disburse.FeeTier, 0, len(rule.Tiers))
+		for _, tier := range rule.Tiers {
+			amounts, err := parseOptionalDecimals(m.Currency, tier.UpTo, tier.Flat)
+			if err != nil {
+				return nil, err
+			}
+
+			tiers = append(tiers, disburse.NewFeeTier(amounts[0], amounts[1], tier.Rate))
+		}
+
+		amounts, err := parseOptionalDecimals(m.Currency, rule.Flat, rule.Min, rule.Max)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, disburse.NewFeeRule(
+			rule.BankCode,
+			disburse.FeeRuleType(rule.Type),
+			amounts[0],
+			rule.Rate,
+			tiers,
+			amounts[1],
+			amounts[2],
+		))
+	}
+
+	return disburse.UnmarshalFeeScheduleFromDatabase(
+		m.ID,
+		m.MerchantID,
+		currency,
+		m.Version,
+		m.EffectiveFrom,
+		rules,
+		m.CreatedAt,
+	), nil
+}
+
+// optionalDecimal returns the decimal of the amount, empty for zero Money
+func optionalDecimal(amount money.Money) string {
+	if amount.IsZero() {
+		return ""
+	}
+
+	return amount.Decimal()
+}
+
+// parseOptionalDecimals parses the decimals in the currency, an empty decimal is zero Money
+func parseOptionalDecimals(currency string, decimals ...string) ([]money.Money, error) {
+	amounts := make([]money.Money, 0, len(decimals))
+	for _, decimal := range decimals {
+		if decimal == "" {
+			amounts = append(amounts, money.Money{})
+			continue
+		}
+
+		amount, err := money.Parse(decimal, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		amounts = append(amounts, amount)
+	}
+
+	return amounts, nil
+}
+
+type postgresFeeRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresFeeRepo) GetEffectiveFeeSchedule(
+	ctx context.Context,
+	merchantID string,
+	currency money.Currency,
+	at time.Time,
+) (*disburse.FeeSchedule, error) {
+	return p.getFeeSchedule(ctx, getEffectiveFeeScheduleQuery, merchantID, currency.String(), at)
+}
+
+func (p *postgresFeeRepo) GetLatestFeeSchedule(
+	ctx context.Context,
+	merchantID string,
+	currency money.Currency,
+) (*disburse.FeeSchedule, error) {
+	return p.getFeeSchedule(ctx, getLatestFeeScheduleQuery, merchantID, currency.String())
+}
+
+func (p *postgresFeeRepo) getFeeSchedule(ctx context.Context, query string, args ...any) (*disburse.FeeSchedule, error) {
+	var model feeScheduleModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, query, args...)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrFeeScheduleNotFound,
+			disburse.ErrFeeScheduleNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get fee schedule",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresFeeRepo) CreateFeeSchedule(ctx context.Context, schedule *disburse.FeeSchedule) error {
+	model, err := newFeeScheduleModel(schedule)
+	if err != nil {
+		return err
+	}
+
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(createFeeScheduleQuery, model)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert fee schedule query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert fee schedule",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert fee schedule",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrFeeScheduleVersionExists,
+			disburse.ErrFeeScheduleVersionExists.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+func NewPostgresFeeRepository(db sqlwrap.Database) disburse.FeeRepository {
+	return &postgresFeeRepo{
+		db: db,
+	}
+}
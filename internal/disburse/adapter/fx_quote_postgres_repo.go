@@ -0,0 +1,162 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var createFXQuoteQuery = `INSERT INTO fx_quotes (
+	id, merchant_id, source_currency, target_currency, rate, disbursement_id, expires_at, created_at
+) VALUES (
+	:id, :merchant_id, :source_currency, :target_currency, :rate, :disbursement_id, :expires_at, :created_at
+)`
+
+var getFXQuoteQuery = `SELECT id, merchant_id, source_currency, target_currency, rate, disbursement_id,
+	expires_at, created_at
+FROM fx_quotes
+WHERE id = $1`
+
+// useFXQuoteQuery sets the disbursement once, using the quote again for the same disbursement is a no-op
+var useFXQuoteQuery = `UPDATE fx_quotes SET
+	disbursement_id = $2
+WHERE id = $1 AND (disbursement_id IS NULL OR disbursement_id = $2)`
+
+type fxQuoteModel struct {
+	ID             uuid.UUID     `db:"id"`
+	MerchantID     string        `db:"merchant_id"`
+	SourceCurrency string        `db:"source_currency"`
+	TargetCurrency string        `db:"target_currency"`
+	Rate           string        `db:"rate"`
+	DisbursementID uuid.NullUUID `db:"disbursement_id"`
+	ExpiresAt      time.Time     `db:"expires_at"`
+	CreatedAt      time.Time     `db:"created_at"`
+}
+
+func newFXQuoteModel(q *disburse.FXQuote) fxQuoteModel {
+	return fxQuoteModel{
+		ID:             q.ID(),
+		MerchantID:     q.MerchantID(),
+		SourceCurrency: q.Rate().Source().String(),
+		TargetCurrency: q.Rate().Target().String(),
+		Rate:           q.Rate().Decimal(),
+		DisbursementID: uuid.NullUUID{
+			UUID:  q.DisbursementID(),
+			Valid: q.DisbursementID() != uuid.Nil,
+		},
+		ExpiresAt: q.ExpiresAt(),
+		CreatedAt: q.CreatedAt(),
+	}
+}
+
+func (m fxQuoteModel) toDomain() (*disburse.FXQuote, error) {
+	rate, err := money.NewRate(money.Currency(m.SourceCurrency), money.Currency(m.TargetCurrency), m.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return disburse.UnmarshalFXQuoteFromDatabase(
+		m.ID,
+		m.MerchantID,
+		rate,
+		m.DisbursementID.UUID,
+		m.ExpiresAt,
+		m.CreatedAt,
+	), nil
+}
+
+type postgresFXQuoteRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresFXQuoteRepo) CreateFXQuote(ctx context.Context, quote *disburse.FXQuote) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(createFXQuoteQuery, newFXQuoteModel(quote))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert fx quote query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert fx quote",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresFXQuoteRepo) GetFXQuote(ctx context.Context, id uuid.UUID) (*disburse.FXQuote, error) {
+	var model fxQuoteModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getFXQuoteQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrFXQuoteNotFound,
+			disburse.ErrFXQuoteNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get fx quote",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresFXQuoteRepo) UseFXQuote(ctx context.Context, quoteID uuid.UUID, disbursementID uuid.UUID) error {
+	res, err := sqlwrap.ExecutorFromContext(ctx, p.db).ExecContext(ctx, useFXQuoteQuery, quoteID, disbursementID)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to use fx quote",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of use fx quote",
+			errors.DpayInternalError,
+		)
+	}
+
+	// the quote was read before, so no affected row means another disbursement used it in between
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrFXQuoteUsed,
+			disburse.ErrFXQuoteUsed.Error(),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	return nil
+}
+
+func NewPostgresFXQuoteRepository(db sqlwrap.Database) disburse.FXQuoteRepository {
+	return &postgresFXQuoteRepo{
+		db: db,
+	}
+}
@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// stubRateFractionDigits is the precision of a cross rate of the stub
+const stubRateFractionDigits = 10
+
+// stubUSDRates is the local rate table, the worth of 1 USD in each currency
+var stubUSDRates = map[money.Currency]string{
+	money.USD: "1",
+	money.IDR: "16250",
+	money.SGD: "1.35",
+	money.MYR: "4.45",
+	money.PHP: "57.5",
+	money.THB: "36.2",
+	money.VND: "25400",
+	"AUD":     "1.52",
+	"CNY":     "7.25",
+	"EUR":     "0.92",
+	"GBP":     "0.79",
+	"HKD":     "7.8",
+	"INR":     "83.5",
+	"JPY":     "150",
+	"KRW":     "1380",
+}
+
+// fxRateStub answers the rates in process from a fixed table, so disbursements in another currency
+// can be quoted locally without a rate provider
+type fxRateStub struct {
+	// usdRates is the worth of 1 USD in each currency of the table
+	usdRates map[money.Currency]*big.Rat
+}
+
+// GetRate returns the cross rate through USD, a currency outside of the table can not be exchanged
+func (s fxRateStub) GetRate(_ context.Context, source money.Currency, target money.Currency) (money.Rate, error) {
+	sourceRate, sourceOK := s.usdRates[source]
+	targetRate, targetOK := s.usdRates[target]
+	if !sourceOK || !targetOK {
+		return money.Rate{}, errors.NewUnprocessableEntityError(
+			disburse.ErrFXRateNotFound,
+			fmt.Sprintf("%s, %s to %s", disburse.ErrFXRateNotFound.Error(), source, target),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	cross := new(big.Rat).Quo(targetRate, sourceRate)
+
+	return money.NewRate(source, target, cross.FloatString(stubRateFractionDigits))
+}
+
+// NewFXRateStub returns an FXRateProvider answering from usdRates, the worth of 1 USD in each currency
+// as a decimal string, nil uses a built-in table of the usual currencies
+func NewFXRateStub(usdRates map[money.Currency]string) (disburse.FXRateProvider, error) {
+	if usdRates == nil {
+		usdRates = stubUSDRates
+	}
+
+	rates := make(map[money.Currency]*big.Rat, len(usdRates))
+	for currency, rate := range usdRates {
+		parsed, err := money.NewRate(money.USD, currency, rate)
+		if err != nil {
+			return nil, err
+		}
+
+		value, _ := new(big.Rat).SetString(parsed.Decimal())
+		rates[currency] = value
+	}
+
+	return fxRateStub{
+		usdRates: rates,
+	}, nil
+}
@@ -0,0 +1,245 @@
+package adapter
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+	"github.com/samber/lo"
+)
+
+// addJournalEntryQuery ignores conflict on id, the caller checks the affected rows
+var addJournalEntryQuery = `INSERT INTO ledger_journal_entries (
+	id, reference_id, description, created_at
+) VALUES (
+	:id, :reference_id, :description, :created_at
+) ON CONFLICT DO NOTHING`
+
+// addAccountsQuery creates the accounts on their first posting
+var addAccountsQuery = `INSERT INTO ledger_accounts (
+	code, type
+) VALUES (
+	:code, :type
+) ON CONFLICT DO NOTHING`
+
+var addPostingsQuery = `INSERT INTO ledger_postings (
+	journal_entry_id, account_code, direction, amount, currency
+) VALUES (
+	:journal_entry_id, :account_code, :direction, :amount, :currency
+)`
+
+// listBalancesQuery uses bindvar ? to expand the IN (?) with the account codes, rebind before executing
+var listBalancesQuery = `SELECT
+	p.account_code,
+	a.type,
+	p.currency,
+	COALESCE(SUM(p.amount) FILTER (WHERE p.direction = 'DEBIT'), 0) AS debit,
+	COALESCE(SUM(p.amount) FILTER (WHERE p.direction = 'CREDIT'), 0) AS credit
+FROM ledger_postings p
+JOIN ledger_accounts a ON a.code = p.account_code
+WHERE p.account_code IN (?)
+GROUP BY p.account_code, a.type, p.currency
+ORDER BY p.account_code, p.currency`
+
+type journalEntryModel struct {
+	ID          uuid.UUID `db:"id"`
+	ReferenceID uuid.UUID `db:"reference_id"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type accountModel struct {
+	Code string `db:"code"`
+	Type string `db:"type"`
+}
+
+type postingModel struct {
+	JournalEntryID uuid.UUID `db:"journal_entry_id"`
+	AccountCode    string    `db:"account_code"`
+	Direction      string    `db:"direction"`
+	Amount         string    `db:"amount"`
+	Currency       string    `db:"currency"`
+}
+
+type balanceModel struct {
+	AccountCode string `db:"account_code"`
+	Type        string `db:"type"`
+	Currency    string `db:"currency"`
+	Debit       string `db:"debit"`
+	Credit      string `db:"credit"`
+}
+
+func (m balanceModel) toDomain() (ledger.Balance, error) {
+	debit, err := money.Parse(m.Debit, m.Currency)
+	if err != nil {
+		return ledger.Balance{}, err
+	}
+
+	credit, err := money.Parse(m.Credit, m.Currency)
+	if err != nil {
+		return ledger.Balance{}, err
+	}
+
+	return ledger.NewBalance(
+		ledger.UnmarshalAccountFromDatabase(m.AccountCode, ledger.AccountType(m.Type)),
+		debit,
+		credit,
+	), nil
+}
+
+type postgresLedgerRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresLedgerRepo) AddJournalEntry(ctx context.Context, entry *ledger.JournalEntry) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(addJournalEntryQuery, journalEntryModel{
+		ID:          entry.ID(),
+		ReferenceID: entry.ReferenceID(),
+		Description: entry.Description(),
+		CreatedAt:   entry.CreatedAt(),
+	})
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert journal entry query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert journal entry",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert journal entry",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			ledger.ErrJournalAlreadyExists,
+			ledger.ErrJournalAlreadyExists.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	accounts := lo.UniqBy(
+		lo.Map(entry.Postings(), func(posting ledger.Posting, _ int) accountModel {
+			return accountModel{
+				Code: posting.Account().Code(),
+				Type: posting.Account().Type().String(),
+			}
+		}),
+		func(account accountModel) string { return account.Code },
+	)
+
+	qry, args, err = executor.BindNamed(addAccountsQuery, accounts)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert ledger accounts query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert ledger accounts",
+			errors.DpayInternalError,
+		)
+	}
+
+	postings := lo.Map(entry.Postings(), func(posting ledger.Posting, _ int) postingModel {
+		return postingModel{
+			JournalEntryID: entry.ID(),
+			AccountCode:    posting.Account().Code(),
+			Direction:      posting.Direction().String(),
+			Amount:         posting.Amount().Decimal(),
+			Currency:       posting.Amount().Currency().String(),
+		}
+	})
+
+	qry, args, err = executor.BindNamed(addPostingsQuery, postings)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert ledger postings query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert ledger postings",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresLedgerRepo) ListBalances(ctx context.Context, accountCodes []string) ([]ledger.Balance, error) {
+	if len(accountCodes) == 0 {
+		return nil, nil
+	}
+
+	qry, args, err := sqlx.In(listBalancesQuery, accountCodes)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to build list ledger balances query",
+			errors.DpayInternalError,
+		)
+	}
+
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	var models []balanceModel
+
+	err = sqlx.SelectContext(ctx, executor, &models, executor.Rebind(qry), args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list ledger balances",
+			errors.DpayInternalError,
+		)
+	}
+
+	balances := make([]ledger.Balance, 0, len(models))
+	for _, model := range models {
+		balance, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+func NewPostgresLedgerRepository(db sqlwrap.Database) ledger.Repository {
+	return &postgresLedgerRepo{
+		db: db,
+	}
+}
@@ -0,0 +1,88 @@
+package adapter
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+type limitsCacheKey struct {
+	merchantID string
+	currency   money.Currency
+}
+
+type limitsCacheEntry struct {
+	limits disburse.Limits
+
+	// err is the not found error of a merchant without limits, it is cached too since that is the common case
+	err       error
+	expiresAt time.Time
+}
+
+// cachedLimitRepo keeps the merchant limits in memory for ttl, so a disbursement does not read them every time.
+// A change is seen right away by this instance and by the other instances once their entry expires.
+type cachedLimitRepo struct {
+	disburse.LimitRepository
+
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[limitsCacheKey]limitsCacheEntry
+}
+
+func (c *cachedLimitRepo) GetMerchantLimits(
+	ctx context.Context,
+	merchantID string,
+	currency money.Currency,
+) (disburse.Limits, error) {
+	key := limitsCacheKey{merchantID: merchantID, currency: currency}
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.limits, entry.err
+	}
+
+	limits, err := c.LimitRepository.GetMerchantLimits(ctx, merchantID, currency)
+	if err != nil && !stderrors.Is(err, disburse.ErrMerchantLimitNotFound) {
+		return disburse.Limits{}, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = limitsCacheEntry{
+		limits:    limits,
+		err:       err,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+
+	return limits, err
+}
+
+func (c *cachedLimitRepo) SetMerchantLimits(ctx context.Context, merchantID string, limits disburse.Limits) error {
+	err := c.LimitRepository.SetMerchantLimits(ctx, merchantID, limits)
+	if err != nil {
+		return err
+	}
+
+	// the entry is dropped rather than replaced, the change may still be rolled back with the transaction of ctx
+	c.mu.Lock()
+	delete(c.entries, limitsCacheKey{merchantID: merchantID, currency: limits.Currency()})
+	c.mu.Unlock()
+
+	return nil
+}
+
+// NewCachedLimitRepository caches the merchant limits read from repo for ttl, the usage is never cached
+func NewCachedLimitRepository(repo disburse.LimitRepository, ttl time.Duration) disburse.LimitRepository {
+	return &cachedLimitRepo{
+		LimitRepository: repo,
+		ttl:             ttl,
+		entries:         make(map[limitsCacheKey]limitsCacheEntry),
+	}
+}
@@ -0,0 +1,108 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var ErrInvalidLimitDefaults = stderrors.New("invalid default disbursement limits config")
+
+// LimitDefaultsConfig is the default limits of a currency in the config, an empty rule is not limited, e.g.
+//
+//	{"IDR": {"per_transaction": "50000000", "daily_total": "500000000", "max_count": 100, "count_window": "1h"}}
+type LimitDefaultsConfig struct {
+	PerTransaction string `json:"per_transaction"`
+	DailyTotal     string `json:"daily_total"`
+	MonthlyTotal   string `json:"monthly_total"`
+	MaxCount       int    `json:"max_count"`
+
+	// CountWindow is a Go duration, e.g. "1h" or "15m"
+	CountWindow string `json:"count_window"`
+}
+
+// configDefaultLimits reads the defaults from the config on every call, so a change of the dynamic config
+// applies to the next disbursement without a restart
+type configDefaultLimits struct {
+	source func() string
+}
+
+// GetDefaultLimits fails on an invalid config instead of not limiting, a broken config must not lift the limits
+func (c configDefaultLimits) GetDefaultLimits(
+	ctx context.Context,
+	currency money.Currency,
+) (disburse.Limits, bool, error) {
+	raw := c.source()
+	if raw == "" {
+		return disburse.Limits{}, false, nil
+	}
+
+	var defaults map[string]LimitDefaultsConfig
+
+	err := json.Unmarshal([]byte(raw), &defaults)
+	if err != nil {
+		return disburse.Limits{}, false, newInvalidLimitDefaultsError(err)
+	}
+
+	conf, ok := defaults[currency.String()]
+	if !ok {
+		return disburse.Limits{}, false, nil
+	}
+
+	limits, err := conf.toDomain(currency)
+	if err != nil {
+		return disburse.Limits{}, false, newInvalidLimitDefaultsError(err)
+	}
+
+	return limits, true, nil
+}
+
+func (c LimitDefaultsConfig) toDomain(currency money.Currency) (disburse.Limits, error) {
+	amounts := make([]money.Money, 0, 3)
+	for _, amount := range []string{c.PerTransaction, c.DailyTotal, c.MonthlyTotal} {
+		if amount == "" {
+			amounts = append(amounts, money.Money{})
+			continue
+		}
+
+		parsed, err := money.Parse(amount, currency.String())
+		if err != nil {
+			return disburse.Limits{}, err
+		}
+
+		amounts = append(amounts, parsed)
+	}
+
+	var countWindow time.Duration
+	if c.CountWindow != "" {
+		window, err := time.ParseDuration(c.CountWindow)
+		if err != nil {
+			return disburse.Limits{}, err
+		}
+
+		countWindow = window
+	}
+
+	return disburse.NewLimits(currency, amounts[0], amounts[1], amounts[2], c.MaxCount, countWindow)
+}
+
+func newInvalidLimitDefaultsError(err error) error {
+	return errors.NewDpayError(
+		err,
+		fmt.Sprintf("%s: %s", ErrInvalidLimitDefaults.Error(), err.Error()),
+		errors.DpayInternalError,
+	)
+}
+
+// NewConfigDefaultLimits reads the default limits from source, the JSON object of currency to LimitDefaultsConfig
+func NewConfigDefaultLimits(source func() string) disburse.DefaultLimits {
+	return configDefaultLimits{
+		source: source,
+	}
+}
@@ -0,0 +1,240 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var getMerchantLimitsQuery = `SELECT merchant_id, currency, per_transaction, daily_total, monthly_total,
+	max_count, count_window_seconds
+FROM merchant_limits
+WHERE merchant_id = $1 AND currency = $2`
+
+var setMerchantLimitsQuery = `INSERT INTO merchant_limits (
+	merchant_id, currency, per_transaction, daily_total, monthly_total, max_count, count_window_seconds,
+	created_at, updated_at
+) VALUES (
+	:merchant_id, :currency, :per_transaction, :daily_total, :monthly_total, :max_count, :count_window_seconds,
+	NOW(), NOW()
+) ON CONFLICT (merchant_id, currency) DO UPDATE SET
+	per_transaction = EXCLUDED.per_transaction,
+	daily_total = EXCLUDED.daily_total,
+	monthly_total = EXCLUDED.monthly_total,
+	max_count = EXCLUDED.max_count,
+	count_window_seconds = EXCLUDED.count_window_seconds,
+	updated_at = NOW()`
+
+// lockMerchantUsageQuery takes a transaction level advisory lock, it is released by the commit or rollback
+var lockMerchantUsageQuery = `SELECT pg_advisory_xact_lock(hashtextextended('merchant_limit_usage:' || $1, 0))`
+
+// getLimitUsageQuery does not count the failed, cancelled and rejected disbursements, their balance is released
+var getLimitUsageQuery = `SELECT
+	COALESCE(SUM(amount) FILTER (WHERE created_at >= $3), 0) AS daily_total,
+	COALESCE(SUM(amount) FILTER (WHERE created_at >= $4), 0) AS monthly_total,
+	COUNT(*) FILTER (WHERE created_at >= $5) AS count
+FROM disbursements
+WHERE merchant_id = $1
+	AND currency = $2
+	AND status NOT IN ('FAILED', 'CANCELLED', 'REJECTED')
+	AND created_at >= LEAST($3::TIMESTAMPTZ, $4::TIMESTAMPTZ, $5::TIMESTAMPTZ)`
+
+type limitsModel struct {
+	MerchantID         string         `db:"merchant_id"`
+	Currency           string         `db:"currency"`
+	PerTransaction     sql.NullString `db:"per_transaction"`
+	DailyTotal         sql.NullString `db:"daily_total"`
+	MonthlyTotal       sql.NullString `db:"monthly_total"`
+	MaxCount           sql.NullInt64  `db:"max_count"`
+	CountWindowSeconds sql.NullInt64  `db:"count_window_seconds"`
+}
+
+func newLimitsModel(merchantID string, l disburse.Limits) limitsModel {
+	return limitsModel{
+		MerchantID:     merchantID,
+		Currency:       l.Currency().String(),
+		PerTransaction: newNullLimitAmount(l.PerTransaction()),
+		DailyTotal:     newNullLimitAmount(l.DailyTotal()),
+		MonthlyTotal:   newNullLimitAmount(l.MonthlyTotal()),
+		MaxCount: sql.NullInt64{
+			Int64: int64(l.MaxCount()),
+			Valid: l.MaxCount() > 0,
+		},
+		CountWindowSeconds: sql.NullInt64{
+			Int64: int64(l.CountWindow() / time.Second),
+			Valid: l.CountWindow() > 0,
+		},
+	}
+}
+
+func (m limitsModel) toDomain() (disburse.Limits, error) {
+	currency, err := money.ParseCurrency(m.Currency)
+	if err != nil {
+		return disburse.Limits{}, err
+	}
+
+	amounts := make([]money.Money, 0, 3)
+	for _, amount := range []sql.NullString{m.PerTransaction, m.DailyTotal, m.MonthlyTotal} {
+		parsed, err := parseNullMoney(amount, sql.NullString{String: m.Currency, Valid: amount.Valid})
+		if err != nil {
+			return disburse.Limits{}, err
+		}
+
+		amounts = append(amounts, parsed)
+	}
+
+	return disburse.UnmarshalLimitsFromDatabase(
+		currency,
+		amounts[0],
+		amounts[1],
+		amounts[2],
+		int(m.MaxCount.Int64),
+		time.Duration(m.CountWindowSeconds.Int64)*time.Second,
+	), nil
+}
+
+// newNullLimitAmount stores the zero Money of a rule that is not limited as NULL
+func newNullLimitAmount(m money.Money) sql.NullString {
+	return sql.NullString{
+		String: m.Decimal(),
+		Valid:  !m.IsZero(),
+	}
+}
+
+type limitUsageModel struct {
+	DailyTotal   string `db:"daily_total"`
+	MonthlyTotal string `db:"monthly_total"`
+	Count        int    `db:"count"`
+}
+
+type postgresLimitRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresLimitRepo) GetMerchantLimits(
+	ctx context.Context,
+	merchantID string,
+	currency money.Currency,
+) (disburse.Limits, error) {
+	var model limitsModel
+
+	err := sqlx.GetContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&model,
+		getMerchantLimitsQuery,
+		merchantID,
+		currency.String(),
+	)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return disburse.Limits{}, errors.NewNotFoundError(
+			disburse.ErrMerchantLimitNotFound,
+			disburse.ErrMerchantLimitNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return disburse.Limits{}, errors.NewDatabaseError(
+			err,
+			"failed to get merchant limits",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresLimitRepo) SetMerchantLimits(ctx context.Context, merchantID string, limits disburse.Limits) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(setMerchantLimitsQuery, newLimitsModel(merchantID, limits))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for set merchant limits query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to set merchant limits",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresLimitRepo) LockMerchantUsage(ctx context.Context, merchantID string) error {
+	_, err := sqlwrap.ExecutorFromContext(ctx, p.db).ExecContext(ctx, lockMerchantUsageQuery, merchantID)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to lock merchant limit usage",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresLimitRepo) GetLimitUsage(
+	ctx context.Context,
+	merchantID string,
+	currency money.Currency,
+	windows disburse.LimitWindows,
+) (disburse.LimitUsage, error) {
+	var model limitUsageModel
+
+	err := sqlx.GetContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&model,
+		getLimitUsageQuery,
+		merchantID,
+		currency.String(),
+		windows.DayStart,
+		windows.MonthStart,
+		windows.CountStart,
+	)
+	if err != nil {
+		return disburse.LimitUsage{}, errors.NewDatabaseError(
+			err,
+			"failed to get merchant limit usage",
+			errors.DpayInternalError,
+		)
+	}
+
+	dailyTotal, err := money.Parse(model.DailyTotal, currency.String())
+	if err != nil {
+		return disburse.LimitUsage{}, err
+	}
+
+	monthlyTotal, err := money.Parse(model.MonthlyTotal, currency.String())
+	if err != nil {
+		return disburse.LimitUsage{}, err
+	}
+
+	return disburse.LimitUsage{
+		DailyTotal:   dailyTotal,
+		MonthlyTotal: monthlyTotal,
+		Count:        model.Count,
+	}, nil
+}
+
+func NewPostgresLimitRepository(db sqlwrap.Database) disburse.LimitRepository {
+	return &postgresLimitRepo{
+		db: db,
+	}
+}
@@ -0,0 +1,98 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/durianpay/dpay-common/proto/client"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMerchantBalance is the balance API of the merchant service, a reservation is referenced
+// by the disbursement id so the calls are idempotent
+type grpcMerchantBalance struct {
+	merchantClient *client.MerchantServiceClient
+}
+
+func (g grpcMerchantBalance) Reserve(
+	ctx context.Context,
+	merchantID string,
+	disbursementID uuid.UUID,
+	amount money.Money,
+) error {
+	_, err := g.merchantClient.Client.ReserveBalance(ctx, &client.ReserveBalanceRequest{
+		MerchantId:  merchantID,
+		ReferenceId: disbursementID.String(),
+		Amount:      amount.Decimal(),
+		Currency:    amount.Currency().String(),
+	})
+	if err != nil {
+		return mapMerchantBalanceError(err, "failed to reserve merchant balance")
+	}
+
+	return nil
+}
+
+func (g grpcMerchantBalance) Release(
+	ctx context.Context,
+	merchantID string,
+	disbursementID uuid.UUID,
+) error {
+	_, err := g.merchantClient.Client.ReleaseBalance(ctx, &client.ReleaseBalanceRequest{
+		MerchantId:  merchantID,
+		ReferenceId: disbursementID.String(),
+	})
+	if err != nil {
+		return mapMerchantBalanceError(err, "failed to release merchant balance")
+	}
+
+	return nil
+}
+
+func (g grpcMerchantBalance) Refund(
+	ctx context.Context,
+	merchantID string,
+	reversalID uuid.UUID,
+	amount money.Money,
+) error {
+	_, err := g.merchantClient.Client.RefundBalance(ctx, &client.RefundBalanceRequest{
+		MerchantId:  merchantID,
+		ReferenceId: reversalID.String(),
+		Amount:      amount.Decimal(),
+		Currency:    amount.Currency().String(),
+	})
+	if err != nil {
+		return mapMerchantBalanceError(err, "failed to refund merchant balance")
+	}
+
+	return nil
+}
+
+// mapMerchantBalanceError converts the merchant service status, FailedPrecondition means the balance is not sufficient
+func mapMerchantBalanceError(err error, message string) error {
+	if status.Code(err) == codes.FailedPrecondition {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrInsufficientBalance,
+			disburse.ErrInsufficientBalance.Error(),
+			errors.DpayInsufficientBalance,
+		)
+	}
+
+	return errors.NewCustomDpayError(
+		err,
+		message+": "+grpcerr.GetErrorMessage(err),
+		errors.DpayMerchantServiceError,
+		grpcerr.GetErrorType(err),
+	)
+}
+
+func NewGRPCMerchantBalance(merchantClient *client.MerchantServiceClient) disburse.MerchantBalance {
+	return &grpcMerchantBalance{
+		merchantClient: merchantClient,
+	}
+}
@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+const (
+	// stubUnknownAccountSuffix lets a local account number ending with it play an account unknown to the bank
+	stubUnknownAccountSuffix = "0000"
+
+	stubHolderNamePrefix = "STUB HOLDER"
+)
+
+// nameInquiryStub answers the name inquiry in process, so beneficiaries can be registered locally without a bank
+type nameInquiryStub struct {
+	// holderNames are the holder names of the known accounts keyed by "<bank code>:<account number>"
+	holderNames map[string]string
+}
+
+func (s nameInquiryStub) InquireHolderName(_ context.Context, bankAccount disburse.BankAccount) (string, error) {
+	if name, ok := s.holderNames[bankAccount.BankCode()+":"+bankAccount.AccountNumber()]; ok {
+		return name, nil
+	}
+
+	if strings.HasSuffix(bankAccount.AccountNumber(), stubUnknownAccountSuffix) {
+		return "", errors.NewUnprocessableEntityError(
+			disburse.ErrBankAccountNotFound,
+			fmt.Sprintf(
+				"%s, account %s at %s",
+				disburse.ErrBankAccountNotFound.Error(), bankAccount.AccountNumber(), bankAccount.BankCode(),
+			),
+			errors.DpayBankAccountNotFound,
+		)
+	}
+
+	// any other account exists with a name derived from its number
+	return stubHolderNamePrefix + " " + bankAccount.AccountNumber(), nil
+}
+
+// NewNameInquiryStub returns a NameInquiry answering holderNames for the known accounts keyed by
+// "<bank code>:<account number>", an account number ending with 0000 is unknown to the bank and
+// any other account is held by "STUB HOLDER <account number>"
+func NewNameInquiryStub(holderNames map[string]string) disburse.NameInquiry {
+	return nameInquiryStub{
+		holderNames: holderNames,
+	}
+}
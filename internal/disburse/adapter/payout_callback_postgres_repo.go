@@ -0,0 +1,94 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+// addPayoutCallbackQuery ignores conflict on provider and event id, the caller checks the affected rows
+var addPayoutCallbackQuery = `INSERT INTO payout_callbacks (
+	provider, event_id, disbursement_id, status, failure_reason, received_at
+) VALUES (
+	:provider, :event_id, :disbursement_id, :status, :failure_reason, :received_at
+) ON CONFLICT DO NOTHING`
+
+type payoutCallbackModel struct {
+	Provider       string         `db:"provider"`
+	EventID        string         `db:"event_id"`
+	DisbursementID uuid.UUID      `db:"disbursement_id"`
+	Status         string         `db:"status"`
+	FailureReason  sql.NullString `db:"failure_reason"`
+	ReceivedAt     time.Time      `db:"received_at"`
+}
+
+type postgresCallbackRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresCallbackRepo) AddCallback(
+	ctx context.Context,
+	provider string,
+	eventID string,
+	result disburse.PayoutResult,
+) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(addPayoutCallbackQuery, payoutCallbackModel{
+		Provider:       provider,
+		EventID:        eventID,
+		DisbursementID: result.DisbursementID,
+		Status:         result.Status.String(),
+		FailureReason: sql.NullString{
+			String: result.FailureReason,
+			Valid:  result.FailureReason != "",
+		},
+		ReceivedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert payout callback query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert payout callback",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of insert payout callback",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrCallbackAlreadyReceived,
+			disburse.ErrCallbackAlreadyReceived.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+func NewPostgresCallbackRepository(db sqlwrap.Database) disburse.CallbackRepository {
+	return &postgresCallbackRepo{
+		db: db,
+	}
+}
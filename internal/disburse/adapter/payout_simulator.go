@@ -0,0 +1,132 @@
+package adapter
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+const simulatedFailureReason = "simulated payout failure"
+
+type PayoutSimulatorConfig struct {
+	// Latency is how long a payout call takes
+	Latency time.Duration
+
+	// FailureRate is the probability between 0 and 1 that a payout fails
+	FailureRate float64
+
+	// CallbackDelay is how long after the payout call the result is sent to the callback,
+	// zero settles the payout in the payout call itself
+	CallbackDelay time.Duration
+}
+
+// payoutSimulator settles payouts in process, so the full disbursement flow runs locally without a bank.
+// The callbacks still waiting for their delay are sent right away by Close, so stopping the process does not
+// leave their disbursements in PROCESSING.
+type payoutSimulator struct {
+	config   PayoutSimulatorConfig
+	callback disburse.PayoutCallback
+
+	closing   chan struct{}
+	closeOnce sync.Once
+	callbacks sync.WaitGroup
+}
+
+func (s *payoutSimulator) Payout(
+	ctx context.Context,
+	disbursement *disburse.Disbursement,
+) (disburse.PayoutResult, error) {
+	select {
+	case <-time.After(s.config.Latency):
+	case <-ctx.Done():
+		return disburse.PayoutResult{}, errors.NewContextCancelledError(
+			ctx.Err(),
+			"payout was cancelled",
+			errors.DpayCancelled,
+		)
+	}
+
+	failed := rand.Float64() < s.config.FailureRate
+
+	if s.config.CallbackDelay <= 0 {
+		if failed {
+			return disburse.PayoutResult{}, errors.NewUnprocessableEntityError(
+				disburse.ErrPayoutRejected,
+				simulatedFailureReason,
+				errors.DpayPayoutRejected,
+			)
+		}
+
+		return disburse.PayoutResult{
+			DisbursementID: disbursement.ID(),
+			Status:         disburse.StatusSuccess,
+		}, nil
+	}
+
+	result := disburse.PayoutResult{
+		DisbursementID: disbursement.ID(),
+		Status:         disburse.StatusSuccess,
+	}
+
+	if failed {
+		result.Status = disburse.StatusFailed
+		result.FailureReason = simulatedFailureReason
+	}
+
+	// the callback outlives the payout call, keep the trace and request id but not the cancellation
+	s.callbacks.Add(1)
+	go s.sendCallback(context.WithoutCancel(ctx), result)
+
+	return disburse.PayoutResult{
+		DisbursementID: disbursement.ID(),
+		Status:         disburse.StatusProcessing,
+	}, nil
+}
+
+func (s *payoutSimulator) sendCallback(ctx context.Context, result disburse.PayoutResult) {
+	defer s.callbacks.Done()
+
+	timer := time.NewTimer(s.config.CallbackDelay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-s.closing:
+	}
+
+	err := s.callback(ctx, result)
+	if err != nil {
+		logger.Errorw(
+			ctx,
+			"failed to handle simulated payout callback",
+			"disbursement_id", result.DisbursementID.String(),
+			"status", result.Status.String(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// Close sends the pending callbacks without waiting for their delay and returns once they are handled,
+// it must be called before the callback dependencies, e.g. the database, are closed
+func (s *payoutSimulator) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+	})
+
+	s.callbacks.Wait()
+
+	return nil
+}
+
+func NewPayoutSimulator(config PayoutSimulatorConfig, callback disburse.PayoutCallback) disburse.PayoutProvider {
+	return &payoutSimulator{
+		config:   config,
+		callback: callback,
+		closing:  make(chan struct{}),
+	}
+}
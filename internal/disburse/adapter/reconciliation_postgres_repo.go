@@ -0,0 +1,288 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+	"github.com/samber/lo"
+)
+
+// discrepanciesInsertChunk keeps a single insert of discrepancies below the postgres limit of bind parameters
+const discrepanciesInsertChunk = 1000
+
+var createReconciliationRunQuery = `INSERT INTO reconciliation_runs (
+	id, provider, file_name, period_start, period_end, line_count, matched_count, discrepancy_count, created_at
+) VALUES (
+	:id, :provider, :file_name, :period_start, :period_end, :line_count, :matched_count, :discrepancy_count, :created_at
+)`
+
+var createDiscrepanciesQuery = `INSERT INTO reconciliation_discrepancies (
+	run_id, position, type, reference, disbursement_id, merchant_id, line_number,
+	expected_amount, expected_currency, settled_amount, settled_currency, detail
+) VALUES (
+	:run_id, :position, :type, :reference, :disbursement_id, :merchant_id, :line_number,
+	:expected_amount, :expected_currency, :settled_amount, :settled_currency, :detail
+)`
+
+var getReconciliationRunQuery = `SELECT id, provider, file_name, period_start, period_end,
+	line_count, matched_count, discrepancy_count, created_at
+FROM reconciliation_runs
+WHERE id = $1`
+
+// listDiscrepanciesQuery does not limit the merchant when $2 is empty
+var listDiscrepanciesQuery = `SELECT run_id, position, type, reference, disbursement_id, merchant_id, line_number,
+	expected_amount, expected_currency, settled_amount, settled_currency, detail
+FROM reconciliation_discrepancies
+WHERE run_id = $1 AND ($2 = '' OR merchant_id = $2)
+ORDER BY position`
+
+type reconciliationRunModel struct {
+	ID               uuid.UUID `db:"id"`
+	Provider         string    `db:"provider"`
+	FileName         string    `db:"file_name"`
+	PeriodStart      time.Time `db:"period_start"`
+	PeriodEnd        time.Time `db:"period_end"`
+	LineCount        int       `db:"line_count"`
+	MatchedCount     int       `db:"matched_count"`
+	DiscrepancyCount int       `db:"discrepancy_count"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+func newReconciliationRunModel(r *disburse.ReconciliationRun) reconciliationRunModel {
+	return reconciliationRunModel{
+		ID:               r.ID(),
+		Provider:         r.Provider(),
+		FileName:         r.FileName(),
+		PeriodStart:      r.PeriodStart(),
+		PeriodEnd:        r.PeriodEnd(),
+		LineCount:        r.LineCount(),
+		MatchedCount:     r.MatchedCount(),
+		DiscrepancyCount: r.DiscrepancyCount(),
+		CreatedAt:        r.CreatedAt(),
+	}
+}
+
+func (m reconciliationRunModel) toDomain() *disburse.ReconciliationRun {
+	return disburse.UnmarshalReconciliationRunFromDatabase(
+		m.ID,
+		m.Provider,
+		m.FileName,
+		m.PeriodStart,
+		m.PeriodEnd,
+		m.LineCount,
+		m.MatchedCount,
+		m.DiscrepancyCount,
+		m.CreatedAt,
+	)
+}
+
+type discrepancyModel struct {
+	RunID            uuid.UUID      `db:"run_id"`
+	Position         int            `db:"position"`
+	Type             string         `db:"type"`
+	Reference        string         `db:"reference"`
+	DisbursementID   uuid.NullUUID  `db:"disbursement_id"`
+	MerchantID       sql.NullString `db:"merchant_id"`
+	LineNumber       sql.NullInt64  `db:"line_number"`
+	ExpectedAmount   sql.NullString `db:"expected_amount"`
+	ExpectedCurrency sql.NullString `db:"expected_currency"`
+	SettledAmount    sql.NullString `db:"settled_amount"`
+	SettledCurrency  sql.NullString `db:"settled_currency"`
+	Detail           string         `db:"detail"`
+}
+
+func newDiscrepancyModel(runID uuid.UUID, position int, d *disburse.Discrepancy) discrepancyModel {
+	expectedAmount, expectedCurrency := newNullMoney(d.ExpectedAmount())
+	settledAmount, settledCurrency := newNullMoney(d.SettledAmount())
+
+	return discrepancyModel{
+		RunID:     runID,
+		Position:  position,
+		Type:      d.Type().String(),
+		Reference: d.Reference(),
+		DisbursementID: uuid.NullUUID{
+			UUID:  d.DisbursementID(),
+			Valid: d.DisbursementID() != uuid.Nil,
+		},
+		MerchantID: sql.NullString{
+			String: d.MerchantID(),
+			Valid:  d.MerchantID() != "",
+		},
+		LineNumber: sql.NullInt64{
+			Int64: int64(d.LineNumber()),
+			Valid: d.LineNumber() != 0,
+		},
+		ExpectedAmount:   expectedAmount,
+		ExpectedCurrency: expectedCurrency,
+		SettledAmount:    settledAmount,
+		SettledCurrency:  settledCurrency,
+		Detail:           d.Detail(),
+	}
+}
+
+func (m discrepancyModel) toDomain() (*disburse.Discrepancy, error) {
+	expectedAmount, err := parseNullMoney(m.ExpectedAmount, m.ExpectedCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	settledAmount, err := parseNullMoney(m.SettledAmount, m.SettledCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return disburse.UnmarshalDiscrepancyFromDatabase(
+		disburse.DiscrepancyType(m.Type),
+		m.Reference,
+		m.DisbursementID.UUID,
+		m.MerchantID.String,
+		int(m.LineNumber.Int64),
+		expectedAmount,
+		settledAmount,
+		m.Detail,
+	), nil
+}
+
+// newNullMoney stores the zero Money a discrepancy uses for a missing amount as NULL
+func newNullMoney(m money.Money) (sql.NullString, sql.NullString) {
+	valid := m.Currency().IsValid()
+
+	return sql.NullString{String: m.Decimal(), Valid: valid}, sql.NullString{String: m.Currency().String(), Valid: valid}
+}
+
+func parseNullMoney(amount sql.NullString, currency sql.NullString) (money.Money, error) {
+	if !amount.Valid || !currency.Valid {
+		return money.Money{}, nil
+	}
+
+	return money.Parse(amount.String, currency.String)
+}
+
+type postgresReconciliationRepo struct {
+	db      sqlwrap.Database
+	manager sqlwrap.ManagerInterface
+}
+
+func (p *postgresReconciliationRepo) CreateRun(
+	ctx context.Context,
+	run *disburse.ReconciliationRun,
+	discrepancies []*disburse.Discrepancy,
+) error {
+	return p.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+		qry, args, err := executor.BindNamed(createReconciliationRunQuery, newReconciliationRunModel(run))
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to bind named for insert reconciliation run query",
+				errors.DpayInternalError,
+			)
+		}
+
+		_, err = executor.ExecContext(ctx, qry, args...)
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to insert reconciliation run",
+				errors.DpayInternalError,
+			)
+		}
+
+		models := lo.Map(discrepancies, func(discrepancy *disburse.Discrepancy, position int) discrepancyModel {
+			return newDiscrepancyModel(run.ID(), position, discrepancy)
+		})
+
+		for _, chunk := range lo.Chunk(models, discrepanciesInsertChunk) {
+			qry, args, err := executor.BindNamed(createDiscrepanciesQuery, chunk)
+			if err != nil {
+				return errors.NewDatabaseError(
+					err,
+					"failed to bind named for insert reconciliation discrepancies query",
+					errors.DpayInternalError,
+				)
+			}
+
+			_, err = executor.ExecContext(ctx, qry, args...)
+			if err != nil {
+				return errors.NewDatabaseError(
+					err,
+					"failed to insert reconciliation discrepancies",
+					errors.DpayInternalError,
+				)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *postgresReconciliationRepo) GetRun(ctx context.Context, id uuid.UUID) (*disburse.ReconciliationRun, error) {
+	var model reconciliationRunModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getReconciliationRunQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			disburse.ErrReconciliationNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get reconciliation run",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain(), nil
+}
+
+func (p *postgresReconciliationRepo) ListDiscrepancies(
+	ctx context.Context,
+	runID uuid.UUID,
+	merchantID string,
+) ([]*disburse.Discrepancy, error) {
+	var models []discrepancyModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, listDiscrepanciesQuery, runID, merchantID)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to list reconciliation discrepancies",
+			errors.DpayInternalError,
+		)
+	}
+
+	discrepancies := make([]*disburse.Discrepancy, 0, len(models))
+	for _, model := range models {
+		discrepancy, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		discrepancies = append(discrepancies, discrepancy)
+	}
+
+	return discrepancies, nil
+}
+
+func NewPostgresReconciliationRepository(
+	db sqlwrap.Database,
+	manager sqlwrap.ManagerInterface,
+) disburse.ReconciliationRepository {
+	return &postgresReconciliationRepo{
+		db:      db,
+		manager: manager,
+	}
+}
@@ -0,0 +1,262 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var scheduleColumns = `id, merchant_id, amount, currency, recurrence, end_at, status,
+	next_run_at, last_run_at, run_count, last_failure_reason, created_at, updated_at`
+
+var createScheduleQuery = `INSERT INTO scheduled_disbursements (
+	id, merchant_id, amount, currency, recurrence, end_at, status,
+	next_run_at, last_run_at, run_count, last_failure_reason, created_at, updated_at
+) VALUES (
+	:id, :merchant_id, :amount, :currency, :recurrence, :end_at, :status,
+	:next_run_at, :last_run_at, :run_count, :last_failure_reason, :created_at, :updated_at
+)`
+
+var getScheduleQuery = `SELECT ` + scheduleColumns + `
+FROM scheduled_disbursements
+WHERE id = $1`
+
+// claimDueSchedulesQuery locks the due rows with SKIP LOCKED so concurrent schedulers never claim the same row,
+// the claim is kept in claimed_until so the row lock does not have to be held while the schedule runs
+var claimDueSchedulesQuery = `UPDATE scheduled_disbursements SET
+	claimed_until = $2
+WHERE id IN (
+	SELECT id
+	FROM scheduled_disbursements
+	WHERE status = 'ACTIVE'
+		AND next_run_at <= $1
+		AND (claimed_until IS NULL OR claimed_until < $1)
+	ORDER BY next_run_at
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING ` + scheduleColumns
+
+// updateScheduleQuery only updates an active schedule, so a run finishing after a cancellation
+// does not bring the schedule back
+var updateScheduleQuery = `UPDATE scheduled_disbursements SET
+	status = :status,
+	next_run_at = :next_run_at,
+	last_run_at = :last_run_at,
+	run_count = :run_count,
+	last_failure_reason = :last_failure_reason,
+	claimed_until = NULL,
+	updated_at = :updated_at
+WHERE id = :id AND status = 'ACTIVE'`
+
+type scheduleModel struct {
+	ID                uuid.UUID      `db:"id"`
+	MerchantID        string         `db:"merchant_id"`
+	Amount            string         `db:"amount"`
+	Currency          string         `db:"currency"`
+	Recurrence        sql.NullString `db:"recurrence"`
+	EndAt             sql.NullTime   `db:"end_at"`
+	Status            string         `db:"status"`
+	NextRunAt         time.Time      `db:"next_run_at"`
+	LastRunAt         sql.NullTime   `db:"last_run_at"`
+	RunCount          int            `db:"run_count"`
+	LastFailureReason sql.NullString `db:"last_failure_reason"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+func newScheduleModel(s *disburse.ScheduledDisbursement) scheduleModel {
+	return scheduleModel{
+		ID:         s.ID(),
+		MerchantID: s.MerchantID(),
+		Amount:     s.Amount().Decimal(),
+		Currency:   s.Amount().Currency().String(),
+		Recurrence: sql.NullString{
+			String: s.Recurrence(),
+			Valid:  s.Recurrence() != "",
+		},
+		EndAt: sql.NullTime{
+			Time:  s.EndAt(),
+			Valid: !s.EndAt().IsZero(),
+		},
+		Status:    s.Status().String(),
+		NextRunAt: s.NextRunAt(),
+		LastRunAt: sql.NullTime{
+			Time:  s.LastRunAt(),
+			Valid: !s.LastRunAt().IsZero(),
+		},
+		RunCount: s.RunCount(),
+		LastFailureReason: sql.NullString{
+			String: s.LastFailureReason(),
+			Valid:  s.LastFailureReason() != "",
+		},
+		CreatedAt: s.CreatedAt(),
+		UpdatedAt: s.UpdatedAt(),
+	}
+}
+
+func (m scheduleModel) toDomain() (*disburse.ScheduledDisbursement, error) {
+	amount, err := money.Parse(m.Amount, m.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return disburse.UnmarshalScheduledDisbursementFromDatabase(
+		m.ID,
+		m.MerchantID,
+		amount,
+		m.Recurrence.String,
+		m.EndAt.Time,
+		disburse.ScheduleStatus(m.Status),
+		m.NextRunAt,
+		m.LastRunAt.Time,
+		m.RunCount,
+		m.LastFailureReason.String,
+		m.CreatedAt,
+		m.UpdatedAt,
+	), nil
+}
+
+type postgresScheduleRepo struct {
+	db sqlwrap.Database
+}
+
+func (p *postgresScheduleRepo) CreateSchedule(ctx context.Context, schedule *disburse.ScheduledDisbursement) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(createScheduleQuery, newScheduleModel(schedule))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for insert schedule query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to insert scheduled disbursement",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
+
+func (p *postgresScheduleRepo) GetSchedule(ctx context.Context, id uuid.UUID) (*disburse.ScheduledDisbursement, error) {
+	var model scheduleModel
+
+	err := sqlx.GetContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &model, getScheduleQuery, id)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return nil, errors.NewNotFoundError(
+			err,
+			"scheduled disbursement not found",
+			errors.DpayNotFound,
+		)
+	}
+
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to get scheduled disbursement",
+			errors.DpayInternalError,
+		)
+	}
+
+	return model.toDomain()
+}
+
+func (p *postgresScheduleRepo) ClaimDueSchedules(
+	ctx context.Context,
+	now time.Time,
+	claimUntil time.Time,
+	limit int,
+) ([]*disburse.ScheduledDisbursement, error) {
+	var models []scheduleModel
+
+	err := sqlx.SelectContext(
+		ctx,
+		sqlwrap.ExecutorFromContext(ctx, p.db),
+		&models,
+		claimDueSchedulesQuery,
+		now,
+		claimUntil,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to claim due scheduled disbursements",
+			errors.DpayInternalError,
+		)
+	}
+
+	schedules := make([]*disburse.ScheduledDisbursement, 0, len(models))
+	for _, model := range models {
+		schedule, err := model.toDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+func (p *postgresScheduleRepo) UpdateSchedule(ctx context.Context, schedule *disburse.ScheduledDisbursement) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := executor.BindNamed(updateScheduleQuery, newScheduleModel(schedule))
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to bind named for update schedule query",
+			errors.DpayInternalError,
+		)
+	}
+
+	res, err := executor.ExecContext(ctx, qry, args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to update scheduled disbursement",
+			errors.DpayInternalError,
+		)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to get affected rows of update scheduled disbursement",
+			errors.DpayInternalError,
+		)
+	}
+
+	if affected == 0 {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrScheduleNotActive,
+			disburse.ErrScheduleNotActive.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+func NewPostgresScheduleRepository(db sqlwrap.Database) disburse.ScheduleRepository {
+	return &postgresScheduleRepo{
+		db: db,
+	}
+}
@@ -0,0 +1,158 @@
+package adapter
+
+import (
+	"context"
+	"encoding/csv"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrMissingSettlementColumns = stderrors.New("settlement report is missing columns")
+	ErrReadingSettlementReport  = stderrors.New("failed to read settlement report")
+)
+
+// CSVSettlementColumns names the header columns of a CSV settlement report, the header is case insensitive
+type CSVSettlementColumns struct {
+	Reference string
+	Amount    string
+	Currency  string
+}
+
+// csvSettlementParser reads a settlement report with a header row, the line number counts the header as line 1
+type csvSettlementParser struct {
+	columns CSVSettlementColumns
+}
+
+func (c csvSettlementParser) Parse(ctx context.Context, report io.Reader) ([]disburse.SettlementLine, error) {
+	reader := csv.NewReader(report)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil && !stderrors.Is(err, io.EOF) {
+		return nil, newReadingSettlementReportError(err)
+	}
+
+	columnIndex, err := c.indexColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lines     []disburse.SettlementLine
+		errInfos  []api.ErrorInfo
+		lineField = func(number int, column string) string { return fmt.Sprintf("lines[%d].%s", number, column) }
+	)
+
+	for number := 2; ; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.NewContextCancelledError(
+				err,
+				"reading settlement report was cancelled",
+				errors.DpayCancelled,
+			)
+		}
+
+		cells, err := reader.Read()
+		if stderrors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, newReadingSettlementReportError(err)
+		}
+
+		if lo.EveryBy(cells, func(cell string) bool { return strings.TrimSpace(cell) == "" }) {
+			continue
+		}
+
+		cell := func(column string) string {
+			index := columnIndex[strings.ToLower(column)]
+			if index >= len(cells) {
+				return ""
+			}
+
+			return strings.TrimSpace(cells[index])
+		}
+
+		reference := cell(c.columns.Reference)
+		if reference == "" {
+			errInfos = append(errInfos, api.ErrorInfo{Field: lineField(number, "reference"), Message: "reference can not be empty"})
+			continue
+		}
+
+		amount, err := money.Parse(cell(c.columns.Amount), cell(c.columns.Currency))
+		if err != nil {
+			errInfos = append(errInfos, api.ErrorInfo{Field: lineField(number, "amount"), Message: err.Error()})
+			continue
+		}
+
+		lines = append(lines, disburse.SettlementLine{
+			Number:    number,
+			Reference: reference,
+			Amount:    amount,
+		})
+	}
+
+	if len(errInfos) > 0 {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrInvalidSettlementLines,
+			fmt.Sprintf("%s: %d invalid lines", disburse.ErrInvalidSettlementLines.Error(), len(errInfos)),
+			errors.DpayInvalidRequest,
+			errInfos...,
+		)
+	}
+
+	return lines, nil
+}
+
+func (c csvSettlementParser) indexColumns(header []string) (map[string]int, error) {
+	columnIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		columnIndex[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	missing := lo.Filter(
+		[]string{c.columns.Reference, c.columns.Amount, c.columns.Currency},
+		func(column string, _ int) bool {
+			_, ok := columnIndex[strings.ToLower(column)]
+			return !ok
+		},
+	)
+
+	if len(missing) > 0 {
+		return nil, errors.NewIncorrectInputError(
+			ErrMissingSettlementColumns,
+			fmt.Sprintf("%s: %s", ErrMissingSettlementColumns.Error(), strings.Join(missing, ", ")),
+			errors.DpayInvalidRequest,
+			lo.Map(missing, func(column string, _ int) api.ErrorInfo {
+				return api.ErrorInfo{Field: column, Message: "column is missing in the header"}
+			})...,
+		)
+	}
+
+	return columnIndex, nil
+}
+
+func newReadingSettlementReportError(err error) error {
+	return errors.NewIncorrectInputError(
+		err,
+		fmt.Sprintf("%s: %s", ErrReadingSettlementReport.Error(), err.Error()),
+		errors.DpayInvalidRequest,
+	)
+}
+
+func NewCSVSettlementParser(columns CSVSettlementColumns) disburse.SettlementParser {
+	return csvSettlementParser{
+		columns: columns,
+	}
+}
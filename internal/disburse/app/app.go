@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/durianpay/dpay-common/proto/client"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
 	"go.uber.org/zap"
 )
@@ -20,8 +21,63 @@ type Dependencies struct {
 }
 
 type Commands struct {
-	Disburse command.DisburseHandler
+	Disburse                 command.DisburseHandler
+	DisburseBatch            command.DisburseBatchHandler
+	UploadDisbursements      command.UploadDisbursementsHandler
+	ProcessUploads           command.ProcessUploadsHandler
+	UpdateDisbursementStatus command.UpdateDisbursementStatusHandler
+	HandlePayoutCallback     command.HandlePayoutCallbackHandler
+	DispatchPayouts          command.DispatchPayoutsHandler
+
+	CancelDisbursement  command.CancelDisbursementHandler
+	ReverseDisbursement command.ReverseDisbursementHandler
+
+	ApproveDisbursement command.ApproveDisbursementHandler
+	RejectDisbursement  command.RejectDisbursementHandler
+	ExpireApprovals     command.ExpireApprovalsHandler
+	SetApprovalPolicy   command.SetApprovalPolicyHandler
+
+	ScheduleDisbursement        command.ScheduleDisbursementHandler
+	CancelScheduledDisbursement command.CancelScheduledDisbursementHandler
+	RunDueSchedules             command.RunDueSchedulesHandler
+
+	ReconcileSettlement command.ReconcileSettlementHandler
+
+	SetMerchantLimits command.SetMerchantLimitsHandler
+
+	CreateFeeSchedule command.CreateFeeScheduleHandler
+
+	CreateFXQuote command.CreateFXQuoteHandler
+
+	CreateBeneficiary command.CreateBeneficiaryHandler
+	UpdateBeneficiary command.UpdateBeneficiaryHandler
+	DeleteBeneficiary command.DeleteBeneficiaryHandler
 }
 
 type Queries struct {
+	GetDisbursement   query.GetDisbursementHandler
+	ListDisbursements query.ListDisbursementsHandler
+
+	GetDisbursementBatch  query.GetDisbursementBatchHandler
+	GetDisbursementUpload query.GetDisbursementUploadHandler
+
+	GetScheduledDisbursement query.GetScheduledDisbursementHandler
+
+	GetMerchantLedgerBalances query.GetMerchantLedgerBalancesHandler
+	GetLedgerAccountBalances  query.GetLedgerAccountBalancesHandler
+
+	GetReconciliationRun query.GetReconciliationRunHandler
+
+	GetMerchantLimits query.GetMerchantLimitsHandler
+
+	GetFeeSchedule query.GetFeeScheduleHandler
+	PreviewFee     query.PreviewFeeHandler
+
+	GetFXQuote query.GetFXQuoteHandler
+
+	GetApprovalPolicy        query.GetApprovalPolicyHandler
+	GetDisbursementApprovals query.GetDisbursementApprovalsHandler
+
+	GetBeneficiary    query.GetBeneficiaryHandler
+	ListBeneficiaries query.ListBeneficiariesHandler
 }
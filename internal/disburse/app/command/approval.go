@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+// approvalChecker parks the new disbursements that need approval by the policy of their merchant
+type approvalChecker struct {
+	approvalRepo disburse.ApprovalRepository
+}
+
+// requestApprovals moves every disbursement above the approval threshold into AWAITING_APPROVAL before it is stored
+// and returns the first steps of their approval chains, they are added with addSteps once the disbursements are stored
+func (c approvalChecker) requestApprovals(
+	ctx context.Context,
+	merchantID string,
+	createdBy string,
+	disbursements []*disburse.Disbursement,
+) ([]*disburse.ApprovalStep, error) {
+	if createdBy == "" {
+		createdBy = merchantID
+	}
+
+	var (
+		policies = make(map[money.Currency]*disburse.ApprovalPolicy)
+		steps    []*disburse.ApprovalStep
+	)
+
+	for _, disbursement := range disbursements {
+		currency := disbursement.Amount().Currency()
+
+		policy, ok := policies[currency]
+		if !ok {
+			found, err := c.approvalRepo.GetApprovalPolicy(ctx, merchantID, currency)
+			if err != nil && !stderrors.Is(err, disburse.ErrApprovalPolicyNotFound) {
+				return nil, err
+			}
+
+			// a currency without policy needs no approval
+			if err == nil {
+				policy = &found
+			}
+
+			policies[currency] = policy
+		}
+
+		if policy == nil || !policy.Requires(disbursement.Amount()) {
+			continue
+		}
+
+		step, err := disbursement.RequestApproval(*policy, createdBy)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+// addSteps must run inside the transaction that stores the disbursements of the steps
+func (c approvalChecker) addSteps(ctx context.Context, steps []*disburse.ApprovalStep) error {
+	for _, step := range steps {
+		err := c.approvalRepo.AddApprovalStep(ctx, step)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
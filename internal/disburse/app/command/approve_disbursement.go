@@ -0,0 +1,103 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type ApproveDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the approval to the disbursements of the merchant, empty is not limited
+	MerchantID string
+
+	// ApproverID is the user approving with ApproverRole, the role must be an approver role of the merchant
+	ApproverID   string
+	ApproverRole string
+}
+
+type ApproveDisbursementHandler decorator.CommandHandler[*ApproveDisbursementParam]
+
+type approveDisbursementHandler struct {
+	disburseRepo disburse.DisburseRepository
+	approvalRepo disburse.ApprovalRepository
+}
+
+// Handle adds the approval to the approval chain of the disbursement,
+// the disbursement moves to PENDING for the DispatchPayouts command to send it out once it has all the approvals
+// it needs
+func (h approveDisbursementHandler) Handle(
+	ctx context.Context,
+	r *ApproveDisbursementParam,
+) error {
+	err := h.disburseRepo.UpdateDisbursement(
+		ctx,
+		r.ID,
+		func(ctx context.Context, stored *disburse.Disbursement) (*disburse.Disbursement, error) {
+			err := checkMerchant(stored, r.MerchantID)
+			if err != nil {
+				return nil, err
+			}
+
+			chain, policy, err := getApprovalChain(ctx, h.approvalRepo, stored)
+			if err != nil {
+				return nil, err
+			}
+
+			step, err := stored.Approve(chain, r.ApproverID, r.ApproverRole, policy)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.approvalRepo.AddApprovalStep(ctx, step)
+			if err != nil {
+				return nil, err
+			}
+
+			// an approval short of the required ones leaves the status as is, it is still saved to bump the version
+			// so a concurrent approval counting the same chain is rejected
+			return stored, nil
+		},
+	)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// getApprovalChain gets the approval chain of the disbursement and the approval policy of its currency
+func getApprovalChain(
+	ctx context.Context,
+	approvalRepo disburse.ApprovalRepository,
+	disbursement *disburse.Disbursement,
+) ([]*disburse.ApprovalStep, disburse.ApprovalPolicy, error) {
+	chain, err := approvalRepo.ListApprovalSteps(ctx, disbursement.ID())
+	if err != nil {
+		return nil, disburse.ApprovalPolicy{}, errors.WrapDpayErrTrace(err)
+	}
+
+	policy, err := approvalRepo.GetApprovalPolicy(ctx, disbursement.MerchantID(), disbursement.Amount().Currency())
+	if err != nil {
+		return nil, disburse.ApprovalPolicy{}, errors.WrapDpayErrTrace(err)
+	}
+
+	return chain, policy, nil
+}
+
+func NewApproveDisbursementHandler(
+	disburseRepo disburse.DisburseRepository,
+	approvalRepo disburse.ApprovalRepository,
+) ApproveDisbursementHandler {
+	return decorator.ApplyCommandDecorators(
+		&approveDisbursementHandler{
+			disburseRepo: disburseRepo,
+			approvalRepo: approvalRepo,
+		},
+	)
+}
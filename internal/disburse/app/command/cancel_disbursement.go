@@ -0,0 +1,126 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type CancelDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the cancellation to the disbursements of the merchant, empty is not limited
+	MerchantID string
+	Reason     string
+
+	// Actor is who asked for the cancellation and Source the channel it came through, both are kept in the audit trail
+	Actor  string
+	Source string
+}
+
+type CancelDisbursementHandler decorator.CommandHandler[*CancelDisbursementParam]
+
+type cancelDisbursementHandler struct {
+	disburseRepo    disburse.DisburseRepository
+	auditRepo       disburse.AuditRepository
+	merchantBalance disburse.MerchantBalance
+}
+
+// Handle cancels a PENDING or AWAITING_APPROVAL disbursement and gives its reserved balance back once the cancellation
+// is saved, the audit entry is written in the same transaction as the status change. A cancellation racing another update
+// of the disbursement fails with disburse.ErrDisbursementConflict and can be retried.
+func (h cancelDisbursementHandler) Handle(
+	ctx context.Context,
+	r *CancelDisbursementParam,
+) error {
+	if r.Actor == "" {
+		return errors.NewIncorrectInputError(
+			disburse.ErrEmptyAuditActor,
+			disburse.ErrEmptyAuditActor.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	var cancelled *disburse.Disbursement
+
+	err := h.disburseRepo.UpdateDisbursement(
+		ctx,
+		r.ID,
+		func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error) {
+			err := checkMerchant(disbursement, r.MerchantID)
+			if err != nil {
+				return nil, err
+			}
+
+			cancelled = disbursement
+
+			// a redelivered cancellation finds the disbursement already cancelled, only the release is retried
+			if disbursement.Status() == disburse.StatusCancelled {
+				return nil, nil
+			}
+
+			fromStatus := disbursement.Status()
+
+			err = disbursement.Cancel()
+			if err != nil {
+				return nil, err
+			}
+
+			entry, err := disburse.NewAuditEntry(
+				disburse.AuditActionCancel,
+				disbursement,
+				fromStatus,
+				r.Actor,
+				r.Source,
+				r.Reason,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.auditRepo.AddAuditEntry(ctx, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			return disbursement, nil
+		},
+	)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return releaseSettledBalance(ctx, h.merchantBalance, cancelled)
+}
+
+// checkMerchant reports a disbursement of another merchant as not found so its existence is not leaked,
+// empty merchantID is not limited
+func checkMerchant(disbursement *disburse.Disbursement, merchantID string) error {
+	if merchantID != "" && disbursement.MerchantID() != merchantID {
+		return errors.NewNotFoundError(
+			disburse.ErrDisbursementNotFound,
+			disburse.ErrDisbursementNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	return nil
+}
+
+func NewCancelDisbursementHandler(
+	disburseRepo disburse.DisburseRepository,
+	auditRepo disburse.AuditRepository,
+	merchantBalance disburse.MerchantBalance,
+) CancelDisbursementHandler {
+	return decorator.ApplyCommandDecorators(
+		&cancelDisbursementHandler{
+			disburseRepo,
+			auditRepo,
+			merchantBalance,
+		},
+	)
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type CancelScheduledDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the cancellation to the schedules of the merchant, empty is not limited
+	MerchantID string
+}
+
+type CancelScheduledDisbursementHandler decorator.CommandHandler[*CancelScheduledDisbursementParam]
+
+type cancelScheduledDisbursementHandler struct {
+	scheduleRepo disburse.ScheduleRepository
+}
+
+// Handle stops the future runs of the schedule, the disbursements of the past runs are not touched
+func (h cancelScheduledDisbursementHandler) Handle(
+	ctx context.Context,
+	r *CancelScheduledDisbursementParam,
+) error {
+	schedule, err := h.scheduleRepo.GetSchedule(ctx, r.ID)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// a schedule of another merchant is reported as not found so its existence is not leaked
+	if r.MerchantID != "" && schedule.MerchantID() != r.MerchantID {
+		return errors.NewNotFoundError(
+			disburse.ErrScheduleNotFound,
+			disburse.ErrScheduleNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	err = schedule.Cancel()
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.scheduleRepo.UpdateSchedule(ctx, schedule)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewCancelScheduledDisbursementHandler(
+	scheduleRepo disburse.ScheduleRepository,
+) CancelScheduledDisbursementHandler {
+	return decorator.ApplyCommandDecorators(
+		&cancelScheduledDisbursementHandler{
+			scheduleRepo,
+		},
+	)
+}
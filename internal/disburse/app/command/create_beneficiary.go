@@ -0,0 +1,91 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type CreateBeneficiaryParam struct {
+	ID         uuid.UUID
+	MerchantID string
+
+	BankCode      string
+	AccountNumber string
+
+	// HolderName is checked against the name registered at the bank, empty takes the registered name as is
+	HolderName string
+}
+
+type CreateBeneficiaryHandler decorator.CommandHandler[*CreateBeneficiaryParam]
+
+type createBeneficiaryHandler struct {
+	beneficiaryRepo disburse.BeneficiaryRepository
+	nameInquiry     disburse.NameInquiry
+}
+
+func (h createBeneficiaryHandler) Handle(
+	ctx context.Context,
+	r *CreateBeneficiaryParam,
+) error {
+	bankAccount, err := disburse.NewBankAccount(r.BankCode, r.AccountNumber)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	holderName, err := inquireHolderName(ctx, h.nameInquiry, bankAccount, r.HolderName)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	beneficiary, err := disburse.NewBeneficiary(r.ID, r.MerchantID, bankAccount, holderName)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.beneficiaryRepo.CreateBeneficiary(ctx, beneficiary)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// inquireHolderName returns the holder name registered at the bank,
+// the given name must match it when the merchant gives one
+func inquireHolderName(
+	ctx context.Context,
+	nameInquiry disburse.NameInquiry,
+	bankAccount disburse.BankAccount,
+	given string,
+) (string, error) {
+	registered, err := nameInquiry.InquireHolderName(ctx, bankAccount)
+	if err != nil {
+		return "", err
+	}
+
+	if given != "" {
+		err = disburse.MatchHolderName(given, registered)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return registered, nil
+}
+
+func NewCreateBeneficiaryHandler(
+	beneficiaryRepo disburse.BeneficiaryRepository,
+	nameInquiry disburse.NameInquiry,
+) CreateBeneficiaryHandler {
+	return decorator.ApplyCommandDecorators(
+		&createBeneficiaryHandler{
+			beneficiaryRepo,
+			nameInquiry,
+		},
+	)
+}
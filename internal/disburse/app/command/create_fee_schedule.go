@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// FeeTierParam is a tier of a TIERED rule, the amounts are exact decimals in major units
+type FeeTierParam struct {
+	// UpTo is the max amount of the tier, empty for the last tier and only for it since it has no upper bound
+	UpTo string
+	Flat string
+
+	// Rate is in basis points, 100 is 1%
+	Rate int64
+}
+
+type FeeRuleParam struct {
+	// BankCode is the bank the rule applies to, empty is the rule of the banks without their own rule
+	BankCode string
+	Type     string
+
+	// Flat is the fee of a FLAT rule, Rate in basis points of a PERCENTAGE rule and Tiers of a TIERED rule
+	Flat  string
+	Rate  int64
+	Tiers []FeeTierParam
+
+	// Min and Max are exact decimal amounts in major units, empty is not capped
+	Min string
+	Max string
+}
+
+type CreateFeeScheduleParam struct {
+	ID         uuid.UUID
+	MerchantID string
+	Currency   string
+
+	// EffectiveFrom is the time the schedule applies from, zero applies it right away
+	EffectiveFrom time.Time
+	Rules         []FeeRuleParam
+}
+
+type CreateFeeScheduleHandler decorator.CommandHandler[*CreateFeeScheduleParam]
+
+type createFeeScheduleHandler struct {
+	feeRepo disburse.FeeRepository
+}
+
+// Handle creates the next version of the fee schedule of the merchant in the currency, the older versions are kept
+// so the fee of every disbursement can be traced back to the schedule it was calculated with
+func (h createFeeScheduleHandler) Handle(
+	ctx context.Context,
+	r *CreateFeeScheduleParam,
+) error {
+	if r.MerchantID == "" {
+		return errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	currency, err := money.ParseCurrency(r.Currency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	rules := make([]disburse.FeeRule, 0, len(r.Rules))
+	for _, rule := range r.Rules {
+		tiers := make([]disburse.FeeTier, 0, len(rule.Tiers))
+		for _, tier := range rule.Tiers {
+			amounts, err := parseOptionalAmounts(currency, tier.UpTo, tier.Flat)
+			if err != nil {
+				return errors.WrapDpayErrTrace(err)
+			}
+
+			tiers = append(tiers, disburse.NewFeeTier(amounts[0], amounts[1], tier.Rate))
+		}
+
+		amounts, err := parseOptionalAmounts(currency, rule.Flat, rule.Min, rule.Max)
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		rules = append(rules, disburse.NewFeeRule(
+			rule.BankCode,
+			disburse.FeeRuleType(rule.Type),
+			amounts[0],
+			rule.Rate,
+			tiers,
+			amounts[1],
+			amounts[2],
+		))
+	}
+
+	version := 1
+
+	latest, err := h.feeRepo.GetLatestFeeSchedule(ctx, r.MerchantID, currency)
+	if err == nil {
+		version = latest.Version() + 1
+	}
+
+	if err != nil && !stderrors.Is(err, disburse.ErrFeeScheduleNotFound) {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	schedule, err := disburse.NewFeeSchedule(r.ID, r.MerchantID, currency, version, r.EffectiveFrom, rules)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// a concurrent request creating the same version fails with ErrFeeScheduleVersionExists and can be retried
+	err = h.feeRepo.CreateFeeSchedule(ctx, schedule)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// parseOptionalAmounts parses the exact decimal amounts in the currency, an empty amount is zero Money
+func parseOptionalAmounts(currency money.Currency, amounts ...string) ([]money.Money, error) {
+	parsed := make([]money.Money, 0, len(amounts))
+	for _, amount := range amounts {
+		if amount == "" {
+			parsed = append(parsed, money.Money{})
+			continue
+		}
+
+		m, err := money.Parse(amount, currency.String())
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, m)
+	}
+
+	return parsed, nil
+}
+
+func NewCreateFeeScheduleHandler(
+	feeRepo disburse.FeeRepository,
+) CreateFeeScheduleHandler {
+	return decorator.ApplyCommandDecorators(
+		&createFeeScheduleHandler{
+			feeRepo: feeRepo,
+		},
+	)
+}
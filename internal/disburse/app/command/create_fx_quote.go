@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type CreateFXQuoteParam struct {
+	ID         uuid.UUID
+	MerchantID string
+
+	// SourceCurrency is the funding currency charged from the merchant balance,
+	// TargetCurrency the currency the disbursement is paid out in
+	SourceCurrency string
+	TargetCurrency string
+}
+
+type CreateFXQuoteHandler decorator.CommandHandler[*CreateFXQuoteParam]
+
+type createFXQuoteHandler struct {
+	fxQuoteRepo    disburse.FXQuoteRepository
+	fxRateProvider disburse.FXRateProvider
+}
+
+// Handle locks the current rate for the merchant for disburse.FXQuoteLockDuration,
+// a disbursement in the target currency using the quote is funded in the source currency at that rate
+func (h createFXQuoteHandler) Handle(
+	ctx context.Context,
+	r *CreateFXQuoteParam,
+) error {
+	source, err := money.ParseCurrency(r.SourceCurrency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	target, err := money.ParseCurrency(r.TargetCurrency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	rate, err := h.fxRateProvider.GetRate(ctx, source, target)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	quote, err := disburse.NewFXQuote(r.ID, r.MerchantID, rate)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.fxQuoteRepo.CreateFXQuote(ctx, quote)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewCreateFXQuoteHandler(
+	fxQuoteRepo disburse.FXQuoteRepository,
+	fxRateProvider disburse.FXRateProvider,
+) CreateFXQuoteHandler {
+	return decorator.ApplyCommandDecorators(
+		&createFXQuoteHandler{
+			fxQuoteRepo:    fxQuoteRepo,
+			fxRateProvider: fxRateProvider,
+		},
+	)
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type DeleteBeneficiaryParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the deletion to the beneficiaries of the merchant, empty is not limited
+	MerchantID string
+}
+
+type DeleteBeneficiaryHandler decorator.CommandHandler[*DeleteBeneficiaryParam]
+
+type deleteBeneficiaryHandler struct {
+	beneficiaryRepo disburse.BeneficiaryRepository
+}
+
+// Handle removes the beneficiary from the registry, the disbursements already paid to it keep referencing it
+func (h deleteBeneficiaryHandler) Handle(
+	ctx context.Context,
+	r *DeleteBeneficiaryParam,
+) error {
+	beneficiary, err := getActiveBeneficiary(ctx, h.beneficiaryRepo, r.ID, r.MerchantID)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = beneficiary.Delete()
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.beneficiaryRepo.UpdateBeneficiary(ctx, beneficiary)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewDeleteBeneficiaryHandler(
+	beneficiaryRepo disburse.BeneficiaryRepository,
+) DeleteBeneficiaryHandler {
+	return decorator.ApplyCommandDecorators(
+		&deleteBeneficiaryHandler{
+			beneficiaryRepo,
+		},
+	)
+}
@@ -2,32 +2,179 @@ package command
 
 import (
 	"context"
+	stderrors "errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
 )
 
 type DisburseParam struct {
-	Amount float32
+	// ID should be generated with disburse.NewDisbursementID so a retry gets the same id
+	ID             uuid.UUID
+	MerchantID     string
+	IdempotencyKey string
+
+	// Amount is the exact decimal amount in major units, e.g. "10000.50"
+	Amount   string
+	Currency string
+
+	// BeneficiaryID is the active beneficiary of the merchant to pay to, uuid.Nil disburses without beneficiary
+	BeneficiaryID uuid.UUID
+
+	// FXQuoteID is the quote of the merchant funding the disbursement in another currency at its locked rate,
+	// uuid.Nil funds the disbursement in its own currency
+	FXQuoteID uuid.UUID
+
+	// CreatedBy is the user asking for the disbursement, who can never approve it, empty is the merchant itself
+	CreatedBy string
 }
 
 type DisburseHandler decorator.CommandHandler[*DisburseParam]
 
 type disburseHandler struct {
-	disburseRepo disburse.DisburseRepository
+	manager         sqlwrap.ManagerInterface
+	disburseRepo    disburse.DisburseRepository
+	beneficiaryRepo disburse.BeneficiaryRepository
+	fxQuoteRepo     disburse.FXQuoteRepository
+	merchantBalance disburse.MerchantBalance
+	limits          limitChecker
+	approvals       approvalChecker
+	fees            feeCalculator
 }
 
+// Handle stores the disbursement with its fee in PENDING for the DispatchPayouts command to send it out, the amount
+// and the fee are both reserved from the merchant balance in the funding currency. A disbursement above the approval
+// threshold of the merchant is stored in AWAITING_APPROVAL instead and is sent out once it is approved.
 func (h disburseHandler) Handle(
 	ctx context.Context,
 	r *DisburseParam,
 ) error {
-	err := h.disburseRepo.CreateDisbursement(ctx, disburse.Disbursement{
-		ID:     uuid.New(),
-		Amount: float64(r.Amount),
+	amount, err := money.Parse(r.Amount, r.Currency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	disbursement, err := disburse.NewDisbursement(r.ID, r.MerchantID, amount, r.IdempotencyKey)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// a disbursement without beneficiary is charged by the default fee rule of the merchant
+	var bankCode string
+
+	if r.BeneficiaryID != uuid.Nil {
+		beneficiary, err := h.beneficiaryRepo.GetBeneficiary(ctx, r.BeneficiaryID)
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		err = disbursement.AssignBeneficiary(beneficiary)
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		bankCode = beneficiary.BankAccount().BankCode()
+	}
+
+	// the quote is only applied after the replay check, a retry of a funded request may find it expired
+	disbursement.RequestFXQuote(r.FXQuoteID)
+
+	// a retried request must not reserve the balance again, the reservation belongs to the original request
+	if disbursement.IdempotencyKey() != "" {
+		original, err := h.disburseRepo.GetDisbursementByIdempotencyKey(
+			ctx,
+			disbursement.MerchantID(),
+			disbursement.IdempotencyKey(),
+		)
+		if err == nil {
+			return h.checkReplay(original, disbursement)
+		}
+
+		if dpayErr, ok := errors.GetDPayError(err); !ok || dpayErr.ErrorType() != errors.ErrorTypeNotFound {
+			return errors.WrapDpayErrTrace(err)
+		}
+	}
+
+	if r.FXQuoteID != uuid.Nil {
+		quote, err := h.fxQuoteRepo.GetFXQuote(ctx, r.FXQuoteID)
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		err = disbursement.ApplyFXQuote(quote, time.Now())
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+	}
+
+	err = h.fees.apply(ctx, disbursement.MerchantID(), bankCode, []*disburse.Disbursement{disbursement})
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	total, err := disbursement.FundingTotal()
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	var reserved bool
+
+	// the limit check holds the usage of the merchant until the disbursement is stored
+	err = h.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err := h.limits.check(ctx, disbursement.MerchantID(), []*disburse.Disbursement{disbursement})
+		if err != nil {
+			return err
+		}
+
+		steps, err := h.approvals.requestApprovals(
+			ctx,
+			disbursement.MerchantID(),
+			r.CreatedBy,
+			[]*disburse.Disbursement{disbursement},
+		)
+		if err != nil {
+			return err
+		}
+
+		// the quote is taken in the same transaction, so it is free again when the disbursement is not stored
+		if disbursement.FXQuoteID() != uuid.Nil {
+			err = h.fxQuoteRepo.UseFXQuote(ctx, disbursement.FXQuoteID(), disbursement.ID())
+			if err != nil {
+				return err
+			}
+		}
+
+		// stored before the reservation, a concurrent request with the same id waits on the insert and fails
+		// with ErrDisbursementAlreadyExists without reserving, so it can not hold or release the balance of the original
+		err = h.disburseRepo.CreateDisbursement(ctx, disbursement)
+		if err != nil {
+			return err
+		}
+
+		err = h.merchantBalance.Reserve(ctx, disbursement.MerchantID(), disbursement.ID(), total)
+		if err != nil {
+			return err
+		}
+
+		reserved = true
+
+		return h.approvals.addSteps(ctx, steps)
 	})
+	if stderrors.Is(err, disburse.ErrDisbursementAlreadyExists) {
+		// nothing was reserved for the request, the reservation belongs to the disbursement stored first
+		return h.handleReplay(ctx, disbursement)
+	}
+
 	if err != nil {
+		if reserved {
+			releaseReservation(ctx, h.merchantBalance, disbursement)
+		}
+
 		// always do wrap since we need to keep the stack trace error from the source
 		return errors.WrapDpayErrTrace(err)
 	}
@@ -35,12 +182,72 @@ func (h disburseHandler) Handle(
 	return nil
 }
 
+// handleReplay accepts a retried request as success without creating a new disbursement,
+// but rejects the idempotency key when it was used for a different request
+func (h disburseHandler) handleReplay(
+	ctx context.Context,
+	requested *disburse.Disbursement,
+) error {
+	if requested.IdempotencyKey() == "" {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrDisbursementAlreadyExists,
+			disburse.ErrDisbursementAlreadyExists.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	original, err := h.disburseRepo.GetDisbursementByIdempotencyKey(
+		ctx,
+		requested.MerchantID(),
+		requested.IdempotencyKey(),
+	)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return h.checkReplay(original, requested)
+}
+
+func (h disburseHandler) checkReplay(original *disburse.Disbursement, requested *disburse.Disbursement) error {
+	if !original.IsReplayOf(requested) {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrIdempotencyKeyReused,
+			disburse.ErrIdempotencyKeyReused.Error(),
+			errors.DpayIdempotencyKeyReused,
+		)
+	}
+
+	return nil
+}
+
 func NewDisburseHandler(
+	manager sqlwrap.ManagerInterface,
 	disburseRepo disburse.DisburseRepository,
+	beneficiaryRepo disburse.BeneficiaryRepository,
+	fxQuoteRepo disburse.FXQuoteRepository,
+	approvalRepo disburse.ApprovalRepository,
+	feeRepo disburse.FeeRepository,
+	merchantBalance disburse.MerchantBalance,
+	limitRepo disburse.LimitRepository,
+	defaultLimits disburse.DefaultLimits,
 ) DisburseHandler {
 	return decorator.ApplyCommandDecorators(
 		&disburseHandler{
-			disburseRepo,
+			manager:         manager,
+			disburseRepo:    disburseRepo,
+			beneficiaryRepo: beneficiaryRepo,
+			fxQuoteRepo:     fxQuoteRepo,
+			merchantBalance: merchantBalance,
+			limits: limitChecker{
+				limitRepo:     limitRepo,
+				defaultLimits: defaultLimits,
+			},
+			approvals: approvalChecker{
+				approvalRepo: approvalRepo,
+			},
+			fees: feeCalculator{
+				feeRepo: feeRepo,
+			},
 		},
 	)
 }
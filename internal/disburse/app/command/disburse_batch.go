@@ -0,0 +1,367 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+type DisburseBatchItem struct {
+	// Amount is the exact decimal amount in major units, e.g. "10000.50"
+	Amount   string
+	Currency string
+}
+
+type DisburseBatchParam struct {
+	// ID should be generated with disburse.NewBatchID so a retry gets the same id,
+	// the item ids are derived from it with disburse.NewBatchItemID
+	ID             uuid.UUID
+	MerchantID     string
+	IdempotencyKey string
+	Items          []DisburseBatchItem
+
+	// CreatedBy is the user asking for the batch, who can never approve its items, empty is the merchant itself
+	CreatedBy string
+}
+
+// batchReservationClaimTimeout is how long a request reserves the balance of a batch before a retry may take over
+const batchReservationClaimTimeout = 5 * time.Minute
+
+type DisburseBatchHandler decorator.CommandHandler[*DisburseBatchParam]
+
+type disburseBatchHandler struct {
+	manager         sqlwrap.ManagerInterface
+	disburseRepo    disburse.DisburseRepository
+	merchantBalance disburse.MerchantBalance
+	limits          limitChecker
+	approvals       approvalChecker
+	fees            feeCalculator
+}
+
+// Handle accepts the batch as a whole, any invalid item rejects the batch with an api.ErrorInfo per failed item.
+// The items have no beneficiary, so they are charged by the default fee rule of the merchant.
+// The batch is stored as RESERVING within the limit lock and the balance of its items is reserved after the lock is
+// released, the items are sent out by the DispatchPayouts command only once the batch is ACCEPTED. An item whose
+// balance can not be reserved rejects the batch, its items are cancelled and the reservations made are released.
+// An item above the approval threshold of the merchant awaits approval and is sent out once it is approved.
+func (h disburseBatchHandler) Handle(
+	ctx context.Context,
+	r *DisburseBatchParam,
+) error {
+	items, err := h.buildItems(r)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	batch, err := disburse.NewBatch(r.ID, r.MerchantID, r.IdempotencyKey, items)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// a retried batch must not be stored again, it resumes the reservation of the original request if unfinished
+	original, err := h.disburseRepo.GetBatch(ctx, batch.ID())
+	if err == nil {
+		return h.handleReplay(ctx, original, batch)
+	}
+
+	if dpayErr, ok := errors.GetDPayError(err); !ok || dpayErr.ErrorType() != errors.ErrorTypeNotFound {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.fees.apply(ctx, batch.MerchantID(), "", items)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err := h.limits.check(ctx, batch.MerchantID(), items)
+		if err != nil {
+			return err
+		}
+
+		steps, err := h.approvals.requestApprovals(ctx, batch.MerchantID(), r.CreatedBy, items)
+		if err != nil {
+			return err
+		}
+
+		err = h.disburseRepo.CreateBatch(ctx, batch, items)
+		if err != nil {
+			return err
+		}
+
+		// the new batch is claimed before it is committed, so a concurrent retry waits for this request
+		now := time.Now().UTC()
+
+		_, err = h.disburseRepo.ClaimBatchReservation(ctx, batch.ID(), now, now.Add(batchReservationClaimTimeout))
+		if err != nil {
+			return err
+		}
+
+		return h.approvals.addSteps(ctx, steps)
+	})
+	if stderrors.Is(err, disburse.ErrBatchAlreadyExists) {
+		// a concurrent retry got the same ids and stored the batch first
+		original, err := h.disburseRepo.GetBatch(ctx, batch.ID())
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		return h.handleReplay(ctx, original, batch)
+	}
+
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return h.reserveBalance(ctx, batch, items)
+}
+
+// handleReplay answers a retried batch by the outcome of the original one, a RESERVING batch whose claim expired
+// is reserved again by the retry
+func (h disburseBatchHandler) handleReplay(ctx context.Context, original *disburse.Batch, requested *disburse.Batch) error {
+	if !original.IsReplayOf(requested) {
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrIdempotencyKeyReused,
+			disburse.ErrIdempotencyKeyReused.Error(),
+			errors.DpayIdempotencyKeyReused,
+		)
+	}
+
+	switch original.Status() {
+	case disburse.BatchStatusAccepted:
+		return nil
+	case disburse.BatchStatusRejected:
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrBatchRejected,
+			disburse.ErrBatchRejected.Error(),
+			errors.DpayInsufficientBalance,
+		)
+	}
+
+	now := time.Now().UTC()
+
+	claimed, err := h.disburseRepo.ClaimBatchReservation(ctx, original.ID(), now, now.Add(batchReservationClaimTimeout))
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	if !claimed {
+		// either another request is reserving or the batch was completed since it was read, both can be retried
+		return errors.NewConflictError(
+			disburse.ErrBatchReserving,
+			disburse.ErrBatchReserving.Error(),
+			errors.DpayConcurrentUpdate,
+		)
+	}
+
+	stored, err := h.disburseRepo.GetDisbursementsByIDs(ctx, original.ItemIDs())
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// the items are reserved in their order so a rejection points at the same item as the original request
+	byID := make(map[uuid.UUID]*disburse.Disbursement, len(stored))
+	for _, item := range stored {
+		byID[item.ID()] = item
+	}
+
+	items := make([]*disburse.Disbursement, 0, len(stored))
+	for _, id := range original.ItemIDs() {
+		if item, ok := byID[id]; ok {
+			items = append(items, item)
+		}
+	}
+
+	return h.reserveBalance(ctx, original, items)
+}
+
+// reserveBalance reserves the amount and fee of every item and accepts the batch. A failed item releases the
+// reservations made before it, a rejected reservation also rejects the batch while any other failure leaves it
+// RESERVING for a retry. An item cancelled or rejected since it was stored is skipped, it holds no balance.
+func (h disburseBatchHandler) reserveBalance(
+	ctx context.Context,
+	batch *disburse.Batch,
+	items []*disburse.Disbursement,
+) error {
+	reserved := make([]*disburse.Disbursement, 0, len(items))
+
+	for i, item := range items {
+		if item.Status().IsFinal() {
+			continue
+		}
+
+		total, err := item.Total()
+		if err == nil {
+			err = h.merchantBalance.Reserve(ctx, item.MerchantID(), item.ID(), total)
+		}
+
+		if err == nil {
+			reserved = append(reserved, item)
+			continue
+		}
+
+		for _, reserved := range reserved {
+			releaseReservation(ctx, h.merchantBalance, reserved)
+		}
+
+		status := disburse.BatchStatusReserving
+		if errors.IsClientError(err) && !errors.IsConflictError(err) {
+			status = disburse.BatchStatusRejected
+		}
+
+		completeErr := h.completeReservation(ctx, batch, items, status)
+		if completeErr != nil {
+			// the claim expires and a retry finishes the batch
+			logger.Errorw(
+				ctx,
+				"failed to complete disbursement batch reservation",
+				"batch_id", batch.ID().String(),
+				"merchant_id", batch.MerchantID(),
+				"error", completeErr.Error(),
+			)
+		}
+
+		dpayErr, ok := errors.GetDPayError(err)
+		if !ok {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		return errors.NewCustomDpayError(
+			err,
+			dpayErr.Error(),
+			dpayErr.ErrorCode(),
+			dpayErr.ErrorType(),
+			api.ErrorInfo{Field: fmt.Sprintf("items[%d]", i), Message: dpayErr.Error()},
+		)
+	}
+
+	err := h.completeReservation(ctx, batch, items, disburse.BatchStatusAccepted)
+	if err != nil {
+		// the reservations are kept, a retry reserves the same disbursement ids again and accepts the batch
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// completeReservation moves the batch out of RESERVING, cancelling its items when it is rejected.
+// RESERVING only gives up the claim, so a retry can reserve the batch right away.
+func (h disburseBatchHandler) completeReservation(
+	ctx context.Context,
+	batch *disburse.Batch,
+	items []*disburse.Disbursement,
+	status disburse.BatchStatus,
+) error {
+	if status != disburse.BatchStatusRejected {
+		return h.disburseRepo.CompleteBatchReservation(ctx, batch.ID(), status)
+	}
+
+	return h.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range items {
+			err := h.disburseRepo.UpdateDisbursement(
+				ctx,
+				item.ID(),
+				func(ctx context.Context, stored *disburse.Disbursement) (*disburse.Disbursement, error) {
+					if stored.Status().IsFinal() {
+						return nil, nil
+					}
+
+					err := stored.Cancel()
+					if err != nil {
+						return nil, err
+					}
+
+					return stored, nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return h.disburseRepo.CompleteBatchReservation(ctx, batch.ID(), status)
+	})
+}
+
+// buildItems validates every item before anything is stored, so all failed items are reported at once
+func (h disburseBatchHandler) buildItems(r *DisburseBatchParam) ([]*disburse.Disbursement, error) {
+	if len(r.Items) > disburse.MaxBatchItems {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrBatchTooLarge,
+			fmt.Sprintf("%s, max %d items", disburse.ErrBatchTooLarge.Error(), disburse.MaxBatchItems),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	var (
+		items     = make([]*disburse.Disbursement, 0, len(r.Items))
+		errInfos  []api.ErrorInfo
+		itemField = func(i int) string { return fmt.Sprintf("items[%d]", i) }
+	)
+
+	for i, item := range r.Items {
+		amount, err := money.Parse(item.Amount, item.Currency)
+		if err != nil {
+			errInfos = append(errInfos, api.ErrorInfo{Field: itemField(i), Message: err.Error()})
+			continue
+		}
+
+		disbursement, err := disburse.NewDisbursement(disburse.NewBatchItemID(r.ID, i), r.MerchantID, amount, "")
+		if err != nil {
+			errInfos = append(errInfos, api.ErrorInfo{Field: itemField(i), Message: err.Error()})
+			continue
+		}
+
+		items = append(items, disbursement)
+	}
+
+	if len(errInfos) > 0 {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrInvalidBatchItems,
+			fmt.Sprintf("%s: %d of %d items are invalid", disburse.ErrInvalidBatchItems.Error(), len(errInfos), len(r.Items)),
+			errors.DpayInvalidRequest,
+			errInfos...,
+		)
+	}
+
+	return items, nil
+}
+
+func NewDisburseBatchHandler(
+	manager sqlwrap.ManagerInterface,
+	disburseRepo disburse.DisburseRepository,
+	approvalRepo disburse.ApprovalRepository,
+	feeRepo disburse.FeeRepository,
+	merchantBalance disburse.MerchantBalance,
+	limitRepo disburse.LimitRepository,
+	defaultLimits disburse.DefaultLimits,
+) DisburseBatchHandler {
+	return decorator.ApplyCommandDecorators(
+		&disburseBatchHandler{
+			manager:         manager,
+			disburseRepo:    disburseRepo,
+			merchantBalance: merchantBalance,
+			limits: limitChecker{
+				limitRepo:     limitRepo,
+				defaultLimits: defaultLimits,
+			},
+			approvals: approvalChecker{
+				approvalRepo: approvalRepo,
+			},
+			fees: feeCalculator{
+				feeRepo: feeRepo,
+			},
+		},
+	)
+}
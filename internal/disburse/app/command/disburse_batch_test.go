@@ -0,0 +1,248 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// fakeBatchRepository holds a single batch with its items, the methods the tests do not use panic
+type fakeBatchRepository struct {
+	disburse.DisburseRepository
+
+	batchID uuid.UUID
+	status  disburse.BatchStatus
+	items   []*disburse.Disbursement
+
+	// claimed tells another request holds the reservation claim
+	claimed bool
+}
+
+func (r *fakeBatchRepository) ClaimBatchReservation(_ context.Context, id uuid.UUID, _, _ time.Time) (bool, error) {
+	if id != r.batchID || r.status != disburse.BatchStatusReserving || r.claimed {
+		return false, nil
+	}
+
+	r.claimed = true
+
+	return true, nil
+}
+
+func (r *fakeBatchRepository) CompleteBatchReservation(_ context.Context, _ uuid.UUID, status disburse.BatchStatus) error {
+	r.status = status
+	r.claimed = false
+
+	return nil
+}
+
+func (r *fakeBatchRepository) GetDisbursementsByIDs(_ context.Context, ids []uuid.UUID) ([]*disburse.Disbursement, error) {
+	var items []*disburse.Disbursement
+	for _, id := range ids {
+		for _, item := range r.items {
+			if item.ID() == id {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func (r *fakeBatchRepository) UpdateDisbursement(
+	ctx context.Context,
+	id uuid.UUID,
+	updateFn func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error),
+) error {
+	for _, item := range r.items {
+		if item.ID() == id {
+			_, err := updateFn(ctx, item)
+			return err
+		}
+	}
+
+	return errors.NewNotFoundError(disburse.ErrDisbursementNotFound, id.String(), errors.DpayNotFound)
+}
+
+func TestDisburseBatchReserveBalance(t *testing.T) {
+	insufficient := errors.NewUnprocessableEntityError(
+		disburse.ErrInsufficientBalance,
+		disburse.ErrInsufficientBalance.Error(),
+		errors.DpayInsufficientBalance,
+	)
+
+	tests := []struct {
+		name       string
+		reserveErr error
+		status     disburse.BatchStatus
+		items      disburse.Status
+	}{
+		{
+			name:   "every item reserved",
+			status: disburse.BatchStatusAccepted,
+			items:  disburse.StatusPending,
+		},
+		{
+			name:       "insufficient balance rejects the batch",
+			reserveErr: insufficient,
+			status:     disburse.BatchStatusRejected,
+			items:      disburse.StatusCancelled,
+		},
+		{
+			// the batch is left for a retry, which reserves the same disbursement ids again
+			name:       "unavailable merchant service",
+			reserveErr: stderrors.New("connection reset"),
+			status:     disburse.BatchStatusReserving,
+			items:      disburse.StatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, items := newTestBatch(t, 3)
+			repo := &fakeBatchRepository{batchID: batch.ID(), status: batch.Status(), items: items, claimed: true}
+
+			balance := &fakeMerchantBalance{}
+			if tt.reserveErr != nil {
+				balance.reserveErr = map[uuid.UUID]error{items[1].ID(): tt.reserveErr}
+			}
+
+			h := disburseBatchHandler{manager: fakeTransactionManager{}, disburseRepo: repo, merchantBalance: balance}
+
+			err := h.reserveBalance(context.Background(), batch, items)
+			if (err != nil) != (tt.reserveErr != nil) {
+				t.Fatalf("reserveBalance error = %v, want %v", err, tt.reserveErr)
+			}
+
+			if repo.status != tt.status || repo.claimed {
+				t.Errorf("batch = %s claimed %v, want %s without claim", repo.status, repo.claimed, tt.status)
+			}
+
+			for _, item := range items {
+				if item.Status() != tt.items {
+					t.Errorf("item %s = %s, want %s", item.ID(), item.Status(), tt.items)
+				}
+			}
+
+			if tt.reserveErr == nil {
+				if len(balance.reserved) != len(items) || len(balance.released) != 0 {
+					t.Errorf("reserved %v and released %v, want every item reserved", balance.reserved, balance.released)
+				}
+
+				return
+			}
+
+			// only the item reserved before the failed one holds balance
+			if len(balance.released) != 1 || balance.released[0] != items[0].ID() {
+				t.Errorf("released = %v, want only %s", balance.released, items[0].ID())
+			}
+		})
+	}
+}
+
+func TestDisburseBatchReplay(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  disburse.BatchStatus
+		claimed bool
+
+		// errType is the error of the replay, empty when the replay succeeds
+		errType  errors.ErrorType
+		reserved int
+	}{
+		{
+			name:   "accepted batch",
+			status: disburse.BatchStatusAccepted,
+		},
+		{
+			name:    "rejected batch",
+			status:  disburse.BatchStatusRejected,
+			errType: errors.ErrorTypeUnprocessableEntity,
+		},
+		{
+			name:    "batch reserved by another request",
+			status:  disburse.BatchStatusReserving,
+			claimed: true,
+			errType: errors.ErrorTypeConflict,
+		},
+		{
+			// the claim of the original request expired, e.g. the request was gone before the batch was accepted
+			name:     "abandoned reservation is resumed",
+			status:   disburse.BatchStatusReserving,
+			reserved: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested, items := newTestBatch(t, 3)
+			original := disburse.UnmarshalBatchFromDatabase(
+				requested.ID(),
+				requested.MerchantID(),
+				requested.ItemCount(),
+				tt.status,
+				requested.IdempotencyKey(),
+				requested.RequestHash(),
+				requested.CreatedAt(),
+			)
+
+			repo := &fakeBatchRepository{batchID: original.ID(), status: tt.status, items: items, claimed: tt.claimed}
+			balance := &fakeMerchantBalance{}
+			h := disburseBatchHandler{manager: fakeTransactionManager{}, disburseRepo: repo, merchantBalance: balance}
+
+			err := h.handleReplay(context.Background(), original, requested)
+			if tt.errType == "" && err != nil {
+				t.Fatalf("handleReplay error = %v", err)
+			}
+
+			if tt.errType != "" {
+				dpayErr, ok := errors.GetDPayError(err)
+				if !ok || dpayErr.ErrorType() != tt.errType {
+					t.Fatalf("handleReplay error = %v, want %s", err, tt.errType)
+				}
+			}
+
+			if len(balance.reserved) != tt.reserved {
+				t.Errorf("reserved %d items, want %d", len(balance.reserved), tt.reserved)
+			}
+
+			if tt.reserved > 0 && repo.status != disburse.BatchStatusAccepted {
+				t.Errorf("batch = %s, want %s", repo.status, disburse.BatchStatusAccepted)
+			}
+		})
+	}
+}
+
+// newTestBatch creates a RESERVING batch of count PENDING items
+func newTestBatch(t *testing.T, count int) (*disburse.Batch, []*disburse.Disbursement) {
+	t.Helper()
+
+	amount, err := money.Parse("10000", "IDR")
+	if err != nil {
+		t.Fatalf("parse amount: %v", err)
+	}
+
+	batchID := uuid.New()
+
+	items := make([]*disburse.Disbursement, 0, count)
+	for i := range count {
+		item, err := disburse.NewDisbursement(disburse.NewBatchItemID(batchID, i), "merchant-1", amount, "")
+		if err != nil {
+			t.Fatalf("new disbursement: %v", err)
+		}
+
+		items = append(items, item)
+	}
+
+	batch, err := disburse.NewBatch(batchID, "merchant-1", "key-1", items)
+	if err != nil {
+		t.Fatalf("new batch: %v", err)
+	}
+
+	return batch, items
+}
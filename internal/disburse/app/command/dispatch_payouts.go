@@ -0,0 +1,152 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+type DispatchPayoutsParam struct {
+	// Limit is the most disbursements sent out in a single run, for the PENDING ones and for the stale ones each
+	Limit int
+
+	// ClaimTimeout is how long a payout is claimed while it is sent, a payout still claimed after it
+	// is sent again. It must be longer than sending Limit payouts takes.
+	ClaimTimeout time.Duration
+}
+
+type DispatchPayoutsHandler decorator.CommandHandler[*DispatchPayoutsParam]
+
+type dispatchPayoutsHandler struct {
+	manager      sqlwrap.ManagerInterface
+	disburseRepo disburse.DisburseRepository
+	payouts      payoutDispatcher
+}
+
+// Handle moves the oldest PENDING disbursements into PROCESSING with their payout claimed and sends them to
+// the payout provider once the move is saved, so a disbursement is never sent before it is stored as sent.
+// The payouts whose claim expired, e.g. the process stopped or the provider failed before the payout was sent,
+// are claimed again and sent again. The claim is released once the provider has the payout.
+// The disbursements are locked with SKIP LOCKED, so it is safe to run on several instances at once.
+// Without payout provider nothing is moved and disburse.ErrPayoutProviderNotConfigured is returned.
+func (h dispatchPayoutsHandler) Handle(
+	ctx context.Context,
+	r *DispatchPayoutsParam,
+) error {
+	if h.payouts.payoutProvider == nil {
+		return errors.NewDpayError(
+			disburse.ErrPayoutProviderNotConfigured,
+			disburse.ErrPayoutProviderNotConfigured.Error(),
+			errors.DpayInternalError,
+		)
+	}
+
+	now := time.Now().UTC()
+	claimUntil := now.Add(r.ClaimTimeout)
+
+	stale, err := h.disburseRepo.ClaimStalePayouts(ctx, now, claimUntil, r.Limit)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	processing, err := h.startProcessing(ctx, claimUntil, r.Limit)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// the moves are committed, a failed payout keeps its claim and does not stop the others
+	for _, disbursement := range append(stale, processing...) {
+		if ctx.Err() != nil {
+			// the claims left expire and the payouts are sent again
+			return nil
+		}
+
+		err = h.payouts.send(ctx, disbursement)
+		if err != nil {
+			logDisbursementError(ctx, disbursement, "failed to send payout, retrying after the claim expires", err)
+			continue
+		}
+
+		err = h.disburseRepo.ReleasePayoutClaim(ctx, disbursement.ID())
+		if err != nil {
+			logDisbursementError(ctx, disbursement, "failed to release payout claim", err)
+		}
+	}
+
+	return nil
+}
+
+// startProcessing moves up to limit PENDING disbursements into PROCESSING with their payout claimed until
+// claimUntil in one transaction
+func (h dispatchPayoutsHandler) startProcessing(
+	ctx context.Context,
+	claimUntil time.Time,
+	limit int,
+) ([]*disburse.Disbursement, error) {
+	var processing []*disburse.Disbursement
+
+	err := h.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		disbursements, err := h.disburseRepo.ListDisbursementsToDispatch(ctx, limit)
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		for _, disbursement := range disbursements {
+			err = h.disburseRepo.UpdateDisbursement(
+				ctx,
+				disbursement.ID(),
+				func(ctx context.Context, stored *disburse.Disbursement) (*disburse.Disbursement, error) {
+					err := transition(stored, disburse.StatusProcessing, "")
+					if err != nil {
+						return nil, err
+					}
+
+					processing = append(processing, stored)
+
+					return stored, nil
+				},
+			)
+			if err != nil {
+				return errors.WrapDpayErrTrace(err)
+			}
+
+			err = h.disburseRepo.ClaimPayout(ctx, disbursement.ID(), claimUntil)
+			if err != nil {
+				return errors.WrapDpayErrTrace(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return processing, nil
+}
+
+// NewDispatchPayoutsHandler accepts a nil payoutProvider for the processes not sending payouts
+func NewDispatchPayoutsHandler(
+	manager sqlwrap.ManagerInterface,
+	disburseRepo disburse.DisburseRepository,
+	merchantBalance disburse.MerchantBalance,
+	payoutProvider disburse.PayoutProvider,
+) DispatchPayoutsHandler {
+	return decorator.ApplyCommandDecorators(
+		&dispatchPayoutsHandler{
+			manager:      manager,
+			disburseRepo: disburseRepo,
+			payouts: payoutDispatcher{
+				disburseRepo:    disburseRepo,
+				merchantBalance: merchantBalance,
+				payoutProvider:  payoutProvider,
+			},
+		},
+	)
+}
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+)
+
+func TestDispatchPayouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *fakePayoutProvider
+		status   disburse.Status
+		claimed  bool
+		released bool
+	}{
+		{
+			name:     "accepted payout waits for the callback",
+			provider: &fakePayoutProvider{result: disburse.StatusProcessing},
+			status:   disburse.StatusProcessing,
+		},
+		{
+			name:     "settled payout",
+			provider: &fakePayoutProvider{result: disburse.StatusSuccess},
+			status:   disburse.StatusSuccess,
+		},
+		{
+			name:     "rejected payout releases the balance",
+			provider: &fakePayoutProvider{err: disburse.ErrPayoutRejected},
+			status:   disburse.StatusFailed,
+			released: true,
+		},
+		{
+			name:     "failed send keeps the claim",
+			provider: &fakePayoutProvider{err: stderrors.New("connection reset")},
+			status:   disburse.StatusProcessing,
+			claimed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeDisburseRepository{disbursement: newTestDisbursement(t, disburse.StatusPending)}
+			balance := &fakeMerchantBalance{}
+
+			h := newTestDispatchPayoutsHandler(repo, balance, tt.provider)
+
+			err := h.Handle(context.Background(), &DispatchPayoutsParam{Limit: 10, ClaimTimeout: time.Minute})
+			if err != nil {
+				t.Fatalf("Handle error = %v", err)
+			}
+
+			if tt.provider.sent != 1 {
+				t.Errorf("sent %d payouts, want 1", tt.provider.sent)
+			}
+
+			if repo.disbursement.Status() != tt.status {
+				t.Errorf("status = %s, want %s", repo.disbursement.Status(), tt.status)
+			}
+
+			if claimed := !repo.payoutClaimedUntil.IsZero(); claimed != tt.claimed {
+				t.Errorf("payout claimed = %v, want %v", claimed, tt.claimed)
+			}
+
+			if released := len(balance.released) > 0; released != tt.released {
+				t.Errorf("released = %v, want %v", balance.released, tt.released)
+			}
+		})
+	}
+}
+
+func TestDispatchPayoutsResendsAfterFailedSend(t *testing.T) {
+	repo := &fakeDisburseRepository{disbursement: newTestDisbursement(t, disburse.StatusPending)}
+	provider := &fakePayoutProvider{err: stderrors.New("connection reset")}
+	h := newTestDispatchPayoutsHandler(repo, &fakeMerchantBalance{}, provider)
+	param := &DispatchPayoutsParam{Limit: 10, ClaimTimeout: time.Minute}
+
+	// the move into PROCESSING is committed before the send fails
+	if err := h.Handle(context.Background(), param); err != nil {
+		t.Fatalf("first Handle error = %v", err)
+	}
+
+	if repo.disbursement.Status() != disburse.StatusProcessing || repo.payoutClaimedUntil.IsZero() {
+		t.Fatalf("after a failed send status = %s, claimed until %s, want claimed in PROCESSING",
+			repo.disbursement.Status(), repo.payoutClaimedUntil)
+	}
+
+	// the claim is still held, nothing is sent again
+	if err := h.Handle(context.Background(), param); err != nil {
+		t.Fatalf("second Handle error = %v", err)
+	}
+
+	if provider.sent != 1 {
+		t.Fatalf("sent %d payouts before the claim expired, want 1", provider.sent)
+	}
+
+	// the claim expires, e.g. the process stopped after the move
+	repo.payoutClaimedUntil = time.Now().Add(-time.Second)
+	provider.err = nil
+	provider.result = disburse.StatusProcessing
+
+	if err := h.Handle(context.Background(), param); err != nil {
+		t.Fatalf("third Handle error = %v", err)
+	}
+
+	if provider.sent != 2 {
+		t.Errorf("sent %d payouts after the claim expired, want 2", provider.sent)
+	}
+
+	if !repo.payoutClaimedUntil.IsZero() {
+		t.Errorf("payout claimed until %s after it was sent, want released", repo.payoutClaimedUntil)
+	}
+}
+
+func newTestDispatchPayoutsHandler(
+	repo *fakeDisburseRepository,
+	balance *fakeMerchantBalance,
+	provider *fakePayoutProvider,
+) dispatchPayoutsHandler {
+	return dispatchPayoutsHandler{
+		manager:      fakeTransactionManager{},
+		disburseRepo: repo,
+		payouts: payoutDispatcher{
+			disburseRepo:    repo,
+			merchantBalance: balance,
+			payoutProvider:  provider,
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+type ExpireApprovalsParam struct {
+	// Limit is the most disbursements rejected in a single run
+	Limit int
+}
+
+type ExpireApprovalsHandler decorator.CommandHandler[*ExpireApprovalsParam]
+
+type expireApprovalsHandler struct {
+	manager         sqlwrap.ManagerInterface
+	disburseRepo    disburse.DisburseRepository
+	approvalRepo    disburse.ApprovalRepository
+	merchantBalance disburse.MerchantBalance
+}
+
+// Handle rejects the disbursements not approved by their deadline and gives their reserved balance back
+// once the rejections are saved.
+// The disbursements are locked with SKIP LOCKED, so it is safe to run on several instances at once.
+func (h expireApprovalsHandler) Handle(
+	ctx context.Context,
+	r *ExpireApprovalsParam,
+) error {
+	var expired []*disburse.Disbursement
+
+	err := h.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now().UTC()
+
+		disbursements, err := h.disburseRepo.ListExpiredApprovals(ctx, now, r.Limit)
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		for _, disbursement := range disbursements {
+			err = h.disburseRepo.UpdateDisbursement(
+				ctx,
+				disbursement.ID(),
+				func(ctx context.Context, stored *disburse.Disbursement) (*disburse.Disbursement, error) {
+					step, err := stored.ExpireApproval(now)
+					if err != nil {
+						return nil, err
+					}
+
+					err = h.approvalRepo.AddApprovalStep(ctx, step)
+					if err != nil {
+						return nil, err
+					}
+
+					expired = append(expired, stored)
+
+					return stored, nil
+				},
+			)
+			if err != nil {
+				return errors.WrapDpayErrTrace(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// the rejections are committed, a failed release is only logged so the others are still released
+	for _, disbursement := range expired {
+		err = releaseSettledBalance(ctx, h.merchantBalance, disbursement)
+		if err != nil {
+			logDisbursementError(ctx, disbursement, "failed to release merchant balance of expired approval", err)
+		}
+	}
+
+	return nil
+}
+
+func NewExpireApprovalsHandler(
+	manager sqlwrap.ManagerInterface,
+	disburseRepo disburse.DisburseRepository,
+	approvalRepo disburse.ApprovalRepository,
+	merchantBalance disburse.MerchantBalance,
+) ExpireApprovalsHandler {
+	return decorator.ApplyCommandDecorators(
+		&expireApprovalsHandler{
+			manager,
+			disburseRepo,
+			approvalRepo,
+			merchantBalance,
+		},
+	)
+}
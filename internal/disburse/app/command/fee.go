@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+// feeCalculator charges the new disbursements the fee of the schedule of their merchant
+type feeCalculator struct {
+	feeRepo disburse.FeeRepository
+}
+
+// apply sets the fee of every disbursement paid to the bank before it is stored, empty bankCode is charged by
+// the default rule of the schedule. The schedule is the one effective when the disbursement was created.
+func (c feeCalculator) apply(
+	ctx context.Context,
+	merchantID string,
+	bankCode string,
+	disbursements []*disburse.Disbursement,
+) error {
+	schedules := make(map[money.Currency]*disburse.FeeSchedule)
+
+	for _, disbursement := range disbursements {
+		currency := disbursement.Amount().Currency()
+
+		schedule, ok := schedules[currency]
+		if !ok {
+			var err error
+
+			schedule, err = disburse.GetEffectiveFeeSchedule(ctx, c.feeRepo, merchantID, currency, disbursement.CreatedAt())
+			if err != nil {
+				return err
+			}
+
+			schedules[currency] = schedule
+		}
+
+		err := disbursement.ApplyFee(schedule, bankCode)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
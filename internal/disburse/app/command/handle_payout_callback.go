@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+var ErrEmptyCallbackEventID = stderrors.New("callback event id can not be empty")
+
+type HandlePayoutCallbackParam struct {
+	Provider string
+
+	// EventID is unique per callback of the provider, a redelivered callback carries the same id
+	EventID string
+
+	DisbursementID uuid.UUID
+	Status         string
+	FailureReason  string
+}
+
+type HandlePayoutCallbackHandler decorator.CommandHandler[*HandlePayoutCallbackParam]
+
+type handlePayoutCallbackHandler struct {
+	manager         sqlwrap.ManagerInterface
+	disburseRepo    disburse.DisburseRepository
+	callbackRepo    disburse.CallbackRepository
+	merchantBalance disburse.MerchantBalance
+}
+
+func (h handlePayoutCallbackHandler) Handle(
+	ctx context.Context,
+	r *HandlePayoutCallbackParam,
+) error {
+	if r.EventID == "" {
+		return errors.NewIncorrectInputError(
+			ErrEmptyCallbackEventID,
+			ErrEmptyCallbackEventID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	// a callback only reports the final result of a payout
+	target := disburse.Status(strings.ToUpper(strings.TrimSpace(r.Status)))
+	if target != disburse.StatusSuccess && target != disburse.StatusFailed {
+		return errors.NewIncorrectInputError(
+			disburse.ErrInvalidStatus,
+			fmt.Sprintf("%s: %q", disburse.ErrInvalidStatus.Error(), r.Status),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	result := disburse.PayoutResult{
+		DisbursementID: r.DisbursementID,
+		Status:         target,
+		FailureReason:  r.FailureReason,
+	}
+
+	var settled *disburse.Disbursement
+
+	// the callback is stored in the same transaction as the status change,
+	// so a callback is either applied and remembered or neither
+	err := h.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err := h.callbackRepo.AddCallback(ctx, r.Provider, r.EventID, result)
+		if stderrors.Is(err, disburse.ErrCallbackAlreadyReceived) {
+			// only the release is retried for a redelivered callback
+			settled, err = h.disburseRepo.GetDisbursement(ctx, result.DisbursementID)
+			return err
+		}
+
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		// the result may already be applied by another callback of the same payout, then it is left as is
+		settled, err = saveStatus(
+			ctx,
+			h.disburseRepo,
+			result.DisbursementID,
+			result.Status,
+			result.FailureReason,
+		)
+
+		return err
+	})
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return releaseSettledBalance(ctx, h.merchantBalance, settled)
+}
+
+func NewHandlePayoutCallbackHandler(
+	manager sqlwrap.ManagerInterface,
+	disburseRepo disburse.DisburseRepository,
+	callbackRepo disburse.CallbackRepository,
+	merchantBalance disburse.MerchantBalance,
+) HandlePayoutCallbackHandler {
+	return decorator.ApplyCommandDecorators(
+		&handlePayoutCallbackHandler{
+			manager,
+			disburseRepo,
+			callbackRepo,
+			merchantBalance,
+		},
+	)
+}
@@ -0,0 +1,255 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// fakeDisburseRepository holds a single disbursement, the methods the tests do not use panic
+type fakeDisburseRepository struct {
+	disburse.DisburseRepository
+
+	disbursement *disburse.Disbursement
+
+	// conflict rejects the save as a concurrent update would
+	conflict bool
+
+	// saved is the status of the last save, empty when nothing is saved
+	saved disburse.Status
+
+	// payoutClaimedUntil is the payout claim of the disbursement, zero when it is not claimed
+	payoutClaimedUntil time.Time
+}
+
+func (r *fakeDisburseRepository) ListDisbursementsToDispatch(_ context.Context, _ int) ([]*disburse.Disbursement, error) {
+	if r.disbursement.Status() != disburse.StatusPending {
+		return nil, nil
+	}
+
+	return []*disburse.Disbursement{r.disbursement}, nil
+}
+
+func (r *fakeDisburseRepository) ClaimPayout(_ context.Context, _ uuid.UUID, claimUntil time.Time) error {
+	r.payoutClaimedUntil = claimUntil
+	return nil
+}
+
+func (r *fakeDisburseRepository) ClaimStalePayouts(
+	_ context.Context,
+	now time.Time,
+	claimUntil time.Time,
+	_ int,
+) ([]*disburse.Disbursement, error) {
+	if r.disbursement.Status() != disburse.StatusProcessing ||
+		r.payoutClaimedUntil.IsZero() || !r.payoutClaimedUntil.Before(now) {
+		return nil, nil
+	}
+
+	r.payoutClaimedUntil = claimUntil
+
+	return []*disburse.Disbursement{r.disbursement}, nil
+}
+
+func (r *fakeDisburseRepository) ReleasePayoutClaim(_ context.Context, _ uuid.UUID) error {
+	r.payoutClaimedUntil = time.Time{}
+	return nil
+}
+
+func (r *fakeDisburseRepository) UpdateDisbursement(
+	ctx context.Context,
+	id uuid.UUID,
+	updateFn func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error),
+) error {
+	if id != r.disbursement.ID() {
+		return errors.NewNotFoundError(
+			disburse.ErrDisbursementNotFound,
+			fmt.Sprintf("%s: %s", disburse.ErrDisbursementNotFound.Error(), id),
+			errors.DpayNotFound,
+		)
+	}
+
+	updated, err := updateFn(ctx, r.disbursement)
+	if err != nil || updated == nil {
+		return err
+	}
+
+	if r.conflict {
+		return errors.NewConflictError(
+			disburse.ErrDisbursementConflict,
+			fmt.Sprintf("%s, disbursement %s", disburse.ErrDisbursementConflict.Error(), id),
+			errors.DpayConcurrentUpdate,
+		)
+	}
+
+	r.saved = updated.Status()
+
+	return nil
+}
+
+type fakeMerchantBalance struct {
+	disburse.MerchantBalance
+
+	// reserveErr fails the reservation of the disbursements in it
+	reserveErr map[uuid.UUID]error
+
+	reserved []uuid.UUID
+	released []uuid.UUID
+}
+
+func (b *fakeMerchantBalance) Reserve(_ context.Context, _ string, disbursementID uuid.UUID, _ money.Money) error {
+	if err := b.reserveErr[disbursementID]; err != nil {
+		return err
+	}
+
+	b.reserved = append(b.reserved, disbursementID)
+
+	return nil
+}
+
+func (b *fakeMerchantBalance) Release(_ context.Context, _ string, disbursementID uuid.UUID) error {
+	b.released = append(b.released, disbursementID)
+	return nil
+}
+
+// fakeTransactionManager runs the function without transaction, the fakes keep no state to roll back
+type fakeTransactionManager struct{}
+
+func (fakeTransactionManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakePayoutProvider answers every payout with err, or with result when err is nil
+type fakePayoutProvider struct {
+	result disburse.Status
+	err    error
+
+	sent int
+}
+
+func (p *fakePayoutProvider) Payout(_ context.Context, d *disburse.Disbursement) (disburse.PayoutResult, error) {
+	p.sent++
+
+	if p.err != nil {
+		return disburse.PayoutResult{}, p.err
+	}
+
+	return disburse.PayoutResult{DisbursementID: d.ID(), Status: p.result}, nil
+}
+
+// fakeUploadRepository holds the rows of a single upload, the methods the tests do not use panic
+type fakeUploadRepository struct {
+	disburse.UploadRepository
+
+	rows      []*disburse.UploadRow
+	completed bool
+}
+
+func (r *fakeUploadRepository) ListUploadRows(
+	_ context.Context,
+	_ uuid.UUID,
+	status disburse.UploadRowStatus,
+) ([]*disburse.UploadRow, error) {
+	var rows []*disburse.UploadRow
+	for _, row := range r.rows {
+		if row.Status() == status {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func (r *fakeUploadRepository) UpdateUploadRow(_ context.Context, _ uuid.UUID, _ *disburse.UploadRow) error {
+	return nil
+}
+
+func (r *fakeUploadRepository) CompleteUpload(_ context.Context, _ *disburse.Upload) error {
+	r.completed = true
+	return nil
+}
+
+// fakeBeneficiaryRepository holds the beneficiaries in memory, the methods the tests do not use panic
+type fakeBeneficiaryRepository struct {
+	disburse.BeneficiaryRepository
+
+	beneficiaries []*disburse.Beneficiary
+}
+
+func (r *fakeBeneficiaryRepository) CreateBeneficiary(_ context.Context, beneficiary *disburse.Beneficiary) error {
+	r.beneficiaries = append(r.beneficiaries, beneficiary)
+	return nil
+}
+
+func (r *fakeBeneficiaryRepository) ListBeneficiaries(
+	_ context.Context,
+	filter disburse.BeneficiaryFilter,
+) ([]*disburse.Beneficiary, error) {
+	var beneficiaries []*disburse.Beneficiary
+	for _, b := range r.beneficiaries {
+		if b.MerchantID() == filter.MerchantID &&
+			b.BankAccount().BankCode() == filter.BankCode &&
+			b.BankAccount().AccountNumber() == filter.AccountNumber {
+			beneficiaries = append(beneficiaries, b)
+		}
+	}
+
+	return beneficiaries, nil
+}
+
+// fakeNameInquiry answers every inquiry with err, or with holderName when err is nil
+type fakeNameInquiry struct {
+	holderName string
+	err        error
+}
+
+func (n fakeNameInquiry) InquireHolderName(_ context.Context, _ disburse.BankAccount) (string, error) {
+	return n.holderName, n.err
+}
+
+// fakeDisburseHandler records the disburse commands it handles and answers them with err
+type fakeDisburseHandler struct {
+	params []*DisburseParam
+	err    error
+}
+
+func (h *fakeDisburseHandler) Handle(_ context.Context, r *DisburseParam) error {
+	h.params = append(h.params, r)
+	return h.err
+}
+
+// newTestDisbursement creates a disbursement and moves it to the status through the domain methods
+func newTestDisbursement(t *testing.T, status disburse.Status) *disburse.Disbursement {
+	t.Helper()
+
+	amount, err := money.Parse("10000", "IDR")
+	if err != nil {
+		t.Fatalf("parse amount: %v", err)
+	}
+
+	d, err := disburse.NewDisbursement(uuid.New(), "merchant-1", amount, "")
+	if err != nil {
+		t.Fatalf("new disbursement: %v", err)
+	}
+
+	steps := map[disburse.Status][]func() error{
+		disburse.StatusPending:    nil,
+		disburse.StatusProcessing: {d.StartProcessing},
+		disburse.StatusSuccess:    {d.StartProcessing, d.MarkSuccess},
+		disburse.StatusFailed:     {d.StartProcessing, func() error { return d.MarkFailed("bank rejected") }},
+	}
+
+	for _, step := range steps[status] {
+		if err := step(); err != nil {
+			t.Fatalf("move disbursement to %s: %v", status, err)
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/samber/lo"
+)
+
+// limitChecker checks new disbursements against the limits of their merchant
+type limitChecker struct {
+	limitRepo     disburse.LimitRepository
+	defaultLimits disburse.DefaultLimits
+}
+
+// check must run inside the transaction that creates the disbursements, the usage of the merchant
+// stays locked until that transaction ends so concurrent disbursements are counted one after another
+func (c limitChecker) check(ctx context.Context, merchantID string, disbursements []*disburse.Disbursement) error {
+	var (
+		byCurrency = lo.GroupBy(disbursements, func(d *disburse.Disbursement) money.Currency {
+			return d.Amount().Currency()
+		})
+		currencies = lo.Keys(byCurrency)
+		locked     bool
+	)
+
+	// a stable order, so a batch in several currencies always reports the same breach first
+	slices.Sort(currencies)
+
+	for _, currency := range currencies {
+		limits, _, err := disburse.GetEffectiveLimits(ctx, c.limitRepo, c.defaultLimits, merchantID, currency)
+		if err != nil {
+			return err
+		}
+
+		if !limits.IsLimited() {
+			continue
+		}
+
+		if !locked {
+			err = c.limitRepo.LockMerchantUsage(ctx, merchantID)
+			if err != nil {
+				return err
+			}
+
+			locked = true
+		}
+
+		usage, err := c.limitRepo.GetLimitUsage(ctx, merchantID, currency, limits.Windows(time.Now()))
+		if err != nil {
+			return err
+		}
+
+		err = limits.Check(usage, lo.Map(byCurrency[currency], func(d *disburse.Disbursement, _ int) money.Money {
+			return d.Amount()
+		})...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
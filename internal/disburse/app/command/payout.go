@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// payoutDispatcher sends stored disbursements out through the payout provider
+type payoutDispatcher struct {
+	disburseRepo    disburse.DisburseRepository
+	merchantBalance disburse.MerchantBalance
+	payoutProvider  disburse.PayoutProvider
+}
+
+// send sends a disbursement already moved into PROCESSING to the payout provider and applies the result.
+// An error leaves the outcome unknown, the caller keeps the payout claimed so it is sent again once the claim
+// expires, the provider pays a disbursement only once however often it is sent.
+func (p payoutDispatcher) send(ctx context.Context, disbursement *disburse.Disbursement) error {
+	result, err := p.payoutProvider.Payout(ctx, disbursement)
+	if stderrors.Is(err, disburse.ErrPayoutRejected) {
+		result = disburse.PayoutResult{
+			DisbursementID: disbursement.ID(),
+			Status:         disburse.StatusFailed,
+			FailureReason:  err.Error(),
+		}
+	} else if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// an accepted payout without result yet is settled by the provider callback
+	if result.Status == disburse.StatusProcessing {
+		return nil
+	}
+
+	// the provider callback may already have applied the result, then it is left as is
+	err = moveStatus(ctx, p.disburseRepo, p.merchantBalance, disbursement.ID(), result.Status, result.FailureReason)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// releaseReservation gives back the reservation of a disbursement that failed to be created,
+// the failure is only logged so the caller gets the original error
+func releaseReservation(
+	ctx context.Context,
+	merchantBalance disburse.MerchantBalance,
+	disbursement *disburse.Disbursement,
+) {
+	err := merchantBalance.Release(ctx, disbursement.MerchantID(), disbursement.ID())
+	if err != nil {
+		logDisbursementError(ctx, disbursement, "failed to release merchant balance", err)
+	}
+}
+
+func logDisbursementError(ctx context.Context, disbursement *disburse.Disbursement, msg string, err error) {
+	logger.Errorw(
+		ctx,
+		msg,
+		"disbursement_id", disbursement.ID().String(),
+		"merchant_id", disbursement.MerchantID(),
+		"error", err.Error(),
+	)
+}
@@ -0,0 +1,218 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type ProcessUploadsParam struct {
+	// Limit is the most uploads claimed in a single run
+	Limit int
+
+	// ClaimTimeout is how long a claimed upload is hidden from the other schedulers,
+	// it must be longer than disbursing the rows of Limit uploads takes
+	ClaimTimeout time.Duration
+}
+
+type ProcessUploadsHandler decorator.CommandHandler[*ProcessUploadsParam]
+
+type processUploadsHandler struct {
+	uploadRepo        disburse.UploadRepository
+	beneficiaryRepo   disburse.BeneficiaryRepository
+	createBeneficiary CreateBeneficiaryHandler
+	disburse          DisburseHandler
+}
+
+// Handle claims the uploads still processing and disburses their pending rows through the Disburse command,
+// so it is safe to run on several instances at once
+func (h processUploadsHandler) Handle(
+	ctx context.Context,
+	r *ProcessUploadsParam,
+) error {
+	now := time.Now().UTC()
+
+	uploads, err := h.uploadRepo.ClaimProcessingUploads(ctx, now, now.Add(r.ClaimTimeout), r.Limit)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	for _, upload := range uploads {
+		if ctx.Err() != nil {
+			// the claims left expire and the uploads are picked up again
+			return nil
+		}
+
+		h.process(ctx, upload)
+	}
+
+	return nil
+}
+
+// process disburses the pending rows one by one to the beneficiary of their bank account, a row rejected for its
+// request is marked failed and does not stop the other rows. A row failing for a server error stops the upload
+// with its claim kept, the row is retried with the same idempotency key once the claim expires.
+func (h processUploadsHandler) process(ctx context.Context, upload *disburse.Upload) {
+	rows, err := h.uploadRepo.ListUploadRows(ctx, upload.ID(), disburse.UploadRowStatusPending)
+	if err != nil {
+		logUploadError(ctx, upload, "failed to list pending disbursement upload rows", err)
+		return
+	}
+
+	for _, row := range rows {
+		disbursementID, err := h.disburseRow(ctx, upload, row)
+		if err != nil && (ctx.Err() != nil || !errors.IsClientError(err)) {
+			logUploadError(
+				ctx,
+				upload,
+				"failed to disburse upload row, retrying after the claim expires",
+				err,
+				"row_number", row.Number(),
+			)
+
+			return
+		}
+
+		if err != nil {
+			row.MarkFailed(err.Error())
+		} else {
+			row.MarkDisbursed(disbursementID)
+		}
+
+		err = h.uploadRepo.UpdateUploadRow(ctx, upload.ID(), row)
+		if err != nil {
+			logUploadError(ctx, upload, "failed to update disbursement upload row", err, "row_number", row.Number())
+			return
+		}
+	}
+
+	upload.Complete()
+
+	err = h.uploadRepo.CompleteUpload(ctx, upload)
+	if err != nil {
+		logUploadError(ctx, upload, "failed to complete disbursement upload", err)
+	}
+}
+
+// disburseRow disburses the row to the beneficiary of its bank account and returns the disbursement id
+func (h processUploadsHandler) disburseRow(
+	ctx context.Context,
+	upload *disburse.Upload,
+	row *disburse.UploadRow,
+) (uuid.UUID, error) {
+	beneficiaryID, err := h.resolveBeneficiary(ctx, upload.MerchantID(), row.BankAccount())
+	if err != nil {
+		return uuid.Nil, errors.WrapDpayErrTrace(err)
+	}
+
+	idempotencyKey := upload.RowIdempotencyKey(row)
+	disbursementID := disburse.NewDisbursementID(upload.MerchantID(), idempotencyKey)
+
+	err = h.disburse.Handle(ctx, &DisburseParam{
+		ID:             disbursementID,
+		MerchantID:     upload.MerchantID(),
+		IdempotencyKey: idempotencyKey,
+		Amount:         row.Amount().Decimal(),
+		Currency:       row.Amount().Currency().String(),
+		BeneficiaryID:  beneficiaryID,
+		CreatedBy:      upload.CreatedBy(),
+	})
+	if err != nil {
+		return uuid.Nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return disbursementID, nil
+}
+
+// resolveBeneficiary returns the active beneficiary of the merchant with the bank account, it is registered through
+// the CreateBeneficiary command when the merchant has none so the holder name is inquired at the bank.
+// An account unknown to the bank is a client error that fails the row.
+func (h processUploadsHandler) resolveBeneficiary(
+	ctx context.Context,
+	merchantID string,
+	bankAccount disburse.BankAccount,
+) (uuid.UUID, error) {
+	beneficiaryID, err := h.findBeneficiary(ctx, merchantID, bankAccount)
+	if err != nil || beneficiaryID != uuid.Nil {
+		return beneficiaryID, err
+	}
+
+	beneficiaryID = uuid.New()
+
+	err = h.createBeneficiary.Handle(ctx, &CreateBeneficiaryParam{
+		ID:            beneficiaryID,
+		MerchantID:    merchantID,
+		BankCode:      bankAccount.BankCode(),
+		AccountNumber: bankAccount.AccountNumber(),
+	})
+	if errors.IsConflictError(err) {
+		// a concurrent request registered the same bank account in the meantime
+		return h.findBeneficiary(ctx, merchantID, bankAccount)
+	}
+
+	if err != nil {
+		return uuid.Nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return beneficiaryID, nil
+}
+
+// findBeneficiary returns the id of the active beneficiary of the merchant with the bank account, uuid.Nil if none
+func (h processUploadsHandler) findBeneficiary(
+	ctx context.Context,
+	merchantID string,
+	bankAccount disburse.BankAccount,
+) (uuid.UUID, error) {
+	beneficiaries, err := h.beneficiaryRepo.ListBeneficiaries(ctx, disburse.BeneficiaryFilter{
+		MerchantID:    merchantID,
+		BankCode:      bankAccount.BankCode(),
+		AccountNumber: bankAccount.AccountNumber(),
+		Limit:         1,
+	})
+	if err != nil {
+		return uuid.Nil, errors.WrapDpayErrTrace(err)
+	}
+
+	if len(beneficiaries) == 0 {
+		return uuid.Nil, nil
+	}
+
+	return beneficiaries[0].ID(), nil
+}
+
+func logUploadError(ctx context.Context, upload *disburse.Upload, msg string, err error, keysAndValues ...any) {
+	logger.Errorw(
+		ctx,
+		msg,
+		append(
+			[]any{
+				"upload_id", upload.ID().String(),
+				"merchant_id", upload.MerchantID(),
+				"error", err.Error(),
+			},
+			keysAndValues...,
+		)...,
+	)
+}
+
+func NewProcessUploadsHandler(
+	uploadRepo disburse.UploadRepository,
+	beneficiaryRepo disburse.BeneficiaryRepository,
+	createBeneficiaryHandler CreateBeneficiaryHandler,
+	disburseHandler DisburseHandler,
+) ProcessUploadsHandler {
+	return decorator.ApplyCommandDecorators(
+		&processUploadsHandler{
+			uploadRepo:        uploadRepo,
+			beneficiaryRepo:   beneficiaryRepo,
+			createBeneficiary: createBeneficiaryHandler,
+			disburse:          disburseHandler,
+		},
+	)
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+func TestProcessUploads(t *testing.T) {
+	bankAccount, err := disburse.NewBankAccount("BCA", "1234567890")
+	if err != nil {
+		t.Fatalf("new bank account: %v", err)
+	}
+
+	existing, err := disburse.NewBeneficiary(uuid.New(), "merchant-1", bankAccount, "JOHN DOE")
+	if err != nil {
+		t.Fatalf("new beneficiary: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		beneficiaries []*disburse.Beneficiary
+		nameInquiry   fakeNameInquiry
+
+		status disburse.UploadRowStatus
+
+		// beneficiaryID is the beneficiary the row is disbursed to, uuid.Nil expects a new one
+		beneficiaryID uuid.UUID
+		completed     bool
+	}{
+		{
+			name:          "existing beneficiary",
+			beneficiaries: []*disburse.Beneficiary{existing},
+			status:        disburse.UploadRowStatusDisbursed,
+			beneficiaryID: existing.ID(),
+			completed:     true,
+		},
+		{
+			name:        "new beneficiary is registered",
+			nameInquiry: fakeNameInquiry{holderName: "JOHN DOE"},
+			status:      disburse.UploadRowStatusDisbursed,
+			completed:   true,
+		},
+		{
+			name: "account unknown to the bank fails the row",
+			nameInquiry: fakeNameInquiry{err: errors.NewUnprocessableEntityError(
+				disburse.ErrBankAccountNotFound,
+				disburse.ErrBankAccountNotFound.Error(),
+				errors.DpayBankAccountNotFound,
+			)},
+			status:    disburse.UploadRowStatusFailed,
+			completed: true,
+		},
+		{
+			name:        "failed inquiry retries the row",
+			nameInquiry: fakeNameInquiry{err: stderrors.New("connection reset")},
+			status:      disburse.UploadRowStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := money.Parse("10000", "IDR")
+			if err != nil {
+				t.Fatalf("parse amount: %v", err)
+			}
+
+			row, err := disburse.NewUploadRow(2, "ref-1", amount, bankAccount)
+			if err != nil {
+				t.Fatalf("new upload row: %v", err)
+			}
+
+			upload, err := disburse.NewUpload(
+				uuid.New(),
+				"merchant-1",
+				"ops-user-1",
+				"disbursements.csv",
+				[]*disburse.UploadRow{row},
+			)
+			if err != nil {
+				t.Fatalf("new upload: %v", err)
+			}
+
+			uploadRepo := &fakeUploadRepository{rows: []*disburse.UploadRow{row}}
+			beneficiaryRepo := &fakeBeneficiaryRepository{beneficiaries: tt.beneficiaries}
+			disburseHandler := &fakeDisburseHandler{}
+
+			h := processUploadsHandler{
+				uploadRepo:        uploadRepo,
+				beneficiaryRepo:   beneficiaryRepo,
+				createBeneficiary: createBeneficiaryHandler{beneficiaryRepo, tt.nameInquiry},
+				disburse:          disburseHandler,
+			}
+
+			h.process(context.Background(), upload)
+
+			if row.Status() != tt.status {
+				t.Fatalf("row status = %s, want %s (%s)", row.Status(), tt.status, row.FailureReason())
+			}
+
+			if uploadRepo.completed != tt.completed {
+				t.Errorf("upload completed = %v, want %v", uploadRepo.completed, tt.completed)
+			}
+
+			if tt.status != disburse.UploadRowStatusDisbursed {
+				if len(disburseHandler.params) != 0 {
+					t.Errorf("disbursed %d times, want 0", len(disburseHandler.params))
+				}
+
+				return
+			}
+
+			if len(disburseHandler.params) != 1 || len(beneficiaryRepo.beneficiaries) != 1 {
+				t.Fatalf("disbursed %d times to %d beneficiaries, want once to 1",
+					len(disburseHandler.params), len(beneficiaryRepo.beneficiaries))
+			}
+
+			param := disburseHandler.params[0]
+
+			beneficiaryID := tt.beneficiaryID
+			if beneficiaryID == uuid.Nil {
+				beneficiaryID = beneficiaryRepo.beneficiaries[0].ID()
+			}
+
+			if param.BeneficiaryID != beneficiaryID {
+				t.Errorf("disbursed to beneficiary %s, want %s", param.BeneficiaryID, beneficiaryID)
+			}
+
+			// the uploader is the maker of the row disbursement so they can not approve it
+			if param.CreatedBy != "ops-user-1" {
+				t.Errorf("created by = %q, want the uploader", param.CreatedBy)
+			}
+
+			// the reference is scoped to the upload so it never collides with an API idempotency key
+			wantKey := "upload:" + upload.ID().String() + ":ref-1"
+			if param.IdempotencyKey != wantKey || row.DisbursementID() != param.ID {
+				t.Errorf("idempotency key = %q, disbursement %s, want %q, disbursement %s",
+					param.IdempotencyKey, row.DisbursementID(), wantKey, param.ID)
+			}
+		})
+	}
+}
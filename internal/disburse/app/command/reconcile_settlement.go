@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/samber/lo"
+)
+
+type ReconcileSettlementParam struct {
+	ID uuid.UUID
+
+	// Provider picks the parser of the report, it is the payout provider that sent it
+	Provider string
+	FileName string
+	Report   io.Reader
+
+	// PeriodStart and PeriodEnd bound the completion time of the disbursements the report should settle
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+type ReconcileSettlementHandler decorator.CommandHandler[*ReconcileSettlementParam]
+
+type reconcileSettlementHandler struct {
+	disburseRepo       disburse.DisburseRepository
+	reconciliationRepo disburse.ReconciliationRepository
+
+	// parsers holds the settlement parser per provider
+	parsers map[string]disburse.SettlementParser
+}
+
+// Handle parses the settlement report, matches its lines to the disbursements and stores the run
+// with its discrepancies. The stored run is read with the GetReconciliationRun query.
+func (h reconcileSettlementHandler) Handle(
+	ctx context.Context,
+	r *ReconcileSettlementParam,
+) error {
+	parser, ok := h.parsers[r.Provider]
+	if !ok {
+		return errors.NewIncorrectInputError(
+			disburse.ErrUnsupportedSettlementProvider,
+			fmt.Sprintf("%s: %q", disburse.ErrUnsupportedSettlementProvider.Error(), r.Provider),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	lines, err := parser.Parse(ctx, r.Report)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// a line outside the period still refers to a disbursement, it is looked up by its reference
+	ids := lo.Uniq(lo.FilterMap(lines, func(line disburse.SettlementLine, _ int) (uuid.UUID, bool) {
+		id, err := uuid.Parse(line.Reference)
+		return id, err == nil
+	}))
+
+	referenced, err := h.disburseRepo.GetDisbursementsByIDs(ctx, ids)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	paidOut, err := h.disburseRepo.ListPaidOutDisbursements(ctx, r.PeriodStart, r.PeriodEnd)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	run, discrepancies, err := disburse.Reconcile(
+		r.ID,
+		r.Provider,
+		r.FileName,
+		r.PeriodStart,
+		r.PeriodEnd,
+		lines,
+		referenced,
+		paidOut,
+	)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.reconciliationRepo.CreateRun(ctx, run, discrepancies)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewReconcileSettlementHandler(
+	disburseRepo disburse.DisburseRepository,
+	reconciliationRepo disburse.ReconciliationRepository,
+	parsers map[string]disburse.SettlementParser,
+) ReconcileSettlementHandler {
+	return decorator.ApplyCommandDecorators(
+		&reconcileSettlementHandler{
+			disburseRepo:       disburseRepo,
+			reconciliationRepo: reconciliationRepo,
+			parsers:            parsers,
+		},
+	)
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type RejectDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the rejection to the disbursements of the merchant, empty is not limited
+	MerchantID string
+
+	// ApproverID is the user rejecting with ApproverRole, the role must be an approver role of the merchant
+	ApproverID   string
+	ApproverRole string
+	Reason       string
+}
+
+type RejectDisbursementHandler decorator.CommandHandler[*RejectDisbursementParam]
+
+type rejectDisbursementHandler struct {
+	disburseRepo    disburse.DisburseRepository
+	approvalRepo    disburse.ApprovalRepository
+	merchantBalance disburse.MerchantBalance
+}
+
+// Handle rejects a disbursement awaiting approval and gives its reserved balance back once the rejection is saved,
+// the rejection is added to the approval chain in the same transaction as the status change
+func (h rejectDisbursementHandler) Handle(
+	ctx context.Context,
+	r *RejectDisbursementParam,
+) error {
+	var rejected *disburse.Disbursement
+
+	err := h.disburseRepo.UpdateDisbursement(
+		ctx,
+		r.ID,
+		func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error) {
+			err := checkMerchant(disbursement, r.MerchantID)
+			if err != nil {
+				return nil, err
+			}
+
+			rejected = disbursement
+
+			chain, policy, err := getApprovalChain(ctx, h.approvalRepo, disbursement)
+			if err != nil {
+				return nil, err
+			}
+
+			step, err := disbursement.Reject(chain, r.ApproverID, r.ApproverRole, policy, r.Reason)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.approvalRepo.AddApprovalStep(ctx, step)
+			if err != nil {
+				return nil, err
+			}
+
+			return disbursement, nil
+		},
+	)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return releaseSettledBalance(ctx, h.merchantBalance, rejected)
+}
+
+func NewRejectDisbursementHandler(
+	disburseRepo disburse.DisburseRepository,
+	approvalRepo disburse.ApprovalRepository,
+	merchantBalance disburse.MerchantBalance,
+) RejectDisbursementHandler {
+	return decorator.ApplyCommandDecorators(
+		&rejectDisbursementHandler{
+			disburseRepo,
+			approvalRepo,
+			merchantBalance,
+		},
+	)
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type ReverseDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the reversal to the disbursements of the merchant, empty is not limited
+	MerchantID string
+	Reason     string
+
+	// Actor is who asked for the reversal and Source the channel it came through, both are kept in the audit trail
+	Actor  string
+	Source string
+}
+
+type ReverseDisbursementHandler decorator.CommandHandler[*ReverseDisbursementParam]
+
+type reverseDisbursementHandler struct {
+	disburseRepo    disburse.DisburseRepository
+	auditRepo       disburse.AuditRepository
+	merchantBalance disburse.MerchantBalance
+}
+
+// Handle reverses a SUCCESS disbursement, the reversal record compensates the payout and its amount
+// is refunded to the merchant once the reversal is saved. The reversal, the status change and the audit entry
+// are written in one transaction.
+func (h reverseDisbursementHandler) Handle(
+	ctx context.Context,
+	r *ReverseDisbursementParam,
+) error {
+	if r.Actor == "" {
+		return errors.NewIncorrectInputError(
+			disburse.ErrEmptyAuditActor,
+			disburse.ErrEmptyAuditActor.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	var reversal *disburse.Reversal
+
+	err := h.disburseRepo.UpdateDisbursement(
+		ctx,
+		r.ID,
+		func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error) {
+			err := checkMerchant(disbursement, r.MerchantID)
+			if err != nil {
+				return nil, err
+			}
+
+			// a redelivered reversal finds the disbursement already reversed, only the refund is retried
+			if disbursement.Status() == disburse.StatusReversed {
+				reversal, err = h.disburseRepo.GetReversal(ctx, disbursement.ID())
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, nil
+			}
+
+			fromStatus := disbursement.Status()
+
+			reversal, err = disbursement.Reverse(r.Reason)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.disburseRepo.CreateReversal(ctx, reversal)
+			if err != nil {
+				return nil, err
+			}
+
+			entry, err := disburse.NewAuditEntry(
+				disburse.AuditActionReverse,
+				disbursement,
+				fromStatus,
+				r.Actor,
+				r.Source,
+				r.Reason,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.auditRepo.AddAuditEntry(ctx, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			return disbursement, nil
+		},
+	)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	// refunded once the reversal is saved, the refund is referenced by the reversal id
+	// so a redelivered reversal refunds again safely when the refund failed
+	err = h.merchantBalance.Refund(ctx, reversal.MerchantID(), reversal.ID(), reversal.Amount())
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewReverseDisbursementHandler(
+	disburseRepo disburse.DisburseRepository,
+	auditRepo disburse.AuditRepository,
+	merchantBalance disburse.MerchantBalance,
+) ReverseDisbursementHandler {
+	return decorator.ApplyCommandDecorators(
+		&reverseDisbursementHandler{
+			disburseRepo,
+			auditRepo,
+			merchantBalance,
+		},
+	)
+}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type RunDueSchedulesParam struct {
+	// Limit is the most schedules claimed in a single run
+	Limit int
+
+	// ClaimTimeout is how long a claimed schedule is hidden from the other schedulers,
+	// it must be longer than running Limit schedules takes
+	ClaimTimeout time.Duration
+}
+
+type RunDueSchedulesHandler decorator.CommandHandler[*RunDueSchedulesParam]
+
+type runDueSchedulesHandler struct {
+	scheduleRepo disburse.ScheduleRepository
+	disburse     DisburseHandler
+}
+
+// Handle claims the due schedules and creates their disbursements through the Disburse command,
+// so it is safe to run on several instances at once
+func (h runDueSchedulesHandler) Handle(
+	ctx context.Context,
+	r *RunDueSchedulesParam,
+) error {
+	now := time.Now().UTC()
+
+	schedules, err := h.scheduleRepo.ClaimDueSchedules(ctx, now, now.Add(r.ClaimTimeout), r.Limit)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			// the claims left expire and the schedules are picked up again
+			return nil
+		}
+
+		h.run(ctx, schedule)
+	}
+
+	return nil
+}
+
+// run creates the disbursement of the run. A run rejected for its request, e.g. an insufficient balance,
+// is recorded and the schedule moves on. A run failing for a server error keeps its claim,
+// so it is retried with the same idempotency key once the claim expires.
+func (h runDueSchedulesHandler) run(ctx context.Context, schedule *disburse.ScheduledDisbursement) {
+	idempotencyKey := schedule.RunIdempotencyKey()
+
+	err := h.disburse.Handle(ctx, &DisburseParam{
+		ID:             disburse.NewDisbursementID(schedule.MerchantID(), idempotencyKey),
+		MerchantID:     schedule.MerchantID(),
+		IdempotencyKey: idempotencyKey,
+		Amount:         schedule.Amount().Decimal(),
+		Currency:       schedule.Amount().Currency().String(),
+	})
+	if err != nil && (ctx.Err() != nil || !errors.IsClientError(err)) {
+		logScheduleError(ctx, schedule, "failed to run scheduled disbursement, retrying after the claim expires", err)
+		return
+	}
+
+	var failureReason string
+	if err != nil {
+		failureReason = err.Error()
+		logScheduleError(ctx, schedule, "scheduled disbursement run was rejected", err)
+	}
+
+	err = schedule.Advance(time.Now().UTC(), failureReason)
+	if err != nil {
+		logScheduleError(ctx, schedule, "failed to advance scheduled disbursement", err)
+		return
+	}
+
+	err = h.scheduleRepo.UpdateSchedule(ctx, schedule)
+	if err != nil {
+		logScheduleError(ctx, schedule, "failed to update scheduled disbursement", err)
+	}
+}
+
+func logScheduleError(ctx context.Context, schedule *disburse.ScheduledDisbursement, msg string, err error) {
+	logger.Errorw(
+		ctx,
+		msg,
+		"schedule_id", schedule.ID().String(),
+		"merchant_id", schedule.MerchantID(),
+		"run_count", schedule.RunCount(),
+		"error", err.Error(),
+	)
+}
+
+func NewRunDueSchedulesHandler(
+	scheduleRepo disburse.ScheduleRepository,
+	disburseHandler DisburseHandler,
+) RunDueSchedulesHandler {
+	return decorator.ApplyCommandDecorators(
+		&runDueSchedulesHandler{
+			scheduleRepo: scheduleRepo,
+			disburse:     disburseHandler,
+		},
+	)
+}
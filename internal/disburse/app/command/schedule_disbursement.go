@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type ScheduleDisbursementParam struct {
+	ID         uuid.UUID
+	MerchantID string
+
+	// Amount is the exact decimal amount in major units, e.g. "10000.50"
+	Amount   string
+	Currency string
+
+	FirstRunAt time.Time
+
+	// Recurrence is the cron rule of the runs after the first, e.g. "0 9 * * 1" or "@monthly",
+	// empty runs only once
+	Recurrence string
+
+	// EndAt stops a recurring schedule, zero runs until it is cancelled
+	EndAt time.Time
+}
+
+type ScheduleDisbursementHandler decorator.CommandHandler[*ScheduleDisbursementParam]
+
+type scheduleDisbursementHandler struct {
+	scheduleRepo disburse.ScheduleRepository
+}
+
+func (h scheduleDisbursementHandler) Handle(
+	ctx context.Context,
+	r *ScheduleDisbursementParam,
+) error {
+	amount, err := money.Parse(r.Amount, r.Currency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	schedule, err := disburse.NewScheduledDisbursement(r.ID, r.MerchantID, amount, r.FirstRunAt, r.Recurrence, r.EndAt)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.scheduleRepo.CreateSchedule(ctx, schedule)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewScheduleDisbursementHandler(
+	scheduleRepo disburse.ScheduleRepository,
+) ScheduleDisbursementHandler {
+	return decorator.ApplyCommandDecorators(
+		&scheduleDisbursementHandler{
+			scheduleRepo,
+		},
+	)
+}
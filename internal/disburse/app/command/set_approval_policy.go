@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type SetApprovalPolicyParam struct {
+	MerchantID string
+	Currency   string
+
+	// Threshold is the exact decimal amount in major units a disbursement must be above to need approval
+	Threshold string
+
+	// RequiredApprovals approvers holding one of ApproverRoles must approve within ApprovalWindow
+	ApproverRoles     []string
+	RequiredApprovals int
+	ApprovalWindow    time.Duration
+}
+
+type SetApprovalPolicyHandler decorator.CommandHandler[*SetApprovalPolicyParam]
+
+type setApprovalPolicyHandler struct {
+	approvalRepo disburse.ApprovalRepository
+}
+
+// Handle replaces the approval policy of the merchant in the currency,
+// the disbursements already awaiting approval keep the approvals and the deadline they were created with
+func (h setApprovalPolicyHandler) Handle(
+	ctx context.Context,
+	r *SetApprovalPolicyParam,
+) error {
+	if r.MerchantID == "" {
+		return errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	threshold, err := money.Parse(r.Threshold, r.Currency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	policy, err := disburse.NewApprovalPolicy(threshold, r.ApproverRoles, r.RequiredApprovals, r.ApprovalWindow)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.approvalRepo.SetApprovalPolicy(ctx, r.MerchantID, policy)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewSetApprovalPolicyHandler(
+	approvalRepo disburse.ApprovalRepository,
+) SetApprovalPolicyHandler {
+	return decorator.ApplyCommandDecorators(
+		&setApprovalPolicyHandler{
+			approvalRepo: approvalRepo,
+		},
+	)
+}
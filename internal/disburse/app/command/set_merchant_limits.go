@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type SetMerchantLimitsParam struct {
+	MerchantID string
+	Currency   string
+
+	// PerTransaction, DailyTotal and MonthlyTotal are exact decimal amounts in major units, empty is not limited
+	PerTransaction string
+	DailyTotal     string
+	MonthlyTotal   string
+
+	// MaxCount disbursements are allowed within CountWindow, zero for both is not limited
+	MaxCount    int
+	CountWindow time.Duration
+}
+
+type SetMerchantLimitsHandler decorator.CommandHandler[*SetMerchantLimitsParam]
+
+type setMerchantLimitsHandler struct {
+	limitRepo disburse.LimitRepository
+}
+
+// Handle replaces the limits of the merchant in the currency, the merchant no longer gets the default limits of it
+func (h setMerchantLimitsHandler) Handle(
+	ctx context.Context,
+	r *SetMerchantLimitsParam,
+) error {
+	if r.MerchantID == "" {
+		return errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	currency, err := money.ParseCurrency(r.Currency)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	amounts := make([]money.Money, 0, 3)
+	for _, amount := range []string{r.PerTransaction, r.DailyTotal, r.MonthlyTotal} {
+		if amount == "" {
+			amounts = append(amounts, money.Money{})
+			continue
+		}
+
+		parsed, err := money.Parse(amount, currency.String())
+		if err != nil {
+			return errors.WrapDpayErrTrace(err)
+		}
+
+		amounts = append(amounts, parsed)
+	}
+
+	limits, err := disburse.NewLimits(currency, amounts[0], amounts[1], amounts[2], r.MaxCount, r.CountWindow)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.limitRepo.SetMerchantLimits(ctx, r.MerchantID, limits)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func NewSetMerchantLimitsHandler(
+	limitRepo disburse.LimitRepository,
+) SetMerchantLimitsHandler {
+	return decorator.ApplyCommandDecorators(
+		&setMerchantLimitsHandler{
+			limitRepo: limitRepo,
+		},
+	)
+}
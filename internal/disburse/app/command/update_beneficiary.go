@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type UpdateBeneficiaryParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the update to the beneficiaries of the merchant, empty is not limited
+	MerchantID string
+
+	BankCode      string
+	AccountNumber string
+
+	// HolderName is checked against the name registered at the bank, empty takes the registered name as is
+	HolderName string
+}
+
+type UpdateBeneficiaryHandler decorator.CommandHandler[*UpdateBeneficiaryParam]
+
+type updateBeneficiaryHandler struct {
+	beneficiaryRepo disburse.BeneficiaryRepository
+	nameInquiry     disburse.NameInquiry
+}
+
+// Handle replaces the bank account of the beneficiary, the name of the new account is inquired again
+func (h updateBeneficiaryHandler) Handle(
+	ctx context.Context,
+	r *UpdateBeneficiaryParam,
+) error {
+	bankAccount, err := disburse.NewBankAccount(r.BankCode, r.AccountNumber)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	beneficiary, err := getActiveBeneficiary(ctx, h.beneficiaryRepo, r.ID, r.MerchantID)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	holderName, err := inquireHolderName(ctx, h.nameInquiry, bankAccount, r.HolderName)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = beneficiary.Update(bankAccount, holderName)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.beneficiaryRepo.UpdateBeneficiary(ctx, beneficiary)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// getActiveBeneficiary returns the beneficiary of the merchant, empty merchantID is not limited
+func getActiveBeneficiary(
+	ctx context.Context,
+	beneficiaryRepo disburse.BeneficiaryRepository,
+	id uuid.UUID,
+	merchantID string,
+) (*disburse.Beneficiary, error) {
+	beneficiary, err := beneficiaryRepo.GetBeneficiary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// a beneficiary of another merchant or a deleted one is reported as not found so its existence is not leaked
+	if (merchantID != "" && beneficiary.MerchantID() != merchantID) || beneficiary.IsDeleted() {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrBeneficiaryNotFound,
+			disburse.ErrBeneficiaryNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	return beneficiary, nil
+}
+
+func NewUpdateBeneficiaryHandler(
+	beneficiaryRepo disburse.BeneficiaryRepository,
+	nameInquiry disburse.NameInquiry,
+) UpdateBeneficiaryHandler {
+	return decorator.ApplyCommandDecorators(
+		&updateBeneficiaryHandler{
+			beneficiaryRepo,
+			nameInquiry,
+		},
+	)
+}
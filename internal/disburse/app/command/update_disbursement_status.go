@@ -0,0 +1,166 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type UpdateDisbursementStatusParam struct {
+	ID     uuid.UUID
+	Status string
+
+	// FailureReason is only used when moving to FAILED
+	FailureReason string
+}
+
+type UpdateDisbursementStatusHandler decorator.CommandHandler[*UpdateDisbursementStatusParam]
+
+type updateDisbursementStatusHandler struct {
+	disburseRepo    disburse.DisburseRepository
+	merchantBalance disburse.MerchantBalance
+}
+
+func (h updateDisbursementStatusHandler) Handle(
+	ctx context.Context,
+	r *UpdateDisbursementStatusParam,
+) error {
+	target := disburse.Status(strings.ToUpper(strings.TrimSpace(r.Status)))
+	if !target.IsValid() {
+		return errors.NewIncorrectInputError(
+			disburse.ErrInvalidStatus,
+			fmt.Sprintf("%s: %q", disburse.ErrInvalidStatus.Error(), r.Status),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	// cancellation and reversal are audited, they only go through their own commands
+	if target == disburse.StatusCancelled || target == disburse.StatusReversed {
+		return errors.NewIncorrectInputError(
+			disburse.ErrInvalidStatus,
+			fmt.Sprintf("%s: %s is set by its own command", disburse.ErrInvalidStatus.Error(), target),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	err := moveStatus(ctx, h.disburseRepo, h.merchantBalance, r.ID, target, r.FailureReason)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// moveStatus moves the disbursement to the target status with saveStatus and releases its balance once it is saved,
+// it must not run inside a transaction since the release would happen before the commit
+func moveStatus(
+	ctx context.Context,
+	disburseRepo disburse.DisburseRepository,
+	merchantBalance disburse.MerchantBalance,
+	id uuid.UUID,
+	target disburse.Status,
+	failureReason string,
+) error {
+	moved, err := saveStatus(ctx, disburseRepo, id, target, failureReason)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return releaseSettledBalance(ctx, merchantBalance, moved)
+}
+
+// saveStatus moves the disbursement to the target status and saves it, a disbursement already in the target status
+// is left as is so a redelivered update is a no-op. A concurrent update of the disbursement fails it with
+// disburse.ErrDisbursementConflict. It returns the disbursement as saved.
+func saveStatus(
+	ctx context.Context,
+	disburseRepo disburse.DisburseRepository,
+	id uuid.UUID,
+	target disburse.Status,
+	failureReason string,
+) (*disburse.Disbursement, error) {
+	var moved *disburse.Disbursement
+
+	err := disburseRepo.UpdateDisbursement(
+		ctx,
+		id,
+		func(ctx context.Context, disbursement *disburse.Disbursement) (*disburse.Disbursement, error) {
+			moved = disbursement
+
+			if disbursement.Status() == target {
+				return nil, nil
+			}
+
+			err := transition(disbursement, target, failureReason)
+			if err != nil {
+				return nil, err
+			}
+
+			return disbursement, nil
+		},
+	)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return moved, nil
+}
+
+// releaseSettledBalance gives the merchant balance back when the saved status of the disbursement releases it.
+// It must run after the update is committed, a release before it could lose the race against a concurrent update
+// and give back the balance of a disbursement still paid out. Releasing is a no-op on the same reference,
+// so a redelivered update finding the status already saved releases again and recovers a failed release.
+func releaseSettledBalance(
+	ctx context.Context,
+	merchantBalance disburse.MerchantBalance,
+	disbursement *disburse.Disbursement,
+) error {
+	if disbursement == nil || !disbursement.Status().ReleasesBalance() {
+		return nil
+	}
+
+	err := merchantBalance.Release(ctx, disbursement.MerchantID(), disbursement.ID())
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// transition calls the domain method of the target status, so every status keeps its own side effects
+func transition(d *disburse.Disbursement, target disburse.Status, failureReason string) error {
+	switch target {
+	case disburse.StatusProcessing:
+		return d.StartProcessing()
+	case disburse.StatusSuccess:
+		return d.MarkSuccess()
+	case disburse.StatusFailed:
+		return d.MarkFailed(failureReason)
+	case disburse.StatusCancelled:
+		return d.Cancel()
+	default:
+		return errors.NewUnprocessableEntityError(
+			disburse.ErrInvalidStatusTransition,
+			fmt.Sprintf("disbursement %s can not move from %s to %s", d.ID(), d.Status(), target),
+			errors.DpayInvalidStatusTransition,
+		)
+	}
+}
+
+func NewUpdateDisbursementStatusHandler(
+	disburseRepo disburse.DisburseRepository,
+	merchantBalance disburse.MerchantBalance,
+) UpdateDisbursementStatusHandler {
+	return decorator.ApplyCommandDecorators(
+		&updateDisbursementStatusHandler{
+			disburseRepo,
+			merchantBalance,
+		},
+	)
+}
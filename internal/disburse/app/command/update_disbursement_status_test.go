@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+)
+
+func TestUpdateDisbursementStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     disburse.Status
+		target   string
+		conflict bool
+		saved    disburse.Status
+		released bool
+		wantErr  error
+	}{
+		{
+			name:   "paid out",
+			from:   disburse.StatusProcessing,
+			target: "SUCCESS",
+			saved:  disburse.StatusSuccess,
+		},
+		{
+			name:     "failed payout releases the balance",
+			from:     disburse.StatusProcessing,
+			target:   "failed",
+			saved:    disburse.StatusFailed,
+			released: true,
+		},
+		{
+			name:     "conflicting update does not release the balance",
+			from:     disburse.StatusProcessing,
+			target:   "FAILED",
+			conflict: true,
+			wantErr:  disburse.ErrDisbursementConflict,
+		},
+		{
+			name:   "redelivered success is a no-op",
+			from:   disburse.StatusSuccess,
+			target: "SUCCESS",
+		},
+		{
+			name:     "redelivered failure releases again",
+			from:     disburse.StatusFailed,
+			target:   "FAILED",
+			released: true,
+		},
+		{
+			name:    "invalid transition",
+			from:    disburse.StatusPending,
+			target:  "SUCCESS",
+			wantErr: disburse.ErrInvalidStatusTransition,
+		},
+		{
+			name:    "cancellation goes through its own command",
+			from:    disburse.StatusPending,
+			target:  "CANCELLED",
+			wantErr: disburse.ErrInvalidStatus,
+		},
+		{
+			name:    "unknown status",
+			from:    disburse.StatusPending,
+			target:  "DONE",
+			wantErr: disburse.ErrInvalidStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeDisburseRepository{
+				disbursement: newTestDisbursement(t, tt.from),
+				conflict:     tt.conflict,
+			}
+			balance := &fakeMerchantBalance{}
+
+			h := updateDisbursementStatusHandler{disburseRepo: repo, merchantBalance: balance}
+
+			err := h.Handle(context.Background(), &UpdateDisbursementStatusParam{
+				ID:            repo.disbursement.ID(),
+				Status:        tt.target,
+				FailureReason: "bank rejected",
+			})
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("Handle error = %v, want %v", err, tt.wantErr)
+			}
+
+			if repo.saved != tt.saved {
+				t.Errorf("saved status = %q, want %q", repo.saved, tt.saved)
+			}
+
+			if released := len(balance.released) > 0; released != tt.released {
+				t.Errorf("released = %v, want %v", balance.released, tt.released)
+			}
+		})
+	}
+}
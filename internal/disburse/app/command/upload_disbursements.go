@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// UploadDisbursementRow is a row of the uploaded file as read, it is validated by the command
+type UploadDisbursementRow struct {
+	// Number is the row number in the file, it is used to point the uploader to an invalid row
+	Number int
+
+	Reference     string
+	Amount        string
+	Currency      string
+	BankCode      string
+	AccountNumber string
+}
+
+type UploadDisbursementsParam struct {
+	ID         uuid.UUID
+	MerchantID string
+	FileName   string
+	Rows       []UploadDisbursementRow
+
+	// CreatedBy is the user uploading the file, who can never approve its rows, empty is the merchant itself
+	CreatedBy string
+}
+
+type UploadDisbursementsHandler decorator.CommandHandler[*UploadDisbursementsParam]
+
+type uploadDisbursementsHandler struct {
+	uploadRepo disburse.UploadRepository
+}
+
+// Handle validates every row and stores the upload, an invalid row is stored failed with its errors and does not
+// reject the other rows. The valid rows are disbursed later by the ProcessUploads command, the progress is tracked
+// on the stored upload.
+func (h uploadDisbursementsHandler) Handle(
+	ctx context.Context,
+	r *UploadDisbursementsParam,
+) error {
+	if len(r.Rows) > disburse.MaxUploadRows {
+		return errors.NewIncorrectInputError(
+			disburse.ErrUploadTooLarge,
+			fmt.Sprintf("%s, max %d rows", disburse.ErrUploadTooLarge.Error(), disburse.MaxUploadRows),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	rows := buildUploadRows(r.Rows)
+
+	upload, err := disburse.NewUpload(r.ID, r.MerchantID, r.CreatedBy, r.FileName, rows)
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	err = h.uploadRepo.CreateUpload(ctx, upload, rows)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+// buildUploadRows validates every row, a row that is invalid is kept as a failed row with every error of the row
+// in its failure reason so the valid rows are still disbursed
+func buildUploadRows(params []UploadDisbursementRow) []*disburse.UploadRow {
+	var (
+		rows       = make([]*disburse.UploadRow, 0, len(params))
+		references = make(map[string]int, len(params))
+	)
+
+	for _, param := range params {
+		var failures []string
+
+		err := disburse.ValidateUploadReference(param.Reference)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("reference: %s", err.Error()))
+		} else if number, ok := references[param.Reference]; ok {
+			failures = append(
+				failures,
+				fmt.Sprintf("reference: %s: row %d", disburse.ErrDuplicateUploadReference.Error(), number),
+			)
+		}
+
+		amount, err := money.Parse(param.Amount, param.Currency)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("amount: %s", err.Error()))
+		}
+
+		bankAccount, err := disburse.NewBankAccount(param.BankCode, param.AccountNumber)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("bank_account: %s", err.Error()))
+		}
+
+		if len(failures) == 0 {
+			row, err := disburse.NewUploadRow(param.Number, param.Reference, amount, bankAccount)
+			if err == nil {
+				references[param.Reference] = param.Number
+				rows = append(rows, row)
+				continue
+			}
+
+			// the reference and bank account are checked above, what is left to fail is a non positive amount
+			failures = append(failures, fmt.Sprintf("amount: %s", err.Error()))
+		}
+
+		rows = append(rows, disburse.NewInvalidUploadRow(param.Number, param.Reference, strings.Join(failures, "; ")))
+	}
+
+	return rows
+}
+
+func NewUploadDisbursementsHandler(uploadRepo disburse.UploadRepository) UploadDisbursementsHandler {
+	return decorator.ApplyCommandDecorators(
+		&uploadDisbursementsHandler{
+			uploadRepo: uploadRepo,
+		},
+	)
+}
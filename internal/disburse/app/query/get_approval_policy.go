@@ -0,0 +1,59 @@
+package query
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetApprovalPolicyParam struct {
+	MerchantID string
+	Currency   string
+}
+
+type GetApprovalPolicyHandler decorator.QueryHandler[*GetApprovalPolicyParam, disburse.ApprovalPolicy]
+
+type getApprovalPolicyHandler struct {
+	approvalRepo disburse.ApprovalRepository
+}
+
+// Handle returns ErrApprovalPolicyNotFound as not found error when the disbursements of the merchant
+// in the currency need no approval
+func (h getApprovalPolicyHandler) Handle(
+	ctx context.Context,
+	q *GetApprovalPolicyParam,
+) (disburse.ApprovalPolicy, error) {
+	if q.MerchantID == "" {
+		return disburse.ApprovalPolicy{}, errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	currency, err := money.ParseCurrency(q.Currency)
+	if err != nil {
+		return disburse.ApprovalPolicy{}, errors.WrapDpayErrTrace(err)
+	}
+
+	policy, err := h.approvalRepo.GetApprovalPolicy(ctx, q.MerchantID, currency)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return disburse.ApprovalPolicy{}, errors.WrapDpayErrTrace(err)
+	}
+
+	return policy, nil
+}
+
+func NewGetApprovalPolicyHandler(
+	approvalRepo disburse.ApprovalRepository,
+) GetApprovalPolicyHandler {
+	return decorator.ApplyQueryDecorators(
+		&getApprovalPolicyHandler{
+			approvalRepo,
+		},
+	)
+}
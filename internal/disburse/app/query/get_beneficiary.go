@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetBeneficiaryParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the lookup to the beneficiaries of the merchant, empty is not limited
+	MerchantID string
+}
+
+type GetBeneficiaryHandler decorator.QueryHandler[*GetBeneficiaryParam, *disburse.Beneficiary]
+
+type getBeneficiaryHandler struct {
+	beneficiaryRepo disburse.BeneficiaryRepository
+}
+
+func (h getBeneficiaryHandler) Handle(
+	ctx context.Context,
+	q *GetBeneficiaryParam,
+) (*disburse.Beneficiary, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyBeneficiaryID,
+			disburse.ErrEmptyBeneficiaryID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	beneficiary, err := h.beneficiaryRepo.GetBeneficiary(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// a beneficiary of another merchant or a deleted one is reported as not found so its existence is not leaked
+	if (q.MerchantID != "" && beneficiary.MerchantID() != q.MerchantID) || beneficiary.IsDeleted() {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrBeneficiaryNotFound,
+			disburse.ErrBeneficiaryNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	return beneficiary, nil
+}
+
+func NewGetBeneficiaryHandler(
+	beneficiaryRepo disburse.BeneficiaryRepository,
+) GetBeneficiaryHandler {
+	return decorator.ApplyQueryDecorators(
+		&getBeneficiaryHandler{
+			beneficiaryRepo,
+		},
+	)
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the lookup to the disbursements of the merchant, empty is not limited
+	MerchantID string
+}
+
+type GetDisbursementHandler decorator.QueryHandler[*GetDisbursementParam, *disburse.Disbursement]
+
+type getDisbursementHandler struct {
+	disburseRepo disburse.DisburseRepository
+}
+
+func (h getDisbursementHandler) Handle(
+	ctx context.Context,
+	q *GetDisbursementParam,
+) (*disburse.Disbursement, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyDisbursementID,
+			disburse.ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	disbursement, err := h.disburseRepo.GetDisbursement(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// a disbursement of another merchant is reported as not found so its existence is not leaked
+	if q.MerchantID != "" && disbursement.MerchantID() != q.MerchantID {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrDisbursementNotFound,
+			disburse.ErrDisbursementNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	return disbursement, nil
+}
+
+func NewGetDisbursementHandler(
+	disburseRepo disburse.DisburseRepository,
+) GetDisbursementHandler {
+	return decorator.ApplyQueryDecorators(
+		&getDisbursementHandler{
+			disburseRepo,
+		},
+	)
+}
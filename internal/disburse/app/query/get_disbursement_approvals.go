@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetDisbursementApprovalsParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the lookup to the disbursements of the merchant, empty is not limited
+	MerchantID string
+}
+
+type GetDisbursementApprovalsHandler decorator.QueryHandler[*GetDisbursementApprovalsParam, []*disburse.ApprovalStep]
+
+type getDisbursementApprovalsHandler struct {
+	disburseRepo disburse.DisburseRepository
+	approvalRepo disburse.ApprovalRepository
+}
+
+// Handle returns the approval chain of the disbursement from its first step,
+// a disbursement that needed no approval has an empty chain
+func (h getDisbursementApprovalsHandler) Handle(
+	ctx context.Context,
+	q *GetDisbursementApprovalsParam,
+) ([]*disburse.ApprovalStep, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyDisbursementID,
+			disburse.ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	disbursement, err := h.disburseRepo.GetDisbursement(ctx, q.ID)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// a disbursement of another merchant is reported as not found so its existence is not leaked
+	if q.MerchantID != "" && disbursement.MerchantID() != q.MerchantID {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrDisbursementNotFound,
+			disburse.ErrDisbursementNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	steps, err := h.approvalRepo.ListApprovalSteps(ctx, disbursement.ID())
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return steps, nil
+}
+
+func NewGetDisbursementApprovalsHandler(
+	disburseRepo disburse.DisburseRepository,
+	approvalRepo disburse.ApprovalRepository,
+) GetDisbursementApprovalsHandler {
+	return decorator.ApplyQueryDecorators(
+		&getDisbursementApprovalsHandler{
+			disburseRepo,
+			approvalRepo,
+		},
+	)
+}
@@ -0,0 +1,91 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetDisbursementBatchParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the lookup to the batches of the merchant, empty is not limited
+	MerchantID string
+}
+
+// DisbursementBatchProgress tells how far the items of the batch are
+type DisbursementBatchProgress struct {
+	Batch *disburse.Batch
+
+	// StatusCounts is the number of items per status, a status without item is absent
+	StatusCounts map[disburse.Status]int
+}
+
+// CompletedCount returns the number of items in a final status
+func (p DisbursementBatchProgress) CompletedCount() int {
+	var completed int
+	for status, count := range p.StatusCounts {
+		if status.IsFinal() {
+			completed += count
+		}
+	}
+
+	return completed
+}
+
+type GetDisbursementBatchHandler decorator.QueryHandler[*GetDisbursementBatchParam, *DisbursementBatchProgress]
+
+type getDisbursementBatchHandler struct {
+	disburseRepo disburse.DisburseRepository
+}
+
+func (h getDisbursementBatchHandler) Handle(
+	ctx context.Context,
+	q *GetDisbursementBatchParam,
+) (*DisbursementBatchProgress, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyDisbursementID,
+			disburse.ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	batch, err := h.disburseRepo.GetBatch(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// a batch of another merchant is reported as not found so its existence is not leaked
+	if q.MerchantID != "" && batch.MerchantID() != q.MerchantID {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrBatchNotFound,
+			disburse.ErrBatchNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	counts, err := h.disburseRepo.CountBatchItemsByStatus(ctx, batch.ID())
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return &DisbursementBatchProgress{
+		Batch:        batch,
+		StatusCounts: counts,
+	}, nil
+}
+
+func NewGetDisbursementBatchHandler(
+	disburseRepo disburse.DisburseRepository,
+) GetDisbursementBatchHandler {
+	return decorator.ApplyQueryDecorators(
+		&getDisbursementBatchHandler{
+			disburseRepo,
+		},
+	)
+}
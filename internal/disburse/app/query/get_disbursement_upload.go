@@ -0,0 +1,88 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetDisbursementUploadParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the lookup to the uploads of the merchant, empty is not limited
+	MerchantID string
+}
+
+// DisbursementUploadProgress tells how far the rows of the upload are
+type DisbursementUploadProgress struct {
+	Upload *disburse.Upload
+
+	// RowCounts is the number of rows per status, a status without row is absent
+	RowCounts map[disburse.UploadRowStatus]int
+
+	// FailedRows are the rows that could not be disbursed, ordered by row number
+	FailedRows []*disburse.UploadRow
+}
+
+type GetDisbursementUploadHandler decorator.QueryHandler[*GetDisbursementUploadParam, *DisbursementUploadProgress]
+
+type getDisbursementUploadHandler struct {
+	uploadRepo disburse.UploadRepository
+}
+
+func (h getDisbursementUploadHandler) Handle(
+	ctx context.Context,
+	q *GetDisbursementUploadParam,
+) (*DisbursementUploadProgress, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyDisbursementID,
+			disburse.ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	upload, err := h.uploadRepo.GetUpload(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// an upload of another merchant is reported as not found so its existence is not leaked
+	if q.MerchantID != "" && upload.MerchantID() != q.MerchantID {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrUploadNotFound,
+			disburse.ErrUploadNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	counts, err := h.uploadRepo.CountUploadRowsByStatus(ctx, upload.ID())
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	failedRows, err := h.uploadRepo.ListUploadRows(ctx, upload.ID(), disburse.UploadRowStatusFailed)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return &DisbursementUploadProgress{
+		Upload:     upload,
+		RowCounts:  counts,
+		FailedRows: failedRows,
+	}, nil
+}
+
+func NewGetDisbursementUploadHandler(
+	uploadRepo disburse.UploadRepository,
+) GetDisbursementUploadHandler {
+	return decorator.ApplyQueryDecorators(
+		&getDisbursementUploadHandler{
+			uploadRepo,
+		},
+	)
+}
@@ -0,0 +1,60 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetFeeScheduleParam struct {
+	MerchantID string
+	Currency   string
+}
+
+type GetFeeScheduleHandler decorator.QueryHandler[*GetFeeScheduleParam, *disburse.FeeSchedule]
+
+type getFeeScheduleHandler struct {
+	feeRepo disburse.FeeRepository
+}
+
+// Handle returns the fee schedule effective now, or ErrFeeScheduleNotFound as not found error
+// when the disbursements of the merchant in the currency are charged nothing
+func (h getFeeScheduleHandler) Handle(
+	ctx context.Context,
+	q *GetFeeScheduleParam,
+) (*disburse.FeeSchedule, error) {
+	if q.MerchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	currency, err := money.ParseCurrency(q.Currency)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	schedule, err := h.feeRepo.GetEffectiveFeeSchedule(ctx, q.MerchantID, currency, time.Now().UTC())
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return schedule, nil
+}
+
+func NewGetFeeScheduleHandler(
+	feeRepo disburse.FeeRepository,
+) GetFeeScheduleHandler {
+	return decorator.ApplyQueryDecorators(
+		&getFeeScheduleHandler{
+			feeRepo: feeRepo,
+		},
+	)
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetFXQuoteParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the quote to the merchant, empty is not limited
+	MerchantID string
+}
+
+type GetFXQuoteHandler decorator.QueryHandler[*GetFXQuoteParam, *disburse.FXQuote]
+
+type getFXQuoteHandler struct {
+	fxQuoteRepo disburse.FXQuoteRepository
+}
+
+func (h getFXQuoteHandler) Handle(
+	ctx context.Context,
+	q *GetFXQuoteParam,
+) (*disburse.FXQuote, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyFXQuoteID,
+			disburse.ErrEmptyFXQuoteID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	quote, err := h.fxQuoteRepo.GetFXQuote(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// a quote of another merchant is reported as not found so its existence is not leaked
+	if q.MerchantID != "" && quote.MerchantID() != q.MerchantID {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrFXQuoteNotFound,
+			disburse.ErrFXQuoteNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	return quote, nil
+}
+
+func NewGetFXQuoteHandler(
+	fxQuoteRepo disburse.FXQuoteRepository,
+) GetFXQuoteHandler {
+	return decorator.ApplyQueryDecorators(
+		&getFXQuoteHandler{
+			fxQuoteRepo: fxQuoteRepo,
+		},
+	)
+}
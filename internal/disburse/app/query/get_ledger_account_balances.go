@@ -0,0 +1,52 @@
+package query
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetLedgerAccountBalancesParam struct {
+	// AccountCodes are the accounts to get, e.g. ledger.SettlementAccount.Code()
+	AccountCodes []string
+}
+
+type GetLedgerAccountBalancesHandler decorator.QueryHandler[*GetLedgerAccountBalancesParam, []ledger.Balance]
+
+type getLedgerAccountBalancesHandler struct {
+	ledgerRepo ledger.Repository
+}
+
+// Handle returns the balances of the accounts in every currency, an account without postings is absent
+func (h getLedgerAccountBalancesHandler) Handle(
+	ctx context.Context,
+	q *GetLedgerAccountBalancesParam,
+) ([]ledger.Balance, error) {
+	if len(q.AccountCodes) == 0 {
+		return nil, errors.NewIncorrectInputError(
+			ledger.ErrEmptyAccountCode,
+			ledger.ErrEmptyAccountCode.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	balances, err := h.ledgerRepo.ListBalances(ctx, q.AccountCodes)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return balances, nil
+}
+
+func NewGetLedgerAccountBalancesHandler(
+	ledgerRepo ledger.Repository,
+) GetLedgerAccountBalancesHandler {
+	return decorator.ApplyQueryDecorators(
+		&getLedgerAccountBalancesHandler{
+			ledgerRepo,
+		},
+	)
+}
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetMerchantLedgerBalancesParam struct {
+	MerchantID string
+}
+
+type GetMerchantLedgerBalancesHandler decorator.QueryHandler[*GetMerchantLedgerBalancesParam, []ledger.Balance]
+
+type getMerchantLedgerBalancesHandler struct {
+	ledgerRepo ledger.Repository
+}
+
+// Handle returns the balances of the available and reserved accounts of the merchant in every currency
+func (h getMerchantLedgerBalancesHandler) Handle(
+	ctx context.Context,
+	q *GetMerchantLedgerBalancesParam,
+) ([]ledger.Balance, error) {
+	if q.MerchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	balances, err := h.ledgerRepo.ListBalances(ctx, []string{
+		ledger.MerchantAvailableAccount(q.MerchantID).Code(),
+		ledger.MerchantReservedAccount(q.MerchantID).Code(),
+	})
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return balances, nil
+}
+
+func NewGetMerchantLedgerBalancesHandler(
+	ledgerRepo ledger.Repository,
+) GetMerchantLedgerBalancesHandler {
+	return decorator.ApplyQueryDecorators(
+		&getMerchantLedgerBalancesHandler{
+			ledgerRepo,
+		},
+	)
+}
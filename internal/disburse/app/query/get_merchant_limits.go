@@ -0,0 +1,71 @@
+package query
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetMerchantLimitsParam struct {
+	MerchantID string
+	Currency   string
+}
+
+// MerchantLimits are the limits the disbursements of the merchant are checked against
+type MerchantLimits struct {
+	Limits disburse.Limits
+
+	// IsDefault tells the merchant has no limits of its own and gets the default limits of the currency
+	IsDefault bool
+}
+
+type GetMerchantLimitsHandler decorator.QueryHandler[*GetMerchantLimitsParam, *MerchantLimits]
+
+type getMerchantLimitsHandler struct {
+	limitRepo     disburse.LimitRepository
+	defaultLimits disburse.DefaultLimits
+}
+
+func (h getMerchantLimitsHandler) Handle(
+	ctx context.Context,
+	q *GetMerchantLimitsParam,
+) (*MerchantLimits, error) {
+	if q.MerchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	currency, err := money.ParseCurrency(q.Currency)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	limits, isDefault, err := disburse.GetEffectiveLimits(ctx, h.limitRepo, h.defaultLimits, q.MerchantID, currency)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return &MerchantLimits{
+		Limits:    limits,
+		IsDefault: isDefault,
+	}, nil
+}
+
+func NewGetMerchantLimitsHandler(
+	limitRepo disburse.LimitRepository,
+	defaultLimits disburse.DefaultLimits,
+) GetMerchantLimitsHandler {
+	return decorator.ApplyQueryDecorators(
+		&getMerchantLimitsHandler{
+			limitRepo,
+			defaultLimits,
+		},
+	)
+}
@@ -0,0 +1,68 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetReconciliationRunParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the discrepancies to the disbursements of the merchant, empty is not limited
+	MerchantID string
+}
+
+// ReconciliationResult is a reconciliation run with the discrepancies it found, in the order they were found
+type ReconciliationResult struct {
+	Run           *disburse.ReconciliationRun
+	Discrepancies []*disburse.Discrepancy
+}
+
+type GetReconciliationRunHandler decorator.QueryHandler[*GetReconciliationRunParam, *ReconciliationResult]
+
+type getReconciliationRunHandler struct {
+	reconciliationRepo disburse.ReconciliationRepository
+}
+
+func (h getReconciliationRunHandler) Handle(
+	ctx context.Context,
+	q *GetReconciliationRunParam,
+) (*ReconciliationResult, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyDisbursementID,
+			disburse.ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	run, err := h.reconciliationRepo.GetRun(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	discrepancies, err := h.reconciliationRepo.ListDiscrepancies(ctx, run.ID(), q.MerchantID)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return &ReconciliationResult{
+		Run:           run,
+		Discrepancies: discrepancies,
+	}, nil
+}
+
+func NewGetReconciliationRunHandler(
+	reconciliationRepo disburse.ReconciliationRepository,
+) GetReconciliationRunHandler {
+	return decorator.ApplyQueryDecorators(
+		&getReconciliationRunHandler{
+			reconciliationRepo,
+		},
+	)
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type GetScheduledDisbursementParam struct {
+	ID uuid.UUID
+
+	// MerchantID limits the lookup to the schedules of the merchant, empty is not limited
+	MerchantID string
+}
+
+type GetScheduledDisbursementHandler decorator.QueryHandler[*GetScheduledDisbursementParam, *disburse.ScheduledDisbursement]
+
+type getScheduledDisbursementHandler struct {
+	scheduleRepo disburse.ScheduleRepository
+}
+
+func (h getScheduledDisbursementHandler) Handle(
+	ctx context.Context,
+	q *GetScheduledDisbursementParam,
+) (*disburse.ScheduledDisbursement, error) {
+	if q.ID == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyDisbursementID,
+			disburse.ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	schedule, err := h.scheduleRepo.GetSchedule(ctx, q.ID)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	// a schedule of another merchant is reported as not found so its existence is not leaked
+	if q.MerchantID != "" && schedule.MerchantID() != q.MerchantID {
+		return nil, errors.NewNotFoundError(
+			disburse.ErrScheduleNotFound,
+			disburse.ErrScheduleNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	return schedule, nil
+}
+
+func NewGetScheduledDisbursementHandler(
+	scheduleRepo disburse.ScheduleRepository,
+) GetScheduledDisbursementHandler {
+	return decorator.ApplyQueryDecorators(
+		&getScheduledDisbursementHandler{
+			scheduleRepo,
+		},
+	)
+}
@@ -0,0 +1,104 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type ListBeneficiariesParam struct {
+	// MerchantID limits the list to the beneficiaries of the merchant, empty is not limited
+	MerchantID string
+
+	// BankCode only lists the beneficiaries at the bank, case insensitive
+	BankCode string
+
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+	Limit  int
+}
+
+type ListBeneficiariesResult struct {
+	Beneficiaries []*disburse.Beneficiary
+
+	// NextCursor is empty when there is no more page
+	NextCursor string
+}
+
+type ListBeneficiariesHandler decorator.QueryHandler[*ListBeneficiariesParam, *ListBeneficiariesResult]
+
+type listBeneficiariesHandler struct {
+	beneficiaryRepo disburse.BeneficiaryRepository
+}
+
+func (h listBeneficiariesHandler) Handle(
+	ctx context.Context,
+	q *ListBeneficiariesParam,
+) (*ListBeneficiariesResult, error) {
+	filter := disburse.BeneficiaryFilter{
+		MerchantID: q.MerchantID,
+		BankCode:   strings.ToUpper(strings.TrimSpace(q.BankCode)),
+		Limit:      q.Limit,
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultListLimit
+	case filter.Limit < 0 || filter.Limit > maxListLimit:
+		return nil, errors.NewIncorrectInputError(
+			ErrInvalidLimit,
+			fmt.Sprintf("%s: must be between 1 and %d", ErrInvalidLimit.Error(), maxListLimit),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if q.Cursor != "" {
+		cursor, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, errors.WrapDpayErrTrace(err)
+		}
+
+		filter.After = &cursor
+	}
+
+	limit := filter.Limit
+
+	// fetch one more row to know whether there is a next page
+	filter.Limit++
+
+	beneficiaries, err := h.beneficiaryRepo.ListBeneficiaries(ctx, filter)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	result := &ListBeneficiariesResult{
+		Beneficiaries: beneficiaries,
+	}
+
+	if len(beneficiaries) > limit {
+		result.Beneficiaries = beneficiaries[:limit]
+
+		last := result.Beneficiaries[limit-1]
+		result.NextCursor = encodeCursor(disburse.ListCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID(),
+		})
+	}
+
+	return result, nil
+}
+
+func NewListBeneficiariesHandler(
+	beneficiaryRepo disburse.BeneficiaryRepository,
+) ListBeneficiariesHandler {
+	return decorator.ApplyQueryDecorators(
+		&listBeneficiariesHandler{
+			beneficiaryRepo,
+		},
+	)
+}
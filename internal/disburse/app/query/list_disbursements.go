@@ -0,0 +1,212 @@
+package query
+
+import (
+	"context"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = stderrors.New("invalid cursor")
+	ErrInvalidLimit  = stderrors.New("invalid limit")
+)
+
+type ListDisbursementsParam struct {
+	// MerchantID limits the list to the disbursements of the merchant, empty is not limited
+	MerchantID string
+
+	Statuses    []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	// MinAmount and MaxAmount are decimal amounts in major units, both require Currency
+	MinAmount string
+	MaxAmount string
+	Currency  string
+
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor string
+	Limit  int
+}
+
+type ListDisbursementsResult struct {
+	Disbursements []*disburse.Disbursement
+
+	// NextCursor is empty when there is no more page
+	NextCursor string
+}
+
+type ListDisbursementsHandler decorator.QueryHandler[*ListDisbursementsParam, *ListDisbursementsResult]
+
+type listDisbursementsHandler struct {
+	disburseRepo disburse.DisburseRepository
+}
+
+func (h listDisbursementsHandler) Handle(
+	ctx context.Context,
+	q *ListDisbursementsParam,
+) (*ListDisbursementsResult, error) {
+	filter, err := h.buildFilter(q)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	limit := filter.Limit
+
+	// fetch one more row to know whether there is a next page
+	filter.Limit++
+
+	disbursements, err := h.disburseRepo.ListDisbursements(ctx, filter)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	result := &ListDisbursementsResult{
+		Disbursements: disbursements,
+	}
+
+	if len(disbursements) > limit {
+		result.Disbursements = disbursements[:limit]
+
+		last := result.Disbursements[limit-1]
+		result.NextCursor = encodeCursor(disburse.ListCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID(),
+		})
+	}
+
+	return result, nil
+}
+
+func (h listDisbursementsHandler) buildFilter(q *ListDisbursementsParam) (disburse.ListFilter, error) {
+	filter := disburse.ListFilter{
+		MerchantID: q.MerchantID,
+		Limit:      q.Limit,
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultListLimit
+	case filter.Limit < 0 || filter.Limit > maxListLimit:
+		return disburse.ListFilter{}, errors.NewIncorrectInputError(
+			ErrInvalidLimit,
+			fmt.Sprintf("%s: must be between 1 and %d", ErrInvalidLimit.Error(), maxListLimit),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	for _, s := range q.Statuses {
+		status := disburse.Status(strings.ToUpper(strings.TrimSpace(s)))
+		if !status.IsValid() {
+			return disburse.ListFilter{}, errors.NewIncorrectInputError(
+				disburse.ErrInvalidStatus,
+				fmt.Sprintf("%s: %q", disburse.ErrInvalidStatus.Error(), s),
+				errors.DpayInvalidRequest,
+			)
+		}
+
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if q.CreatedFrom != nil {
+		filter.CreatedFrom = q.CreatedFrom.UTC()
+	}
+
+	if q.CreatedTo != nil {
+		filter.CreatedTo = q.CreatedTo.UTC()
+	}
+
+	if q.MinAmount != "" {
+		minAmount, err := money.Parse(q.MinAmount, q.Currency)
+		if err != nil {
+			return disburse.ListFilter{}, err
+		}
+
+		filter.MinAmount = &minAmount
+	}
+
+	if q.MaxAmount != "" {
+		maxAmount, err := money.Parse(q.MaxAmount, q.Currency)
+		if err != nil {
+			return disburse.ListFilter{}, err
+		}
+
+		filter.MaxAmount = &maxAmount
+	}
+
+	if q.Cursor != "" {
+		cursor, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return disburse.ListFilter{}, err
+		}
+
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+// encodeCursor keeps the cursor opaque for the client, its content is not part of the API contract
+func encodeCursor(cursor disburse.ListCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(encoded string) (disburse.ListCursor, error) {
+	invalidCursorErr := errors.NewIncorrectInputError(
+		ErrInvalidCursor,
+		ErrInvalidCursor.Error(),
+		errors.DpayInvalidRequest,
+	)
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return disburse.ListCursor{}, invalidCursorErr
+	}
+
+	rawCreatedAt, rawID, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return disburse.ListCursor{}, invalidCursorErr
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, rawCreatedAt)
+	if err != nil {
+		return disburse.ListCursor{}, invalidCursorErr
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return disburse.ListCursor{}, invalidCursorErr
+	}
+
+	return disburse.ListCursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}, nil
+}
+
+func NewListDisbursementsHandler(
+	disburseRepo disburse.DisburseRepository,
+) ListDisbursementsHandler {
+	return decorator.ApplyQueryDecorators(
+		&listDisbursementsHandler{
+			disburseRepo,
+		},
+	)
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/decorator"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+type PreviewFeeParam struct {
+	MerchantID string
+
+	// Amount is the exact decimal amount in major units, e.g. "10000.50"
+	Amount   string
+	Currency string
+
+	// BeneficiaryID is the beneficiary of the merchant the disbursement would be paid to, its bank code is used
+	// instead of BankCode. Both empty previews the default fee rule of the merchant.
+	BeneficiaryID uuid.UUID
+	BankCode      string
+}
+
+// FeePreview is the fee a disbursement of the amount would be charged now
+type FeePreview struct {
+	Amount money.Money
+	Fee    money.Money
+
+	// Total is the amount with the fee, what would be reserved from the merchant balance
+	Total money.Money
+
+	// Schedule is the fee schedule the fee is calculated with, nil when the merchant has none
+	Schedule *disburse.FeeSchedule
+}
+
+type PreviewFeeHandler decorator.QueryHandler[*PreviewFeeParam, *FeePreview]
+
+type previewFeeHandler struct {
+	feeRepo         disburse.FeeRepository
+	beneficiaryRepo disburse.BeneficiaryRepository
+}
+
+// Handle calculates the fee the same way a disbursement does without disbursing anything,
+// a newer schedule may apply by the time the merchant disburses
+func (h previewFeeHandler) Handle(
+	ctx context.Context,
+	q *PreviewFeeParam,
+) (*FeePreview, error) {
+	if q.MerchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyMerchantID,
+			disburse.ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	amount, err := money.Parse(q.Amount, q.Currency)
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	if !amount.IsPositive() {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrInvalidAmount,
+			disburse.ErrInvalidAmount.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	bankCode := q.BankCode
+	if q.BeneficiaryID != uuid.Nil {
+		beneficiary, err := h.beneficiaryRepo.GetBeneficiary(ctx, q.BeneficiaryID)
+		if err != nil {
+			return nil, errors.WrapDpayErrTrace(err)
+		}
+
+		// a beneficiary of another merchant is reported as not found so its existence is not leaked
+		if beneficiary.MerchantID() != q.MerchantID {
+			return nil, errors.NewNotFoundError(
+				disburse.ErrBeneficiaryNotFound,
+				disburse.ErrBeneficiaryNotFound.Error(),
+				errors.DpayNotFound,
+			)
+		}
+
+		bankCode = beneficiary.BankAccount().BankCode()
+	}
+
+	schedule, err := disburse.GetEffectiveFeeSchedule(ctx, h.feeRepo, q.MerchantID, amount.Currency(), time.Now().UTC())
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	fee, err := money.New(0, amount.Currency())
+	if err != nil {
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	if schedule != nil {
+		fee, err = schedule.Calculate(amount, bankCode)
+		if err != nil {
+			return nil, errors.WrapDpayErrTrace(err)
+		}
+	}
+
+	total, err := amount.Add(fee)
+	if err != nil {
+		// always do wrap since we need to keep the stack trace error from the source
+		return nil, errors.WrapDpayErrTrace(err)
+	}
+
+	return &FeePreview{
+		Amount:   amount,
+		Fee:      fee,
+		Total:    total,
+		Schedule: schedule,
+	}, nil
+}
+
+func NewPreviewFeeHandler(
+	feeRepo disburse.FeeRepository,
+	beneficiaryRepo disburse.BeneficiaryRepository,
+) PreviewFeeHandler {
+	return decorator.ApplyQueryDecorators(
+		&previewFeeHandler{
+			feeRepo:         feeRepo,
+			beneficiaryRepo: beneficiaryRepo,
+		},
+	)
+}
@@ -0,0 +1,424 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrInvalidApprovalPolicy  = stderrors.New("invalid approval policy")
+	ErrApprovalPolicyNotFound = stderrors.New("merchant has no approval policy")
+	ErrEmptyApprover          = stderrors.New("approver can not be empty")
+	ErrApproverNotAllowed     = stderrors.New("approver role is not allowed to approve disbursements")
+	ErrSelfApproval           = stderrors.New("disbursement can not be approved by its creator")
+	ErrAlreadyApproved        = stderrors.New("disbursement is already approved by the approver")
+	ErrApprovalExpired        = stderrors.New("approval deadline of the disbursement has passed")
+)
+
+const (
+	// ApprovalExpiredReason is the failure reason of a disbursement rejected since nobody approved it in time
+	ApprovalExpiredReason = "approval deadline passed"
+
+	// ApprovalSystemActor is the actor of the steps nobody asked for, like an expiry
+	ApprovalSystemActor = "system"
+)
+
+type ApprovalAction string
+
+const (
+	ApprovalActionSubmit  = ApprovalAction("SUBMIT")
+	ApprovalActionApprove = ApprovalAction("APPROVE")
+	ApprovalActionReject  = ApprovalAction("REJECT")
+	ApprovalActionExpire  = ApprovalAction("EXPIRE")
+)
+
+func (a ApprovalAction) String() string {
+	return string(a)
+}
+
+// ApprovalPolicy is the maker-checker rule of a merchant in a currency. A disbursement above the threshold
+// waits for requiredApprovals approvers holding one of the approverRoles, other than its creator,
+// and it is rejected when they do not approve it within window.
+type ApprovalPolicy struct {
+	threshold         money.Money
+	approverRoles     []string
+	requiredApprovals int
+	window            time.Duration
+}
+
+// NewApprovalPolicy creates the policy of the currency of threshold, the roles are compared case-insensitively
+func NewApprovalPolicy(
+	threshold money.Money,
+	approverRoles []string,
+	requiredApprovals int,
+	window time.Duration,
+) (ApprovalPolicy, error) {
+	if !threshold.Currency().IsValid() {
+		return ApprovalPolicy{}, newInvalidApprovalPolicyError(
+			fmt.Sprintf("currency %q is not supported", threshold.Currency()),
+		)
+	}
+
+	if threshold.IsNegative() {
+		return ApprovalPolicy{}, newInvalidApprovalPolicyError("threshold can not be negative")
+	}
+
+	roles := lo.Uniq(lo.FilterMap(approverRoles, func(role string, _ int) (string, bool) {
+		role = normalizeRole(role)
+		return role, role != ""
+	}))
+	if len(roles) == 0 {
+		return ApprovalPolicy{}, newInvalidApprovalPolicyError("at least one approver role is needed")
+	}
+
+	for _, role := range roles {
+		if strings.Contains(role, ",") {
+			return ApprovalPolicy{}, newInvalidApprovalPolicyError(fmt.Sprintf("approver role %q can not contain a comma", role))
+		}
+	}
+
+	if requiredApprovals < 1 {
+		return ApprovalPolicy{}, newInvalidApprovalPolicyError("at least one approval is needed")
+	}
+
+	if window <= 0 {
+		return ApprovalPolicy{}, newInvalidApprovalPolicyError("approval window must be positive")
+	}
+
+	return ApprovalPolicy{
+		threshold:         threshold,
+		approverRoles:     roles,
+		requiredApprovals: requiredApprovals,
+		window:            window,
+	}, nil
+}
+
+// UnmarshalApprovalPolicyFromDatabase unmarshals ApprovalPolicy from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalApprovalPolicyFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalApprovalPolicyFromDatabase(
+	threshold money.Money,
+	approverRoles []string,
+	requiredApprovals int,
+	window time.Duration,
+) ApprovalPolicy {
+	return ApprovalPolicy{
+		threshold:         threshold,
+		approverRoles:     approverRoles,
+		requiredApprovals: requiredApprovals,
+		window:            window,
+	}
+}
+
+func (p ApprovalPolicy) Currency() money.Currency {
+	return p.threshold.Currency()
+}
+
+// Threshold returns the amount a disbursement must be above to need approval
+func (p ApprovalPolicy) Threshold() money.Money {
+	return p.threshold
+}
+
+func (p ApprovalPolicy) ApproverRoles() []string {
+	return p.approverRoles
+}
+
+func (p ApprovalPolicy) RequiredApprovals() int {
+	return p.requiredApprovals
+}
+
+// Window returns how long after its creation a disbursement may still be approved
+func (p ApprovalPolicy) Window() time.Duration {
+	return p.window
+}
+
+// Requires checks whether a disbursement of the amount needs approval, an amount in another currency does not
+func (p ApprovalPolicy) Requires(amount money.Money) bool {
+	return amount.Currency() == p.threshold.Currency() && amount.MinorUnits() > p.threshold.MinorUnits()
+}
+
+// CanApprove checks whether the role is one of the approver roles
+func (p ApprovalPolicy) CanApprove(role string) bool {
+	return slices.Contains(p.approverRoles, normalizeRole(role))
+}
+
+func normalizeRole(role string) string {
+	return strings.ToLower(strings.TrimSpace(role))
+}
+
+func newInvalidApprovalPolicyError(message string) error {
+	return errors.NewIncorrectInputError(
+		ErrInvalidApprovalPolicy,
+		fmt.Sprintf("%s: %s", ErrInvalidApprovalPolicy.Error(), message),
+		errors.DpayInvalidRequest,
+	)
+}
+
+// ApprovalStep is an entry of the approval chain of a disbursement, a step is never updated
+type ApprovalStep struct {
+	id             uuid.UUID
+	disbursementID uuid.UUID
+	action         ApprovalAction
+
+	// actor is who did the step with its role, ApprovalSystemActor for an expiry
+	actor string
+	role  string
+
+	reason    string
+	createdAt time.Time
+}
+
+// UnmarshalApprovalStepFromDatabase unmarshals ApprovalStep from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalApprovalStepFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalApprovalStepFromDatabase(
+	id uuid.UUID,
+	disbursementID uuid.UUID,
+	action ApprovalAction,
+	actor string,
+	role string,
+	reason string,
+	createdAt time.Time,
+) *ApprovalStep {
+	return &ApprovalStep{
+		id:             id,
+		disbursementID: disbursementID,
+		action:         action,
+		actor:          actor,
+		role:           role,
+		reason:         reason,
+		createdAt:      createdAt,
+	}
+}
+
+func (s ApprovalStep) ID() uuid.UUID {
+	return s.id
+}
+
+func (s ApprovalStep) DisbursementID() uuid.UUID {
+	return s.disbursementID
+}
+
+func (s ApprovalStep) Action() ApprovalAction {
+	return s.action
+}
+
+func (s ApprovalStep) Actor() string {
+	return s.actor
+}
+
+func (s ApprovalStep) Role() string {
+	return s.role
+}
+
+func (s ApprovalStep) Reason() string {
+	return s.reason
+}
+
+func (s ApprovalStep) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func newApprovalStep(
+	disbursementID uuid.UUID,
+	action ApprovalAction,
+	actor string,
+	role string,
+	reason string,
+	createdAt time.Time,
+) *ApprovalStep {
+	return &ApprovalStep{
+		id:             uuid.New(),
+		disbursementID: disbursementID,
+		action:         action,
+		actor:          actor,
+		role:           role,
+		reason:         reason,
+		createdAt:      createdAt,
+	}
+}
+
+// RequestApproval parks a new PENDING disbursement in AWAITING_APPROVAL until the approvers of the policy approve it,
+// createdBy is the maker of the disbursement who can never approve it. It returns the first step of the chain.
+func (d *Disbursement) RequestApproval(policy ApprovalPolicy, createdBy string) (*ApprovalStep, error) {
+	if createdBy == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyApprover,
+			"creator of the disbursement can not be empty",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if d.status != StatusPending || d.RequiresApproval() {
+		return nil, errors.NewUnprocessableEntityError(
+			ErrInvalidStatusTransition,
+			fmt.Sprintf("disbursement %s can not move from %s to %s", d.id, d.status, StatusAwaitingApproval),
+			errors.DpayInvalidStatusTransition,
+		)
+	}
+
+	d.status = StatusAwaitingApproval
+	d.requiredApprovals = policy.RequiredApprovals()
+	d.approvalDeadline = d.createdAt.Add(policy.Window())
+
+	return newApprovalStep(d.id, ApprovalActionSubmit, createdBy, "", "", d.createdAt), nil
+}
+
+// Approve adds the approval of the approver to the chain of an AWAITING_APPROVAL disbursement, the disbursement
+// moves into PENDING with its last required approval and is left unchanged before. The approver must hold
+// an approver role of the policy and must not be the creator or an earlier approver of the disbursement.
+func (d *Disbursement) Approve(
+	chain []*ApprovalStep,
+	approver string,
+	role string,
+	policy ApprovalPolicy,
+) (*ApprovalStep, error) {
+	if err := d.checkApprover(chain, approver, role, policy); err != nil {
+		return nil, err
+	}
+
+	approvals := lo.CountBy(chain, func(s *ApprovalStep) bool {
+		return s.action == ApprovalActionApprove
+	}) + 1
+
+	if approvals < d.requiredApprovals {
+		return newApprovalStep(d.id, ApprovalActionApprove, approver, normalizeRole(role), "", time.Now().UTC()), nil
+	}
+
+	if err := d.transitionTo(StatusPending); err != nil {
+		return nil, err
+	}
+
+	return newApprovalStep(d.id, ApprovalActionApprove, approver, normalizeRole(role), "", d.updatedAt), nil
+}
+
+// Reject completes an AWAITING_APPROVAL disbursement as REJECTED, the same approvers as Approve may reject
+func (d *Disbursement) Reject(
+	chain []*ApprovalStep,
+	approver string,
+	role string,
+	policy ApprovalPolicy,
+	reason string,
+) (*ApprovalStep, error) {
+	if err := d.checkApprover(chain, approver, role, policy); err != nil {
+		return nil, err
+	}
+
+	if err := d.transitionTo(StatusRejected); err != nil {
+		return nil, err
+	}
+
+	d.failureReason = reason
+	d.completedAt = d.updatedAt
+
+	return newApprovalStep(d.id, ApprovalActionReject, approver, normalizeRole(role), reason, d.updatedAt), nil
+}
+
+// ExpireApproval rejects an AWAITING_APPROVAL disbursement whose approval deadline is passed at now
+func (d *Disbursement) ExpireApproval(now time.Time) (*ApprovalStep, error) {
+	if !d.IsApprovalExpired(now) {
+		return nil, errors.NewUnprocessableEntityError(
+			ErrInvalidStatusTransition,
+			fmt.Sprintf("disbursement %s is not past its approval deadline", d.id),
+			errors.DpayInvalidStatusTransition,
+		)
+	}
+
+	if err := d.transitionTo(StatusRejected); err != nil {
+		return nil, err
+	}
+
+	d.failureReason = ApprovalExpiredReason
+	d.completedAt = d.updatedAt
+
+	return newApprovalStep(d.id, ApprovalActionExpire, ApprovalSystemActor, "", ApprovalExpiredReason, d.updatedAt), nil
+}
+
+// IsApprovalExpired checks whether the disbursement still awaits approval past its deadline at now
+func (d Disbursement) IsApprovalExpired(now time.Time) bool {
+	return d.status == StatusAwaitingApproval && !now.Before(d.approvalDeadline)
+}
+
+func (d Disbursement) checkApprover(chain []*ApprovalStep, approver string, role string, policy ApprovalPolicy) error {
+	if approver == "" {
+		return errors.NewIncorrectInputError(
+			ErrEmptyApprover,
+			ErrEmptyApprover.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if d.status != StatusAwaitingApproval {
+		return errors.NewUnprocessableEntityError(
+			ErrInvalidStatusTransition,
+			fmt.Sprintf("disbursement %s is %s and does not await approval", d.id, d.status),
+			errors.DpayInvalidStatusTransition,
+		)
+	}
+
+	if d.IsApprovalExpired(time.Now()) {
+		return errors.NewForbiddenError(
+			ErrApprovalExpired,
+			fmt.Sprintf("%s, disbursement %s had to be approved before %s", ErrApprovalExpired.Error(), d.id, d.approvalDeadline),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	if !policy.CanApprove(role) {
+		return errors.NewForbiddenError(
+			ErrApproverNotAllowed,
+			fmt.Sprintf("%s, role %q", ErrApproverNotAllowed.Error(), role),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	for _, step := range chain {
+		if step.actor != approver {
+			continue
+		}
+
+		switch step.action {
+		case ApprovalActionSubmit:
+			return errors.NewForbiddenError(
+				ErrSelfApproval,
+				ErrSelfApproval.Error(),
+				errors.DpayActionNotAllowed,
+			)
+		case ApprovalActionApprove:
+			return errors.NewForbiddenError(
+				ErrAlreadyApproved,
+				ErrAlreadyApproved.Error(),
+				errors.DpayActionNotAllowed,
+			)
+		}
+	}
+
+	return nil
+}
+
+type ApprovalRepository interface {
+	// GetApprovalPolicy returns ErrApprovalPolicyNotFound as not found error
+	// when the merchant has no approval policy of the currency, its disbursements then need no approval
+	GetApprovalPolicy(ctx context.Context, merchantID string, currency money.Currency) (ApprovalPolicy, error)
+
+	// SetApprovalPolicy replaces the approval policy of the merchant in the currency of the policy
+	SetApprovalPolicy(ctx context.Context, merchantID string, policy ApprovalPolicy) error
+
+	// AddApprovalStep must run in the transaction of the change it records, an actor has at most one step
+	// in the chain and a concurrent step of the same actor is rejected with ErrDisbursementConflict as conflict error
+	AddApprovalStep(ctx context.Context, step *ApprovalStep) error
+
+	// ListApprovalSteps returns the approval chain of the disbursement from its first step
+	ListApprovalSteps(ctx context.Context, disbursementID uuid.UUID) ([]*ApprovalStep, error)
+}
@@ -0,0 +1,118 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var ErrEmptyAuditActor = stderrors.New("actor of the action can not be empty")
+
+type AuditAction string
+
+const (
+	AuditActionCancel  = AuditAction("CANCEL")
+	AuditActionReverse = AuditAction("REVERSE")
+)
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+// the sources an action on a disbursement may come from
+const (
+	AuditSourceHTTP  = "http"
+	AuditSourceGRPC  = "grpc"
+	AuditSourceKafka = "kafka"
+)
+
+// AuditEntry records who did an action on a disbursement and the status change it made
+type AuditEntry struct {
+	id             uuid.UUID
+	disbursementID uuid.UUID
+	action         AuditAction
+	fromStatus     Status
+	toStatus       Status
+
+	// actor is who asked for the action, source is the channel it was asked through
+	actor  string
+	source string
+	reason string
+
+	createdAt time.Time
+}
+
+// NewAuditEntry records the action that moved the disbursement out of fromStatus into its current status
+func NewAuditEntry(
+	action AuditAction,
+	disbursement *Disbursement,
+	fromStatus Status,
+	actor string,
+	source string,
+	reason string,
+) (*AuditEntry, error) {
+	if actor == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyAuditActor,
+			ErrEmptyAuditActor.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return &AuditEntry{
+		id:             uuid.New(),
+		disbursementID: disbursement.ID(),
+		action:         action,
+		fromStatus:     fromStatus,
+		toStatus:       disbursement.Status(),
+		actor:          actor,
+		source:         source,
+		reason:         reason,
+		createdAt:      disbursement.UpdatedAt(),
+	}, nil
+}
+
+func (a AuditEntry) ID() uuid.UUID {
+	return a.id
+}
+
+func (a AuditEntry) DisbursementID() uuid.UUID {
+	return a.disbursementID
+}
+
+func (a AuditEntry) Action() AuditAction {
+	return a.action
+}
+
+func (a AuditEntry) FromStatus() Status {
+	return a.fromStatus
+}
+
+func (a AuditEntry) ToStatus() Status {
+	return a.toStatus
+}
+
+func (a AuditEntry) Actor() string {
+	return a.actor
+}
+
+func (a AuditEntry) Source() string {
+	return a.source
+}
+
+func (a AuditEntry) Reason() string {
+	return a.reason
+}
+
+func (a AuditEntry) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// AuditRepository keeps the audit trail of the actions on disbursements, an entry is never updated
+type AuditRepository interface {
+	// AddAuditEntry must run in the transaction of the status change it records
+	AddAuditEntry(ctx context.Context, entry *AuditEntry) error
+}
@@ -0,0 +1,27 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+var ErrInsufficientBalance = stderrors.New("merchant balance is not sufficient")
+
+// MerchantBalance holds the merchant funds while a disbursement is in flight.
+// The reservation is referenced by the disbursement id, so reserving the same disbursement twice
+// holds the funds only once.
+type MerchantBalance interface {
+	// Reserve holds the amount from the merchant available balance,
+	// it returns ErrInsufficientBalance when the available balance is less than the amount
+	Reserve(ctx context.Context, merchantID string, disbursementID uuid.UUID, amount money.Money) error
+
+	// Release gives the reserved amount back to the merchant available balance
+	Release(ctx context.Context, merchantID string, disbursementID uuid.UUID) error
+
+	// Refund credits the amount of a reversed disbursement back to the merchant available balance,
+	// it is referenced by the reversal id so refunding the same reversal twice credits it only once
+	Refund(ctx context.Context, merchantID string, reversalID uuid.UUID, amount money.Money) error
+}
@@ -0,0 +1,97 @@
+package disburse
+
+import (
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrInvalidBankCode      = stderrors.New("bank code must be 2 to 11 letters or digits")
+	ErrInvalidAccountNumber = stderrors.New("account number must be 5 to 20 digits")
+)
+
+var (
+	bankCodePattern      = regexp.MustCompile(`^[A-Z0-9]{2,11}$`)
+	accountNumberPattern = regexp.MustCompile(`^\d{5,20}$`)
+)
+
+// accountNumberFormat is the account number format of a bank, rule tells the merchant what is expected
+type accountNumberFormat struct {
+	pattern *regexp.Regexp
+	rule    string
+}
+
+// bankAccountNumberFormats are the banks with a known account number format,
+// the account number of any other bank is only checked against accountNumberPattern
+var bankAccountNumberFormats = map[string]accountNumberFormat{
+	"BCA":     {pattern: regexp.MustCompile(`^\d{10}$`), rule: "10 digits"},
+	"BNI":     {pattern: regexp.MustCompile(`^\d{10}$`), rule: "10 digits"},
+	"BRI":     {pattern: regexp.MustCompile(`^\d{15}$`), rule: "15 digits"},
+	"MANDIRI": {pattern: regexp.MustCompile(`^\d{13}$`), rule: "13 digits"},
+	"PERMATA": {pattern: regexp.MustCompile(`^\d{10}$`), rule: "10 digits"},
+	"CIMB":    {pattern: regexp.MustCompile(`^\d{13,14}$`), rule: "13 or 14 digits"},
+}
+
+// BankAccount is the destination account of a payout
+type BankAccount struct {
+	bankCode      string
+	accountNumber string
+}
+
+// NewBankAccount validates the format of the account against the format of its bank, the bank code is
+// case insensitive and the spaces and dashes people use to group the account number are ignored
+func NewBankAccount(bankCode string, accountNumber string) (BankAccount, error) {
+	bankCode = strings.ToUpper(strings.TrimSpace(bankCode))
+	if !bankCodePattern.MatchString(bankCode) {
+		return BankAccount{}, errors.NewIncorrectInputError(
+			ErrInvalidBankCode,
+			ErrInvalidBankCode.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	accountNumber = strings.NewReplacer(" ", "", "-", "").Replace(accountNumber)
+	if !accountNumberPattern.MatchString(accountNumber) {
+		return BankAccount{}, errors.NewIncorrectInputError(
+			ErrInvalidAccountNumber,
+			ErrInvalidAccountNumber.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if format, ok := bankAccountNumberFormats[bankCode]; ok && !format.pattern.MatchString(accountNumber) {
+		return BankAccount{}, errors.NewIncorrectInputError(
+			ErrInvalidAccountNumber,
+			fmt.Sprintf("account number of %s must be %s", bankCode, format.rule),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return BankAccount{
+		bankCode:      bankCode,
+		accountNumber: accountNumber,
+	}, nil
+}
+
+// UnmarshalBankAccountFromDatabase unmarshals BankAccount from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalBankAccountFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalBankAccountFromDatabase(bankCode string, accountNumber string) BankAccount {
+	return BankAccount{
+		bankCode:      bankCode,
+		accountNumber: accountNumber,
+	}
+}
+
+func (a BankAccount) BankCode() string {
+	return a.bankCode
+}
+
+func (a BankAccount) AccountNumber() string {
+	return a.accountNumber
+}
@@ -0,0 +1,221 @@
+package disburse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptyBatch         = stderrors.New("batch must have at least one item")
+	ErrBatchTooLarge      = stderrors.New("batch has too many items")
+	ErrInvalidBatchItems  = stderrors.New("batch has invalid items")
+	ErrBatchAlreadyExists = stderrors.New("disbursement batch already exists")
+	ErrBatchNotFound      = stderrors.New("disbursement batch not found")
+	ErrBatchReserving     = stderrors.New("disbursement batch is still reserving the balance of its items")
+	ErrBatchRejected      = stderrors.New("disbursement batch was rejected, the balance of an item could not be reserved")
+)
+
+type BatchStatus string
+
+const (
+	// BatchStatusReserving is a stored batch whose balance is being reserved item by item,
+	// its items are not sent out until the batch is accepted
+	BatchStatusReserving = BatchStatus("RESERVING")
+
+	// BatchStatusAccepted has the balance of every item reserved, its items are sent out like any disbursement
+	BatchStatusAccepted = BatchStatus("ACCEPTED")
+
+	// BatchStatusRejected could not reserve the balance of an item, its items are cancelled and nothing stays reserved
+	BatchStatusRejected = BatchStatus("REJECTED")
+)
+
+func (s BatchStatus) String() string {
+	return string(s)
+}
+
+// MaxBatchItems is the most disbursements a single batch may carry
+const MaxBatchItems = 1000
+
+// batchNamespace is the UUID namespace to derive batch id from the idempotency key
+var batchNamespace = uuid.MustParse("0d6f3f0e-3c1b-4f57-8e0a-7b9c2d4e5f61")
+
+// Batch groups disbursements requested together, the items are stored as disbursements of the batch
+type Batch struct {
+	id         uuid.UUID
+	merchantID string
+	itemCount  int
+	status     BatchStatus
+
+	idempotencyKey string
+
+	// requestHash is the hex SHA-256 of the items as requested, see hashBatchItems
+	requestHash string
+
+	createdAt time.Time
+}
+
+// NewBatchID returns the id for a new batch, the same idempotency key of the same merchant always gives the same id.
+// Empty key gives a random id.
+func NewBatchID(merchantID string, idempotencyKey string) uuid.UUID {
+	if idempotencyKey == "" {
+		return uuid.New()
+	}
+
+	return uuid.NewSHA1(batchNamespace, []byte(merchantID+":"+idempotencyKey))
+}
+
+// NewBatchItemID returns the disbursement id of the item at the index, so a retried batch gets the same item ids
+func NewBatchItemID(batchID uuid.UUID, index int) uuid.UUID {
+	return uuid.NewSHA1(batchID, []byte(strconv.Itoa(index)))
+}
+
+// NewBatch creates a RESERVING batch of the merchant and assigns the items to it,
+// every item must be a new disbursement of the same merchant
+func NewBatch(id uuid.UUID, merchantID string, idempotencyKey string, items []*Disbursement) (*Batch, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyDisbursementID,
+			ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(items) == 0 {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyBatch,
+			ErrEmptyBatch.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(items) > MaxBatchItems {
+		return nil, errors.NewIncorrectInputError(
+			ErrBatchTooLarge,
+			fmt.Sprintf("%s, max %d items", ErrBatchTooLarge.Error(), MaxBatchItems),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	for _, item := range items {
+		if item.merchantID != merchantID {
+			return nil, errors.NewDpayError(
+				ErrInvalidBatchItems,
+				fmt.Sprintf("disbursement %s does not belong to merchant %s", item.id, merchantID),
+				errors.DpayInternalError,
+			)
+		}
+
+		item.batchID = id
+	}
+
+	return &Batch{
+		id:             id,
+		merchantID:     merchantID,
+		itemCount:      len(items),
+		status:         BatchStatusReserving,
+		idempotencyKey: idempotencyKey,
+		requestHash:    hashBatchItems(items),
+		createdAt:      time.Now().UTC(),
+	}, nil
+}
+
+// hashBatchItems hashes the amount of every item in their order, the amount is normalized by money.Money
+// so "10000.50" and "10000.5" are the same request
+func hashBatchItems(items []*Disbursement) string {
+	var normalized strings.Builder
+	for _, item := range items {
+		normalized.WriteString(item.amount.Currency().String())
+		normalized.WriteString(" ")
+		normalized.WriteString(item.amount.Decimal())
+		normalized.WriteString("\n")
+	}
+
+	sum := sha256.Sum256([]byte(normalized.String()))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// UnmarshalBatchFromDatabase unmarshals Batch from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalBatchFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalBatchFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	itemCount int,
+	status BatchStatus,
+	idempotencyKey string,
+	requestHash string,
+	createdAt time.Time,
+) *Batch {
+	return &Batch{
+		id:             id,
+		merchantID:     merchantID,
+		itemCount:      itemCount,
+		status:         status,
+		idempotencyKey: idempotencyKey,
+		requestHash:    requestHash,
+		createdAt:      createdAt,
+	}
+}
+
+func (b Batch) ID() uuid.UUID {
+	return b.id
+}
+
+func (b Batch) MerchantID() string {
+	return b.merchantID
+}
+
+func (b Batch) ItemCount() int {
+	return b.itemCount
+}
+
+func (b Batch) Status() BatchStatus {
+	return b.status
+}
+
+// ItemIDs returns the disbursement ids of the items in their order, see NewBatchItemID
+func (b Batch) ItemIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, b.itemCount)
+	for i := range b.itemCount {
+		ids = append(ids, NewBatchItemID(b.id, i))
+	}
+
+	return ids
+}
+
+// IdempotencyKey returns the key the batch was requested with, empty if requested without key
+func (b Batch) IdempotencyKey() string {
+	return b.idempotencyKey
+}
+
+// RequestHash returns the hash of the items as requested, empty for the batches stored before it was kept
+func (b Batch) RequestHash() string {
+	return b.requestHash
+}
+
+func (b Batch) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// IsReplayOf checks whether the other batch is a retry of the same request,
+// a retry must come from the same merchant and carry the same idempotency key and the same items in the same order.
+// A batch stored without request hash can only be compared by its number of items.
+func (b Batch) IsReplayOf(other *Batch) bool {
+	sameItems := b.itemCount == other.itemCount &&
+		(b.requestHash == "" || other.requestHash == "" || b.requestHash == other.requestHash)
+
+	return b.idempotencyKey != "" &&
+		b.merchantID == other.merchantID &&
+		b.idempotencyKey == other.idempotencyKey &&
+		sameItems
+}
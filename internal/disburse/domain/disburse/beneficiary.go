@@ -0,0 +1,257 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptyBeneficiaryID       = stderrors.New("beneficiary id can not be empty")
+	ErrEmptyHolderName          = stderrors.New("holder name can not be empty")
+	ErrHolderNameTooLong        = stderrors.New("holder name is too long")
+	ErrHolderNameMismatch       = stderrors.New("holder name does not match the name registered at the bank")
+	ErrBankAccountNotFound      = stderrors.New("bank account not found")
+	ErrBeneficiaryNotFound      = stderrors.New("beneficiary not found")
+	ErrBeneficiaryAlreadyExists = stderrors.New("beneficiary with the same bank account already exists")
+	ErrBeneficiaryDeleted       = stderrors.New("beneficiary is deleted")
+	ErrNameInquiryNotConfigured = stderrors.New("name inquiry is not configured")
+)
+
+const maxHolderNameLength = 255
+
+// Beneficiary is a bank account the merchant disburses to, the holder name is the one registered at the bank
+type Beneficiary struct {
+	id          uuid.UUID
+	merchantID  string
+	bankAccount BankAccount
+	holderName  string
+
+	createdAt time.Time
+	updatedAt time.Time
+
+	// deletedAt is zero for an active beneficiary, a deleted one is kept for the disbursements referencing it
+	deletedAt time.Time
+}
+
+// NewBeneficiary creates a beneficiary of the merchant, holderName should come from the NameInquiry of the account
+func NewBeneficiary(
+	id uuid.UUID,
+	merchantID string,
+	bankAccount BankAccount,
+	holderName string,
+) (*Beneficiary, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyBeneficiaryID,
+			ErrEmptyBeneficiaryID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if merchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyMerchantID,
+			ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	holderName, err := validateHolderName(holderName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	return &Beneficiary{
+		id:          id,
+		merchantID:  merchantID,
+		bankAccount: bankAccount,
+		holderName:  holderName,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// UnmarshalBeneficiaryFromDatabase unmarshals Beneficiary from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalBeneficiaryFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalBeneficiaryFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	bankAccount BankAccount,
+	holderName string,
+	createdAt time.Time,
+	updatedAt time.Time,
+	deletedAt time.Time,
+) *Beneficiary {
+	return &Beneficiary{
+		id:          id,
+		merchantID:  merchantID,
+		bankAccount: bankAccount,
+		holderName:  holderName,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		deletedAt:   deletedAt,
+	}
+}
+
+func (b Beneficiary) ID() uuid.UUID {
+	return b.id
+}
+
+// MerchantID returns the merchant owning the beneficiary
+func (b Beneficiary) MerchantID() string {
+	return b.merchantID
+}
+
+func (b Beneficiary) BankAccount() BankAccount {
+	return b.bankAccount
+}
+
+func (b Beneficiary) HolderName() string {
+	return b.holderName
+}
+
+func (b Beneficiary) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+func (b Beneficiary) UpdatedAt() time.Time {
+	return b.updatedAt
+}
+
+// DeletedAt returns the time the beneficiary was deleted, zero if it is active
+func (b Beneficiary) DeletedAt() time.Time {
+	return b.deletedAt
+}
+
+func (b Beneficiary) IsDeleted() bool {
+	return !b.deletedAt.IsZero()
+}
+
+// Update replaces the bank account and the holder name, holderName should come from the NameInquiry of the account
+func (b *Beneficiary) Update(bankAccount BankAccount, holderName string) error {
+	if err := b.checkActive(); err != nil {
+		return err
+	}
+
+	holderName, err := validateHolderName(holderName)
+	if err != nil {
+		return err
+	}
+
+	b.bankAccount = bankAccount
+	b.holderName = holderName
+	b.updatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// Delete removes the beneficiary from the registry, it can no longer be disbursed to
+func (b *Beneficiary) Delete() error {
+	if err := b.checkActive(); err != nil {
+		return err
+	}
+
+	b.updatedAt = time.Now().UTC()
+	b.deletedAt = b.updatedAt
+
+	return nil
+}
+
+func (b Beneficiary) checkActive() error {
+	if b.IsDeleted() {
+		return errors.NewUnprocessableEntityError(
+			ErrBeneficiaryDeleted,
+			fmt.Sprintf("%s, beneficiary %s", ErrBeneficiaryDeleted.Error(), b.id),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+func validateHolderName(holderName string) (string, error) {
+	holderName = strings.TrimSpace(holderName)
+	if holderName == "" {
+		return "", errors.NewIncorrectInputError(
+			ErrEmptyHolderName,
+			ErrEmptyHolderName.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(holderName) > maxHolderNameLength {
+		return "", errors.NewIncorrectInputError(
+			ErrHolderNameTooLong,
+			fmt.Sprintf("%s, max %d characters", ErrHolderNameTooLong.Error(), maxHolderNameLength),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return holderName, nil
+}
+
+// MatchHolderName checks the holder name given by the merchant against the name registered at the bank,
+// the case, the punctuation and the spacing are ignored since banks format the names differently
+func MatchHolderName(given string, registered string) error {
+	if normalizeHolderName(given) != normalizeHolderName(registered) {
+		return errors.NewUnprocessableEntityError(
+			ErrHolderNameMismatch,
+			fmt.Sprintf("%s, the bank has %q", ErrHolderNameMismatch.Error(), registered),
+			errors.DpayHolderNameMismatch,
+		)
+	}
+
+	return nil
+}
+
+func normalizeHolderName(name string) string {
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, " ")
+}
+
+// NameInquiry looks up the holder name of a bank account at its bank
+type NameInquiry interface {
+	// InquireHolderName returns the name registered at the bank,
+	// an account unknown to the bank is Unprocessable with ErrBankAccountNotFound
+	InquireHolderName(ctx context.Context, bankAccount BankAccount) (string, error)
+}
+
+// BeneficiaryFilter narrows down beneficiaries to list, zero value fields are not filtered.
+// Deleted beneficiaries are never listed and the result is ordered from the newest beneficiary.
+type BeneficiaryFilter struct {
+	MerchantID    string
+	BankCode      string
+	AccountNumber string
+
+	// After is the position of the last beneficiary of the previous page, nil for the first page
+	After *ListCursor
+	Limit int
+}
+
+type BeneficiaryRepository interface {
+	// CreateBeneficiary fails with ErrBeneficiaryAlreadyExists when the merchant has an active beneficiary
+	// of the same bank account
+	CreateBeneficiary(ctx context.Context, beneficiary *Beneficiary) error
+
+	// GetBeneficiary returns the beneficiary even if it is deleted
+	GetBeneficiary(ctx context.Context, id uuid.UUID) (*Beneficiary, error)
+	ListBeneficiaries(ctx context.Context, filter BeneficiaryFilter) ([]*Beneficiary, error)
+
+	// UpdateBeneficiary saves the beneficiary, it fails with ErrBeneficiaryAlreadyExists when the new bank account
+	// belongs to another active beneficiary of the merchant
+	UpdateBeneficiary(ctx context.Context, beneficiary *Beneficiary) error
+}
@@ -0,0 +1,14 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+)
+
+var ErrCallbackAlreadyReceived = stderrors.New("payout callback was already received")
+
+// CallbackRepository remembers the payout callbacks already handled, a provider may deliver the same callback more than once
+type CallbackRepository interface {
+	// AddCallback returns ErrCallbackAlreadyReceived when the provider already sent a callback with the same event id
+	AddCallback(ctx context.Context, provider string, eventID string, result PayoutResult) error
+}
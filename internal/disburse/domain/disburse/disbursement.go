@@ -1,8 +1,461 @@
 package disburse
 
-import "github.com/google/uuid"
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptyDisbursementID     = stderrors.New("disbursement id can not be empty")
+	ErrEmptyMerchantID         = stderrors.New("merchant id can not be empty")
+	ErrInvalidAmount           = stderrors.New("disbursement amount must be greater than zero")
+	ErrInvalidStatus           = stderrors.New("invalid disbursement status")
+	ErrInvalidStatusTransition = stderrors.New("invalid disbursement status transition")
+
+	ErrIdempotencyKeyTooLong     = stderrors.New("idempotency key is too long")
+	ErrIdempotencyKeyReused      = stderrors.New("idempotency key was already used for a different disbursement")
+	ErrDisbursementAlreadyExists = stderrors.New("disbursement already exists")
+	ErrDisbursementNotFound      = stderrors.New("disbursement not found")
+	ErrDisbursementInPayout      = stderrors.New("disbursement is already sent for payout and can not be cancelled")
+	ErrDisbursementConflict      = stderrors.New("disbursement was updated concurrently, retry the request")
+)
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyNamespace is the UUID namespace to derive disbursement id from the idempotency key
+var idempotencyNamespace = uuid.MustParse("6f1e0c52-8d7e-4a57-9a3b-2c54f0a1d9e3")
 
 type Disbursement struct {
-	ID     uuid.UUID
-	Amount float64
+	id         uuid.UUID
+	merchantID string
+	amount     money.Money
+	status     Status
+
+	// batchID is uuid.Nil for a disbursement requested on its own
+	batchID uuid.UUID
+
+	// beneficiaryID is uuid.Nil for a disbursement requested without beneficiary
+	beneficiaryID uuid.UUID
+
+	idempotencyKey string
+	failureReason  string
+
+	// requiredApprovals is the number of approvals the disbursement waits for in AWAITING_APPROVAL before it
+	// is paid out and approvalDeadline the time they must come by, zero for a disbursement that needs no approval
+	requiredApprovals int
+	approvalDeadline  time.Time
+
+	// fee is charged to the merchant on top of the amount, feeScheduleID is the schedule it was calculated with,
+	// uuid.Nil when the merchant had no fee schedule
+	fee           money.Money
+	feeScheduleID uuid.UUID
+
+	// fxQuoteID is the quote the disbursement is funded with in another currency and fxRate its locked rate
+	// from the funding currency into the currency of amount, uuid.Nil and zero Rate when funded in the same currency
+	fxQuoteID uuid.UUID
+	fxRate    money.Rate
+
+	createdAt   time.Time
+	updatedAt   time.Time
+	processedAt time.Time
+	completedAt time.Time
+
+	// version is the stored version the disbursement was loaded at, every save increases it by one
+	// and is rejected with ErrDisbursementConflict when another save came in between
+	version int
+}
+
+// NewDisbursementID returns the id for a new disbursement. The same idempotency key of the same merchant
+// always gives the same id, so a retried request points to the disbursement created by the first one.
+// Empty key gives a random id.
+func NewDisbursementID(merchantID string, idempotencyKey string) uuid.UUID {
+	if idempotencyKey == "" {
+		return uuid.New()
+	}
+
+	return uuid.NewSHA1(idempotencyNamespace, []byte(merchantID+":"+idempotencyKey))
+}
+
+// NewDisbursement creates a new disbursement of the merchant in PENDING status
+func NewDisbursement(
+	id uuid.UUID,
+	merchantID string,
+	amount money.Money,
+	idempotencyKey string,
+) (*Disbursement, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyDisbursementID,
+			ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if merchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyMerchantID,
+			ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if !amount.IsPositive() {
+		return nil, errors.NewIncorrectInputError(
+			ErrInvalidAmount,
+			ErrInvalidAmount.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, errors.NewIncorrectInputError(
+			ErrIdempotencyKeyTooLong,
+			fmt.Sprintf("%s, max %d characters", ErrIdempotencyKeyTooLong.Error(), maxIdempotencyKeyLength),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	now := time.Now().UTC()
+
+	return &Disbursement{
+		id:             id,
+		merchantID:     merchantID,
+		amount:         amount,
+		status:         StatusPending,
+		idempotencyKey: idempotencyKey,
+		createdAt:      now,
+		updatedAt:      now,
+		version:        1,
+	}, nil
+}
+
+// UnmarshalDisbursementFromDatabase unmarshals Disbursement from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalDisbursementFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalDisbursementFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	amount money.Money,
+	status Status,
+	batchID uuid.UUID,
+	beneficiaryID uuid.UUID,
+	idempotencyKey string,
+	failureReason string,
+	requiredApprovals int,
+	approvalDeadline time.Time,
+	fee money.Money,
+	feeScheduleID uuid.UUID,
+	fxQuoteID uuid.UUID,
+	fxRate money.Rate,
+	createdAt time.Time,
+	updatedAt time.Time,
+	processedAt time.Time,
+	completedAt time.Time,
+	version int,
+) (*Disbursement, error) {
+	if !status.IsValid() {
+		return nil, errors.NewDpayError(
+			ErrInvalidStatus,
+			fmt.Sprintf("%s: %s", ErrInvalidStatus.Error(), status),
+			errors.DpayInternalError,
+		)
+	}
+
+	return &Disbursement{
+		id:                id,
+		merchantID:        merchantID,
+		amount:            amount,
+		status:            status,
+		batchID:           batchID,
+		beneficiaryID:     beneficiaryID,
+		idempotencyKey:    idempotencyKey,
+		failureReason:     failureReason,
+		requiredApprovals: requiredApprovals,
+		approvalDeadline:  approvalDeadline,
+		fee:               fee,
+		feeScheduleID:     feeScheduleID,
+		fxQuoteID:         fxQuoteID,
+		fxRate:            fxRate,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+		processedAt:       processedAt,
+		completedAt:       completedAt,
+		version:           version,
+	}, nil
+}
+
+func (d Disbursement) ID() uuid.UUID {
+	return d.id
+}
+
+// MerchantID returns the merchant the disbursement is paid from
+func (d Disbursement) MerchantID() string {
+	return d.merchantID
+}
+
+func (d Disbursement) Amount() money.Money {
+	return d.amount
+}
+
+// Fee returns the fee charged to the merchant for the disbursement, zero Money before it is applied
+func (d Disbursement) Fee() money.Money {
+	return d.fee
+}
+
+// FeeScheduleID returns the fee schedule the fee was calculated with, uuid.Nil when the merchant had none
+func (d Disbursement) FeeScheduleID() uuid.UUID {
+	return d.feeScheduleID
+}
+
+// Total returns the amount with the fee, what is taken from the merchant balance
+func (d Disbursement) Total() (money.Money, error) {
+	if d.fee.IsZero() {
+		return d.amount, nil
+	}
+
+	return d.amount.Add(d.fee)
+}
+
+// FXQuoteID returns the fx quote the disbursement is funded with, uuid.Nil when funded in its own currency
+func (d Disbursement) FXQuoteID() uuid.UUID {
+	return d.fxQuoteID
+}
+
+// FXRate returns the locked rate from the funding currency into the disbursement currency,
+// zero Rate when funded in its own currency
+func (d Disbursement) FXRate() money.Rate {
+	return d.fxRate
+}
+
+// FundingCurrency returns the currency the merchant balance is charged in
+func (d Disbursement) FundingCurrency() money.Currency {
+	if d.fxRate.IsZero() {
+		return d.amount.Currency()
+	}
+
+	return d.fxRate.Source()
+}
+
+// FundingTotal returns Total in the funding currency, what is reserved from the merchant balance
+func (d Disbursement) FundingTotal() (money.Money, error) {
+	total, err := d.Total()
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return d.funding(total)
+}
+
+// funding converts the amount in the disbursement currency into the funding currency at the locked rate
+func (d Disbursement) funding(amount money.Money) (money.Money, error) {
+	if d.fxRate.IsZero() {
+		return amount, nil
+	}
+
+	return d.fxRate.Inverse().Convert(amount)
+}
+
+func (d Disbursement) Status() Status {
+	return d.status
+}
+
+// BatchID returns the batch the disbursement belongs to, uuid.Nil when requested on its own
+func (d Disbursement) BatchID() uuid.UUID {
+	return d.batchID
+}
+
+// BeneficiaryID returns the beneficiary the disbursement is paid to, uuid.Nil when requested without beneficiary
+func (d Disbursement) BeneficiaryID() uuid.UUID {
+	return d.beneficiaryID
+}
+
+// AssignBeneficiary sets the beneficiary the disbursement is paid to,
+// it must be an active beneficiary of the merchant of the disbursement
+func (d *Disbursement) AssignBeneficiary(beneficiary *Beneficiary) error {
+	// a beneficiary of another merchant is reported as not found so its existence is not leaked
+	if beneficiary.MerchantID() != d.merchantID {
+		return errors.NewNotFoundError(
+			ErrBeneficiaryNotFound,
+			ErrBeneficiaryNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if err := beneficiary.checkActive(); err != nil {
+		return err
+	}
+
+	d.beneficiaryID = beneficiary.ID()
+
+	return nil
+}
+
+// IdempotencyKey returns the key the disbursement was requested with, empty if requested without key
+func (d Disbursement) IdempotencyKey() string {
+	return d.idempotencyKey
+}
+
+// IsReplayOf checks whether the other disbursement is a retry of the same request,
+// a retry must come from the same merchant and carry the same idempotency key, amount, beneficiary and fx quote
+func (d Disbursement) IsReplayOf(other *Disbursement) bool {
+	return d.idempotencyKey != "" &&
+		d.merchantID == other.merchantID &&
+		d.idempotencyKey == other.idempotencyKey &&
+		d.amount.Equal(other.amount) &&
+		d.beneficiaryID == other.beneficiaryID &&
+		d.fxQuoteID == other.fxQuoteID
+}
+
+func (d Disbursement) FailureReason() string {
+	return d.failureReason
+}
+
+// RequiredApprovals returns the number of approvals the disbursement needs, 0 when it needs no approval
+func (d Disbursement) RequiredApprovals() int {
+	return d.requiredApprovals
+}
+
+// RequiresApproval checks whether the disbursement had to be approved before it is paid out
+func (d Disbursement) RequiresApproval() bool {
+	return d.requiredApprovals > 0
+}
+
+// ApprovalDeadline returns the time the disbursement must be approved by, zero when it needs no approval
+func (d Disbursement) ApprovalDeadline() time.Time {
+	return d.approvalDeadline
+}
+
+func (d Disbursement) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d Disbursement) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+// ProcessedAt returns the time the disbursement was picked up for processing, zero if never processed
+func (d Disbursement) ProcessedAt() time.Time {
+	return d.processedAt
+}
+
+// CompletedAt returns the time the disbursement reached a final status, zero if not completed yet
+func (d Disbursement) CompletedAt() time.Time {
+	return d.completedAt
+}
+
+// Version returns the stored version the disbursement was loaded at, 1 for a new disbursement
+func (d Disbursement) Version() int {
+	return d.version
+}
+
+// StartProcessing moves a PENDING disbursement into PROCESSING
+func (d *Disbursement) StartProcessing() error {
+	if err := d.transitionTo(StatusProcessing); err != nil {
+		return err
+	}
+
+	d.processedAt = d.updatedAt
+
+	return nil
+}
+
+// MarkSuccess completes a PROCESSING disbursement as SUCCESS
+func (d *Disbursement) MarkSuccess() error {
+	if err := d.transitionTo(StatusSuccess); err != nil {
+		return err
+	}
+
+	d.completedAt = d.updatedAt
+
+	return nil
+}
+
+// MarkFailed completes a PROCESSING disbursement as FAILED with the given reason
+func (d *Disbursement) MarkFailed(reason string) error {
+	if err := d.transitionTo(StatusFailed); err != nil {
+		return err
+	}
+
+	d.failureReason = reason
+	d.completedAt = d.updatedAt
+
+	return nil
+}
+
+// Cancel stops a PENDING or AWAITING_APPROVAL disbursement before it is processed,
+// a PROCESSING disbursement is already sent to the payout provider and can only be reversed once paid out
+func (d *Disbursement) Cancel() error {
+	if d.status == StatusProcessing {
+		return errors.NewForbiddenError(
+			ErrDisbursementInPayout,
+			fmt.Sprintf("%s, disbursement %s is %s", ErrDisbursementInPayout.Error(), d.id, d.status),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	if err := d.transitionTo(StatusCancelled); err != nil {
+		return err
+	}
+
+	d.completedAt = d.updatedAt
+
+	return nil
+}
+
+// Reverse marks a SUCCESS disbursement as REVERSED and returns the reversal that compensates its payout,
+// a disbursement completed longer than ReversalWindow ago can no longer be reversed.
+// The reversal refunds the amount in the funding currency at the rate the disbursement was funded with.
+func (d *Disbursement) Reverse(reason string) (*Reversal, error) {
+	if d.status == StatusSuccess && time.Since(d.completedAt) > ReversalWindow {
+		return nil, errors.NewForbiddenError(
+			ErrReversalWindowExpired,
+			fmt.Sprintf(
+				"%s, disbursement %s was completed more than %s ago",
+				ErrReversalWindowExpired.Error(), d.id, ReversalWindow,
+			),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	refund, err := d.funding(d.amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.transitionTo(StatusReversed); err != nil {
+		return nil, err
+	}
+
+	return &Reversal{
+		id:             NewReversalID(d.id),
+		disbursementID: d.id,
+		merchantID:     d.merchantID,
+		amount:         refund,
+		reason:         reason,
+		createdAt:      d.updatedAt,
+	}, nil
+}
+
+func (d *Disbursement) transitionTo(target Status) error {
+	if !d.status.CanTransitionTo(target) {
+		return errors.NewUnprocessableEntityError(
+			ErrInvalidStatusTransition,
+			fmt.Sprintf(
+				"disbursement %s can not move from %s to %s",
+				d.id, d.status, target,
+			),
+			errors.DpayInvalidStatusTransition,
+		)
+	}
+
+	d.status = target
+	d.updatedAt = time.Now().UTC()
+
+	return nil
 }
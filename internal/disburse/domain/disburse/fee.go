@@ -0,0 +1,543 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrInvalidFeeSchedule       = stderrors.New("invalid fee schedule")
+	ErrFeeScheduleNotFound      = stderrors.New("merchant has no fee schedule")
+	ErrFeeScheduleVersionExists = stderrors.New("fee schedule version already exists")
+)
+
+// maxFeeRate is the max percentage rate in basis points, a fee can not be more than the amount
+const maxFeeRate = 10000
+
+type FeeRuleType string
+
+const (
+	// FeeRuleFlat charges the same fee whatever the amount
+	FeeRuleFlat = FeeRuleType("FLAT")
+
+	// FeeRulePercentage charges a rate of the amount
+	FeeRulePercentage = FeeRuleType("PERCENTAGE")
+
+	// FeeRuleTiered charges the flat fee and rate of the tier the whole amount falls in
+	FeeRuleTiered = FeeRuleType("TIERED")
+)
+
+func (t FeeRuleType) String() string {
+	return string(t)
+}
+
+func (t FeeRuleType) IsValid() bool {
+	return t == FeeRuleFlat || t == FeeRulePercentage || t == FeeRuleTiered
+}
+
+// FeeTier is a tier of a TIERED rule, the whole amount is charged the flat fee and rate of the first tier
+// whose upTo it doesn't exceed, the amount isn't split across tiers. A zero upTo has no upper bound and is
+// required for the last tier, so every amount falls in a tier.
+type FeeTier struct {
+	upTo money.Money
+	flat money.Money
+
+	// rate is in basis points, 100 is 1%
+	rate int64
+}
+
+func NewFeeTier(upTo money.Money, flat money.Money, rate int64) FeeTier {
+	return FeeTier{
+		upTo: upTo,
+		flat: flat,
+		rate: rate,
+	}
+}
+
+// UpTo returns the max amount of the tier, zero Money when it has no upper bound
+func (t FeeTier) UpTo() money.Money {
+	return t.upTo
+}
+
+func (t FeeTier) Flat() money.Money {
+	return t.flat
+}
+
+// Rate returns the rate of the tier in basis points
+func (t FeeTier) Rate() int64 {
+	return t.rate
+}
+
+// FeeRule is how the fee of a disbursement to a bank is calculated, a zero min or max is not capped
+type FeeRule struct {
+	// bankCode is the bank the rule applies to, empty for the banks without their own rule
+	bankCode string
+	ruleType FeeRuleType
+
+	// flat is used by FLAT, rate in basis points by PERCENTAGE and tiers by TIERED
+	flat  money.Money
+	rate  int64
+	tiers []FeeTier
+
+	min money.Money
+	max money.Money
+}
+
+// NewFeeRule creates a rule, only the fields of its type are kept
+func NewFeeRule(
+	bankCode string,
+	ruleType FeeRuleType,
+	flat money.Money,
+	rate int64,
+	tiers []FeeTier,
+	min money.Money,
+	max money.Money,
+) FeeRule {
+	rule := FeeRule{
+		bankCode: bankCode,
+		ruleType: ruleType,
+		min:      min,
+		max:      max,
+	}
+
+	switch ruleType {
+	case FeeRuleFlat:
+		rule.flat = flat
+	case FeeRulePercentage:
+		rule.rate = rate
+	case FeeRuleTiered:
+		rule.tiers = tiers
+	}
+
+	return rule
+}
+
+// BankCode returns the bank the rule applies to, empty for the default rule
+func (r FeeRule) BankCode() string {
+	return r.bankCode
+}
+
+func (r FeeRule) Type() FeeRuleType {
+	return r.ruleType
+}
+
+// Flat returns the fee of a FLAT rule
+func (r FeeRule) Flat() money.Money {
+	return r.flat
+}
+
+// Rate returns the rate of a PERCENTAGE rule in basis points
+func (r FeeRule) Rate() int64 {
+	return r.rate
+}
+
+// Tiers returns the tiers of a TIERED rule from the lowest
+func (r FeeRule) Tiers() []FeeTier {
+	return r.tiers
+}
+
+// Min returns the lowest fee of the rule, zero Money when not capped
+func (r FeeRule) Min() money.Money {
+	return r.min
+}
+
+// Max returns the highest fee of the rule, zero Money when not capped
+func (r FeeRule) Max() money.Money {
+	return r.max
+}
+
+func (r FeeRule) validate(currency money.Currency) error {
+	if !r.ruleType.IsValid() {
+		return newInvalidFeeScheduleError(fmt.Sprintf("fee rule type %q is not supported", r.ruleType))
+	}
+
+	amounts := map[string]money.Money{"min": r.min, "max": r.max, "flat": r.flat}
+	for i, tier := range r.tiers {
+		amounts[fmt.Sprintf("tier %d up to", i+1)] = tier.upTo
+		amounts[fmt.Sprintf("tier %d flat", i+1)] = tier.flat
+	}
+
+	for name, amount := range amounts {
+		if err := validateFeeAmount(name, amount, currency); err != nil {
+			return err
+		}
+	}
+
+	if !r.min.IsZero() && !r.max.IsZero() && r.min.MinorUnits() > r.max.MinorUnits() {
+		return newInvalidFeeScheduleError("min fee can not be more than the max fee")
+	}
+
+	switch r.ruleType {
+	case FeeRuleFlat:
+		if r.flat.IsZero() {
+			return newInvalidFeeScheduleError("flat rule needs a fee")
+		}
+	case FeeRulePercentage:
+		if err := validateFeeRate(r.rate); err != nil {
+			return err
+		}
+	case FeeRuleTiered:
+		return r.validateTiers()
+	}
+
+	return nil
+}
+
+func (r FeeRule) validateTiers() error {
+	if len(r.tiers) == 0 {
+		return newInvalidFeeScheduleError("tiered rule needs at least one tier")
+	}
+
+	for i, tier := range r.tiers {
+		if err := validateFeeRate(tier.rate); err != nil {
+			return err
+		}
+
+		last := i == len(r.tiers)-1
+		if tier.upTo.IsZero() {
+			if !last {
+				return newInvalidFeeScheduleError("only the last tier can have no upper bound")
+			}
+
+			continue
+		}
+
+		if last {
+			return newInvalidFeeScheduleError("the last tier can not have an upper bound")
+		}
+
+		if i > 0 && tier.upTo.MinorUnits() <= r.tiers[i-1].upTo.MinorUnits() {
+			return newInvalidFeeScheduleError("tiers must be ordered by their upper bound")
+		}
+	}
+
+	return nil
+}
+
+// calculate returns the fee of the amount before the caps
+func (r FeeRule) calculate(amount money.Money) (money.Money, error) {
+	zero, err := money.New(0, amount.Currency())
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	switch r.ruleType {
+	case FeeRuleFlat:
+		return r.flat, nil
+	case FeeRulePercentage:
+		return amount.BasisPoints(r.rate)
+	case FeeRuleTiered:
+		for _, tier := range r.tiers {
+			if !tier.upTo.IsZero() && amount.MinorUnits() > tier.upTo.MinorUnits() {
+				continue
+			}
+
+			fee, err := amount.BasisPoints(tier.rate)
+			if err != nil {
+				return money.Money{}, err
+			}
+
+			if tier.flat.IsZero() {
+				return fee, nil
+			}
+
+			return fee.Add(tier.flat)
+		}
+
+		// unreachable for a validated rule, its last tier has no upper bound
+		return money.Money{}, newInvalidFeeScheduleError("amount is above the last tier")
+	default:
+		return zero, nil
+	}
+}
+
+// capped returns the fee within the min and max of the rule
+func (r FeeRule) capped(fee money.Money) money.Money {
+	if !r.min.IsZero() && fee.MinorUnits() < r.min.MinorUnits() {
+		return r.min
+	}
+
+	if !r.max.IsZero() && fee.MinorUnits() > r.max.MinorUnits() {
+		return r.max
+	}
+
+	return fee
+}
+
+func validateFeeAmount(name string, amount money.Money, currency money.Currency) error {
+	if amount.IsZero() {
+		return nil
+	}
+
+	if amount.Currency() != currency {
+		return newInvalidFeeScheduleError(fmt.Sprintf("%s must be in %s", name, currency))
+	}
+
+	if amount.IsNegative() {
+		return newInvalidFeeScheduleError(fmt.Sprintf("%s can not be negative", name))
+	}
+
+	return nil
+}
+
+func validateFeeRate(rate int64) error {
+	if rate < 0 || rate > maxFeeRate {
+		return newInvalidFeeScheduleError(fmt.Sprintf("rate must be between 0 and %d basis points", maxFeeRate))
+	}
+
+	return nil
+}
+
+// FeeSchedule is a version of the fee rules of a merchant in a currency. A new version never changes
+// the older ones, it applies from effectiveFrom on and the fee of a disbursement keeps the schedule it was
+// calculated with.
+type FeeSchedule struct {
+	id         uuid.UUID
+	merchantID string
+	currency   money.Currency
+
+	// version starts from 1 and grows with every schedule of the merchant in the currency
+	version       int
+	effectiveFrom time.Time
+
+	// rules has at most one rule per bank code
+	rules []FeeRule
+
+	createdAt time.Time
+}
+
+// NewFeeSchedule creates the version of the schedule, every amount of the rules must be in the currency
+func NewFeeSchedule(
+	id uuid.UUID,
+	merchantID string,
+	currency money.Currency,
+	version int,
+	effectiveFrom time.Time,
+	rules []FeeRule,
+) (*FeeSchedule, error) {
+	if merchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyMerchantID,
+			ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if !currency.IsValid() {
+		return nil, newInvalidFeeScheduleError(fmt.Sprintf("currency %q is not supported", currency))
+	}
+
+	if version < 1 {
+		return nil, newInvalidFeeScheduleError("version must be positive")
+	}
+
+	if len(rules) == 0 {
+		return nil, newInvalidFeeScheduleError("at least one rule is needed")
+	}
+
+	bankCodes := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		if _, ok := bankCodes[rule.bankCode]; ok {
+			return nil, newInvalidFeeScheduleError(fmt.Sprintf("bank code %q has more than one rule", rule.bankCode))
+		}
+
+		bankCodes[rule.bankCode] = struct{}{}
+
+		if err := rule.validate(currency); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+
+	return &FeeSchedule{
+		id:            id,
+		merchantID:    merchantID,
+		currency:      currency,
+		version:       version,
+		effectiveFrom: effectiveFrom.UTC(),
+		rules:         rules,
+		createdAt:     now,
+	}, nil
+}
+
+// UnmarshalFeeScheduleFromDatabase unmarshals FeeSchedule from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalFeeScheduleFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalFeeScheduleFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	currency money.Currency,
+	version int,
+	effectiveFrom time.Time,
+	rules []FeeRule,
+	createdAt time.Time,
+) *FeeSchedule {
+	return &FeeSchedule{
+		id:            id,
+		merchantID:    merchantID,
+		currency:      currency,
+		version:       version,
+		effectiveFrom: effectiveFrom,
+		rules:         rules,
+		createdAt:     createdAt,
+	}
+}
+
+func (s FeeSchedule) ID() uuid.UUID {
+	return s.id
+}
+
+func (s FeeSchedule) MerchantID() string {
+	return s.merchantID
+}
+
+func (s FeeSchedule) Currency() money.Currency {
+	return s.currency
+}
+
+func (s FeeSchedule) Version() int {
+	return s.version
+}
+
+// EffectiveFrom returns the time the schedule applies from until a newer version is effective
+func (s FeeSchedule) EffectiveFrom() time.Time {
+	return s.effectiveFrom
+}
+
+func (s FeeSchedule) Rules() []FeeRule {
+	return s.rules
+}
+
+func (s FeeSchedule) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Calculate returns the fee of a disbursement of the amount to the bank, the rule of the bank is used
+// when there is one and the default rule otherwise. A bank without a rule and no default rule is charged nothing.
+func (s FeeSchedule) Calculate(amount money.Money, bankCode string) (money.Money, error) {
+	if amount.Currency() != s.currency {
+		return money.Money{}, errors.NewDpayError(
+			money.ErrCurrencyMismatch,
+			fmt.Sprintf(
+				"%s: fee schedule is in %s, got %s",
+				money.ErrCurrencyMismatch.Error(), s.currency, amount.Currency(),
+			),
+			errors.DpayInternalError,
+		)
+	}
+
+	rule, ok := s.rule(bankCode)
+	if !ok {
+		return money.New(0, s.currency)
+	}
+
+	fee, err := rule.calculate(amount)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return rule.capped(fee), nil
+}
+
+func (s FeeSchedule) rule(bankCode string) (FeeRule, bool) {
+	var (
+		fallback FeeRule
+		found    bool
+	)
+
+	for _, rule := range s.rules {
+		if bankCode != "" && rule.bankCode == bankCode {
+			return rule, true
+		}
+
+		if rule.bankCode == "" {
+			fallback, found = rule, true
+		}
+	}
+
+	return fallback, found
+}
+
+func newInvalidFeeScheduleError(message string) error {
+	return errors.NewIncorrectInputError(
+		ErrInvalidFeeSchedule,
+		fmt.Sprintf("%s: %s", ErrInvalidFeeSchedule.Error(), message),
+		errors.DpayInvalidRequest,
+	)
+}
+
+// ApplyFee sets the fee of the disbursement from the schedule, a nil schedule charges nothing
+func (d *Disbursement) ApplyFee(schedule *FeeSchedule, bankCode string) error {
+	if schedule == nil {
+		fee, err := money.New(0, d.amount.Currency())
+		if err != nil {
+			return err
+		}
+
+		d.fee = fee
+		d.feeScheduleID = uuid.Nil
+
+		return nil
+	}
+
+	fee, err := schedule.Calculate(d.amount, bankCode)
+	if err != nil {
+		return err
+	}
+
+	d.fee = fee
+	d.feeScheduleID = schedule.ID()
+
+	return nil
+}
+
+// GetEffectiveFeeSchedule returns the fee schedule of the merchant in the currency effective at the time,
+// nil when the merchant has none so it is charged nothing
+func GetEffectiveFeeSchedule(
+	ctx context.Context,
+	feeRepo FeeRepository,
+	merchantID string,
+	currency money.Currency,
+	at time.Time,
+) (*FeeSchedule, error) {
+	schedule, err := feeRepo.GetEffectiveFeeSchedule(ctx, merchantID, currency, at)
+	if stderrors.Is(err, ErrFeeScheduleNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+type FeeRepository interface {
+	// GetEffectiveFeeSchedule returns the highest version effective at the time, or ErrFeeScheduleNotFound
+	// as not found error when the merchant has none in the currency, its disbursements are then charged nothing
+	GetEffectiveFeeSchedule(
+		ctx context.Context,
+		merchantID string,
+		currency money.Currency,
+		at time.Time,
+	) (*FeeSchedule, error)
+
+	// GetLatestFeeSchedule returns the highest version whatever its effective time,
+	// or ErrFeeScheduleNotFound as not found error
+	GetLatestFeeSchedule(ctx context.Context, merchantID string, currency money.Currency) (*FeeSchedule, error)
+
+	// CreateFeeSchedule returns ErrFeeScheduleVersionExists as unprocessable entity error
+	// when the version was already created, e.g. by a concurrent request
+	CreateFeeSchedule(ctx context.Context, schedule *FeeSchedule) error
+}
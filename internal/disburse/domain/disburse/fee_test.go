@@ -0,0 +1,206 @@
+package disburse
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+func TestFeeRuleCalculate(t *testing.T) {
+	tiers := []FeeTier{
+		NewFeeTier(idr(t, "1000000"), idr(t, "2500"), 0),
+		NewFeeTier(idr(t, "10000000"), idr(t, "1000"), 10),
+		NewFeeTier(money.Money{}, money.Money{}, 5),
+	}
+
+	tests := []struct {
+		name   string
+		rule   FeeRule
+		amount string
+		expect string
+	}{
+		{
+			name:   "flat",
+			rule:   NewFeeRule("", FeeRuleFlat, idr(t, "6500"), 0, nil, money.Money{}, money.Money{}),
+			amount: "5000000",
+			expect: "6500.00",
+		},
+		{
+			name:   "percentage",
+			rule:   NewFeeRule("", FeeRulePercentage, money.Money{}, 25, nil, money.Money{}, money.Money{}),
+			amount: "1000000",
+			expect: "2500.00",
+		},
+		{
+			name:   "percentage rounded half away from zero",
+			rule:   NewFeeRule("", FeeRulePercentage, money.Money{}, 25, nil, money.Money{}, money.Money{}),
+			amount: "2",
+			expect: "0.01",
+		},
+		{
+			name:   "tiered below the first bound",
+			rule:   NewFeeRule("", FeeRuleTiered, money.Money{}, 0, tiers, money.Money{}, money.Money{}),
+			amount: "500000",
+			expect: "2500.00",
+		},
+		{
+			name:   "tiered on the first bound",
+			rule:   NewFeeRule("", FeeRuleTiered, money.Money{}, 0, tiers, money.Money{}, money.Money{}),
+			amount: "1000000",
+			expect: "2500.00",
+		},
+		{
+			name:   "tiered charges the whole amount at the second tier",
+			rule:   NewFeeRule("", FeeRuleTiered, money.Money{}, 0, tiers, money.Money{}, money.Money{}),
+			amount: "1000000.01",
+			expect: "2000.00",
+		},
+		{
+			name:   "tiered in the unbounded last tier",
+			rule:   NewFeeRule("", FeeRuleTiered, money.Money{}, 0, tiers, money.Money{}, money.Money{}),
+			amount: "50000000",
+			expect: "25000.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.calculate(idr(t, tt.amount))
+			if err != nil {
+				t.Fatalf("calculate error = %v", err)
+			}
+
+			if got.Decimal() != tt.expect {
+				t.Errorf("calculate(%s) = %s, want %s", tt.amount, got.Decimal(), tt.expect)
+			}
+		})
+	}
+}
+
+func TestFeeScheduleCalculate(t *testing.T) {
+	schedule, err := NewFeeSchedule(
+		uuid.New(),
+		"merchant-1",
+		money.IDR,
+		1,
+		time.Time{},
+		[]FeeRule{
+			NewFeeRule("", FeeRulePercentage, money.Money{}, 100, nil, idr(t, "2000"), idr(t, "15000")),
+			NewFeeRule("BCA", FeeRuleFlat, idr(t, "1000"), 0, nil, money.Money{}, money.Money{}),
+		},
+	)
+	if err != nil {
+		t.Fatalf("new fee schedule: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		amount   money.Money
+		bankCode string
+		expect   string
+		wantErr  error
+	}{
+		{name: "default rule", amount: idr(t, "500000"), bankCode: "BNI", expect: "5000.00"},
+		{name: "default rule without bank", amount: idr(t, "500000"), expect: "5000.00"},
+		{name: "capped by min", amount: idr(t, "10000"), bankCode: "BNI", expect: "2000.00"},
+		{name: "capped by max", amount: idr(t, "10000000"), bankCode: "BNI", expect: "15000.00"},
+		{name: "bank rule", amount: idr(t, "10000000"), bankCode: "BCA", expect: "1000.00"},
+		{name: "other currency", amount: usd(t, "100"), wantErr: money.ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := schedule.Calculate(tt.amount, tt.bankCode)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("Calculate error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && got.Decimal() != tt.expect {
+				t.Errorf("Calculate(%s, %q) = %s, want %s", tt.amount, tt.bankCode, got.Decimal(), tt.expect)
+			}
+		})
+	}
+}
+
+func TestFeeScheduleWithoutDefaultRule(t *testing.T) {
+	schedule, err := NewFeeSchedule(
+		uuid.New(),
+		"merchant-1",
+		money.IDR,
+		1,
+		time.Time{},
+		[]FeeRule{NewFeeRule("BCA", FeeRuleFlat, idr(t, "1000"), 0, nil, money.Money{}, money.Money{})},
+	)
+	if err != nil {
+		t.Fatalf("new fee schedule: %v", err)
+	}
+
+	got, err := schedule.Calculate(idr(t, "500000"), "BNI")
+	if err != nil || !got.IsZero() {
+		t.Errorf("Calculate for a bank without rule = %v, %v, want zero fee", got, err)
+	}
+}
+
+func TestNewFeeScheduleValidatesRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []FeeRule
+	}{
+		{
+			name:  "no rule",
+			rules: nil,
+		},
+		{
+			name: "bounded last tier",
+			rules: []FeeRule{NewFeeRule("", FeeRuleTiered, money.Money{}, 0, []FeeTier{
+				NewFeeTier(idr(t, "1000000"), idr(t, "2500"), 0),
+			}, money.Money{}, money.Money{})},
+		},
+		{
+			name: "unbounded tier before the last",
+			rules: []FeeRule{NewFeeRule("", FeeRuleTiered, money.Money{}, 0, []FeeTier{
+				NewFeeTier(money.Money{}, idr(t, "2500"), 0),
+				NewFeeTier(money.Money{}, idr(t, "1000"), 0),
+			}, money.Money{}, money.Money{})},
+		},
+		{
+			name: "unordered tiers",
+			rules: []FeeRule{NewFeeRule("", FeeRuleTiered, money.Money{}, 0, []FeeTier{
+				NewFeeTier(idr(t, "5000000"), idr(t, "2500"), 0),
+				NewFeeTier(idr(t, "1000000"), idr(t, "1000"), 0),
+				NewFeeTier(money.Money{}, money.Money{}, 5),
+			}, money.Money{}, money.Money{})},
+		},
+		{
+			name:  "rate above 100%",
+			rules: []FeeRule{NewFeeRule("", FeeRulePercentage, money.Money{}, 10001, nil, money.Money{}, money.Money{})},
+		},
+		{
+			name:  "min above max",
+			rules: []FeeRule{NewFeeRule("", FeeRulePercentage, money.Money{}, 10, nil, idr(t, "5000"), idr(t, "1000"))},
+		},
+		{
+			name:  "amount in another currency",
+			rules: []FeeRule{NewFeeRule("", FeeRuleFlat, usd(t, "1"), 0, nil, money.Money{}, money.Money{})},
+		},
+		{
+			name: "two rules for a bank",
+			rules: []FeeRule{
+				NewFeeRule("BCA", FeeRuleFlat, idr(t, "1000"), 0, nil, money.Money{}, money.Money{}),
+				NewFeeRule("BCA", FeeRuleFlat, idr(t, "2000"), 0, nil, money.Money{}, money.Money{}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFeeSchedule(uuid.New(), "merchant-1", money.IDR, 1, time.Time{}, tt.rules)
+			if !stderrors.Is(err, ErrInvalidFeeSchedule) {
+				t.Errorf("NewFeeSchedule error = %v, want %v", err, ErrInvalidFeeSchedule)
+			}
+		})
+	}
+}
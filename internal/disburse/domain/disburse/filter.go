@@ -0,0 +1,31 @@
+package disburse
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+// ListFilter narrows down disbursements to list, zero value fields are not filtered.
+// The result is ordered from the newest disbursement.
+type ListFilter struct {
+	MerchantID  string
+	Statuses    []Status
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	// MinAmount and MaxAmount only match disbursements in the same currency
+	MinAmount *money.Money
+	MaxAmount *money.Money
+
+	// After is the position of the last disbursement of the previous page, nil for the first page
+	After *ListCursor
+	Limit int
+}
+
+// ListCursor is the position of a disbursement or a beneficiary in the list order
+type ListCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
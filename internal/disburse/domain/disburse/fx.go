@@ -0,0 +1,202 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrFXRateNotFound  = stderrors.New("no exchange rate for the currency pair")
+	ErrFXSameCurrency  = stderrors.New("exchange needs two different currencies")
+	ErrEmptyFXQuoteID  = stderrors.New("fx quote id is empty")
+	ErrFXQuoteNotFound = stderrors.New("fx quote not found")
+	ErrFXQuoteExpired  = stderrors.New("fx quote has expired")
+	ErrFXQuoteUsed     = stderrors.New("fx quote is already used by another disbursement")
+	ErrFXQuoteMismatch = stderrors.New("fx quote does not convert into the disbursement currency")
+)
+
+// FXQuoteLockDuration is how long the rate of a quote is locked, a disbursement must use the quote before it expires
+const FXQuoteLockDuration = 5 * time.Minute
+
+// FXQuote is a rate locked for a merchant until expiresAt, from the funding currency of the merchant
+// as source into the currency of the disbursement as target. A quote is used by one disbursement only.
+type FXQuote struct {
+	id         uuid.UUID
+	merchantID string
+	rate       money.Rate
+
+	// disbursementID is the disbursement that used the quote, uuid.Nil while the quote is not used
+	disbursementID uuid.UUID
+
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewFXQuote locks the rate for the merchant for FXQuoteLockDuration
+func NewFXQuote(id uuid.UUID, merchantID string, rate money.Rate) (*FXQuote, error) {
+	if merchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyMerchantID,
+			ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if rate.Source() == rate.Target() {
+		return nil, errors.NewIncorrectInputError(
+			ErrFXSameCurrency,
+			fmt.Sprintf("%s, got %s to %s", ErrFXSameCurrency.Error(), rate.Source(), rate.Target()),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	now := time.Now().UTC()
+
+	return &FXQuote{
+		id:         id,
+		merchantID: merchantID,
+		rate:       rate,
+		expiresAt:  now.Add(FXQuoteLockDuration),
+		createdAt:  now,
+	}, nil
+}
+
+// UnmarshalFXQuoteFromDatabase unmarshals FXQuote from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalFXQuoteFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalFXQuoteFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	rate money.Rate,
+	disbursementID uuid.UUID,
+	expiresAt time.Time,
+	createdAt time.Time,
+) *FXQuote {
+	return &FXQuote{
+		id:             id,
+		merchantID:     merchantID,
+		rate:           rate,
+		disbursementID: disbursementID,
+		expiresAt:      expiresAt,
+		createdAt:      createdAt,
+	}
+}
+
+func (q FXQuote) ID() uuid.UUID {
+	return q.id
+}
+
+func (q FXQuote) MerchantID() string {
+	return q.merchantID
+}
+
+// Rate returns the locked rate, its source is the funding currency and its target the disbursement currency
+func (q FXQuote) Rate() money.Rate {
+	return q.rate
+}
+
+// DisbursementID returns the disbursement that used the quote, uuid.Nil while the quote is not used
+func (q FXQuote) DisbursementID() uuid.UUID {
+	return q.disbursementID
+}
+
+func (q FXQuote) ExpiresAt() time.Time {
+	return q.expiresAt
+}
+
+func (q FXQuote) CreatedAt() time.Time {
+	return q.createdAt
+}
+
+// IsExpired checks whether the rate is no longer locked at now
+func (q FXQuote) IsExpired(now time.Time) bool {
+	return !now.Before(q.expiresAt)
+}
+
+// RequestFXQuote sets the quote the disbursement asks to be funded with, before the quote is applied,
+// so a retry is compared with the original request by its quote even when the quote expired since
+func (d *Disbursement) RequestFXQuote(id uuid.UUID) {
+	d.fxQuoteID = id
+}
+
+// ApplyFXQuote funds the disbursement in the source currency of the quote at its locked rate,
+// the quote must belong to the merchant, convert into the disbursement currency and be neither expired nor used
+func (d *Disbursement) ApplyFXQuote(quote *FXQuote, now time.Time) error {
+	// a quote of another merchant is reported as not found so its existence is not leaked
+	if quote.merchantID != d.merchantID {
+		return errors.NewNotFoundError(
+			ErrFXQuoteNotFound,
+			ErrFXQuoteNotFound.Error(),
+			errors.DpayNotFound,
+		)
+	}
+
+	if quote.rate.Target() != d.amount.Currency() {
+		return errors.NewIncorrectInputError(
+			ErrFXQuoteMismatch,
+			fmt.Sprintf(
+				"%s, quote %s converts into %s, disbursement is in %s",
+				ErrFXQuoteMismatch.Error(), quote.id, quote.rate.Target(), d.amount.Currency(),
+			),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if quote.disbursementID != uuid.Nil && quote.disbursementID != d.id {
+		return errors.NewUnprocessableEntityError(
+			ErrFXQuoteUsed,
+			fmt.Sprintf("%s, quote %s", ErrFXQuoteUsed.Error(), quote.id),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	if quote.IsExpired(now) {
+		return errors.NewUnprocessableEntityError(
+			ErrFXQuoteExpired,
+			fmt.Sprintf("%s, quote %s expired at %s", ErrFXQuoteExpired.Error(), quote.id, quote.expiresAt.Format(time.RFC3339)),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	funding, err := quote.rate.Inverse().Convert(d.amount)
+	if err != nil {
+		return err
+	}
+
+	if !funding.IsPositive() {
+		return errors.NewIncorrectInputError(
+			ErrInvalidAmount,
+			fmt.Sprintf("%s, %s is worth nothing in %s", ErrInvalidAmount.Error(), d.amount, quote.rate.Source()),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	d.fxQuoteID = quote.id
+	d.fxRate = quote.rate
+
+	return nil
+}
+
+// FXRateProvider is the source of the exchange rates a quote is locked with
+type FXRateProvider interface {
+	// GetRate returns ErrFXRateNotFound as unprocessable entity error when the pair can not be exchanged
+	GetRate(ctx context.Context, source money.Currency, target money.Currency) (money.Rate, error)
+}
+
+type FXQuoteRepository interface {
+	CreateFXQuote(ctx context.Context, quote *FXQuote) error
+
+	// GetFXQuote returns ErrFXQuoteNotFound as not found error
+	GetFXQuote(ctx context.Context, id uuid.UUID) (*FXQuote, error)
+
+	// UseFXQuote marks the quote as used by the disbursement, it must run in the transaction that stores
+	// the disbursement. It returns ErrFXQuoteUsed as unprocessable entity error when another disbursement used it first.
+	UseFXQuote(ctx context.Context, quoteID uuid.UUID, disbursementID uuid.UUID) error
+}
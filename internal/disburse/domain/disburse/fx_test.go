@@ -0,0 +1,113 @@
+package disburse
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+func TestApplyFXQuote(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		quote   *FXQuote
+		now     time.Time
+		expect  string
+		wantErr error
+	}{
+		{
+			name:   "funded at the locked rate",
+			quote:  newTestFXQuote(t, "merchant-1", money.USD, money.IDR, "16000", uuid.Nil),
+			now:    now,
+			expect: "0.63",
+		},
+		{
+			name:    "quote of another merchant",
+			quote:   newTestFXQuote(t, "merchant-2", money.USD, money.IDR, "16000", uuid.Nil),
+			now:     now,
+			wantErr: ErrFXQuoteNotFound,
+		},
+		{
+			name:    "quote into another currency",
+			quote:   newTestFXQuote(t, "merchant-1", money.IDR, money.USD, "0.0000625", uuid.Nil),
+			now:     now,
+			wantErr: ErrFXQuoteMismatch,
+		},
+		{
+			name:    "quote used by another disbursement",
+			quote:   newTestFXQuote(t, "merchant-1", money.USD, money.IDR, "16000", uuid.New()),
+			now:     now,
+			wantErr: ErrFXQuoteUsed,
+		},
+		{
+			name:    "expired quote",
+			quote:   newTestFXQuote(t, "merchant-1", money.USD, money.IDR, "16000", uuid.Nil),
+			now:     now.Add(FXQuoteLockDuration + time.Minute),
+			wantErr: ErrFXQuoteExpired,
+		},
+		{
+			name:    "amount worth nothing in the funding currency",
+			quote:   newTestFXQuote(t, "merchant-1", money.USD, money.IDR, "3000000", uuid.Nil),
+			now:     now,
+			wantErr: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDisbursement(t)
+
+			err := d.ApplyFXQuote(tt.quote, tt.now)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyFXQuote error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if d.FundingCurrency() != money.IDR {
+					t.Errorf("FundingCurrency() = %s after a rejected quote, want %s", d.FundingCurrency(), money.IDR)
+				}
+
+				return
+			}
+
+			funding, err := d.FundingTotal()
+			if err != nil {
+				t.Fatalf("FundingTotal error = %v", err)
+			}
+
+			if funding.Currency() != money.USD || funding.Decimal() != tt.expect {
+				t.Errorf("FundingTotal() = %s, want %s USD", funding, tt.expect)
+			}
+		})
+	}
+}
+
+// newTestFXQuote creates a quote locked at the rate from now, used by disbursementID unless it is uuid.Nil
+func newTestFXQuote(
+	t *testing.T,
+	merchantID string,
+	source money.Currency,
+	target money.Currency,
+	rate string,
+	disbursementID uuid.UUID,
+) *FXQuote {
+	t.Helper()
+
+	r, err := money.NewRate(source, target, rate)
+	if err != nil {
+		t.Fatalf("new rate: %v", err)
+	}
+
+	quote, err := NewFXQuote(uuid.New(), merchantID, r)
+	if err != nil {
+		t.Fatalf("new fx quote: %v", err)
+	}
+
+	quote.disbursementID = disbursementID
+
+	return quote
+}
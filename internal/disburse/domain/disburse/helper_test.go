@@ -0,0 +1,57 @@
+package disburse
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+// step moves a disbursement through one transition of its lifecycle
+type step func(d *Disbursement) error
+
+func startProcessing(d *Disbursement) error { return d.StartProcessing() }
+func markSuccess(d *Disbursement) error     { return d.MarkSuccess() }
+func markFailed(d *Disbursement) error      { return d.MarkFailed("bank rejected") }
+func cancel(d *Disbursement) error          { return d.Cancel() }
+
+// newTestDisbursement creates a PENDING disbursement of 10000 IDR and moves it through the steps
+func newTestDisbursement(t *testing.T, steps ...step) *Disbursement {
+	t.Helper()
+
+	d, err := NewDisbursement(uuid.New(), "merchant-1", idr(t, "10000"), "")
+	if err != nil {
+		t.Fatalf("new disbursement: %v", err)
+	}
+
+	for _, step := range steps {
+		if err := step(d); err != nil {
+			t.Fatalf("move disbursement from %s: %v", d.Status(), err)
+		}
+	}
+
+	return d
+}
+
+func idr(t *testing.T, amount string) money.Money {
+	t.Helper()
+
+	return mustParseMoney(t, amount, money.IDR)
+}
+
+func usd(t *testing.T, amount string) money.Money {
+	t.Helper()
+
+	return mustParseMoney(t, amount, money.USD)
+}
+
+func mustParseMoney(t *testing.T, amount string, currency money.Currency) money.Money {
+	t.Helper()
+
+	m, err := money.Parse(amount, currency.String())
+	if err != nil {
+		t.Fatalf("parse %s %s: %v", amount, currency, err)
+	}
+
+	return m
+}
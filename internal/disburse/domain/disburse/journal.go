@@ -0,0 +1,120 @@
+package disburse
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+// journalNamespace is the UUID namespace to derive the journal entry id from the disbursement id and status
+var journalNamespace = uuid.MustParse("0c9d5f3a-2e7b-4b18-a6d4-93f1e8c27b60")
+
+// journalDescriptions describes the journal entry written when the disbursement reaches the status
+var journalDescriptions = map[Status]string{
+	StatusAwaitingApproval: "reserve the merchant funds until the disbursement is approved",
+	StatusPending:          "reserve the merchant funds",
+	StatusProcessing:       "send the reserved funds for payout",
+	StatusSuccess:          "pay out to the beneficiary and earn the fee",
+	StatusFailed:           "return the funds of the failed payout",
+	StatusCancelled:        "return the reserved funds of the cancelled disbursement",
+	StatusReversed:         "refund the reversed payout",
+	StatusRejected:         "return the reserved funds of the rejected disbursement",
+}
+
+// Journal returns the ledger entry of the move into the current status. Every status moves the funds the same way
+// whatever status it is reached from, so the current status alone tells which account is debited and which is
+// credited. The only exception is an approved disbursement entering PENDING, its funds are already reserved
+// since it started awaiting approval, so there is no entry and Journal returns nil.
+// The fee is reserved and sent with the amount and earned once the payout succeeds, a reversal only refunds
+// the amount so the fee is kept. The merchant accounts are in the funding currency and the payout accounts in the
+// disbursement currency, the funds are exchanged at the locked rate when they move between them.
+// The entry id is derived from the status, so the same move is never journaled twice.
+func (d Disbursement) Journal() (*ledger.JournalEntry, error) {
+	var (
+		available = ledger.MerchantAvailableAccount(d.merchantID)
+		reserved  = ledger.MerchantReservedAccount(d.merchantID)
+		postings  []ledger.Posting
+	)
+
+	total, err := d.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	fundingTotal, err := d.funding(total)
+	if err != nil {
+		return nil, err
+	}
+
+	switch d.status {
+	case StatusAwaitingApproval:
+		postings = transfer(available, reserved, fundingTotal)
+	case StatusPending:
+		if d.RequiresApproval() {
+			return nil, nil
+		}
+
+		postings = transfer(available, reserved, fundingTotal)
+	case StatusProcessing:
+		postings = exchange(reserved, fundingTotal, ledger.PayoutInTransitAccount, total)
+	case StatusSuccess:
+		postings = transfer(ledger.PayoutInTransitAccount, ledger.SettlementAccount, d.amount)
+		if d.fee.IsPositive() {
+			postings = append(postings, transfer(ledger.PayoutInTransitAccount, ledger.FeeRevenueAccount, d.fee)...)
+		}
+	case StatusFailed:
+		postings = exchange(ledger.PayoutInTransitAccount, total, available, fundingTotal)
+	case StatusCancelled, StatusRejected:
+		postings = transfer(reserved, available, fundingTotal)
+	case StatusReversed:
+		refund, err := d.funding(d.amount)
+		if err != nil {
+			return nil, err
+		}
+
+		postings = exchange(ledger.SettlementAccount, d.amount, available, refund)
+	default:
+		return nil, errors.NewDpayError(
+			ErrInvalidStatus,
+			fmt.Sprintf("%s: no journal for %s", ErrInvalidStatus.Error(), d.status),
+			errors.DpayInternalError,
+		)
+	}
+
+	return ledger.NewJournalEntry(
+		uuid.NewSHA1(journalNamespace, []byte(d.id.String()+":"+d.status.String())),
+		d.id,
+		journalDescriptions[d.status],
+		postings,
+		d.updatedAt,
+	)
+}
+
+// transfer returns the postings moving the amount from the debited account to the credited one
+func transfer(debit ledger.Account, credit ledger.Account, amount money.Money) []ledger.Posting {
+	return []ledger.Posting{
+		ledger.Debit(debit, amount),
+		ledger.Credit(credit, amount),
+	}
+}
+
+// exchange returns the postings moving debitAmount out of the debited account and creditAmount into
+// the credited one, amounts in two currencies go through ledger.FXConversionAccount so each currency balances
+func exchange(
+	debit ledger.Account,
+	debitAmount money.Money,
+	credit ledger.Account,
+	creditAmount money.Money,
+) []ledger.Posting {
+	if debitAmount.Currency() == creditAmount.Currency() {
+		return transfer(debit, credit, debitAmount)
+	}
+
+	return append(
+		transfer(debit, ledger.FXConversionAccount, debitAmount),
+		transfer(ledger.FXConversionAccount, credit, creditAmount)...,
+	)
+}
@@ -0,0 +1,76 @@
+package disburse
+
+import (
+	"testing"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
+)
+
+func TestDisbursementJournal(t *testing.T) {
+	reserved := ledger.MerchantReservedAccount("merchant-1")
+	available := ledger.MerchantAvailableAccount("merchant-1")
+
+	tests := []struct {
+		name  string
+		steps []step
+
+		// expect is what the accounts hold on their normal side at the end, the other accounts end at zero
+		expect map[ledger.Account]int64
+	}{
+		{
+			name:   "paid out",
+			steps:  []step{startProcessing, markSuccess},
+			expect: map[ledger.Account]int64{available: -1000000, ledger.SettlementAccount: -1000000},
+		},
+		{
+			name:  "failed payout",
+			steps: []step{startProcessing, markFailed},
+		},
+		{
+			name:  "cancelled before processing",
+			steps: []step{cancel},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDisbursement(t)
+			balances := make(map[ledger.Account]int64)
+
+			addJournal := func() {
+				entry, err := d.Journal()
+				if err != nil {
+					t.Fatalf("journal of %s: %v", d.Status(), err)
+				}
+
+				for _, posting := range entry.Postings() {
+					amount := posting.Amount().MinorUnits()
+					if posting.Direction() != posting.Account().Type().NormalSide() {
+						amount = -amount
+					}
+
+					balances[posting.Account()] += amount
+				}
+			}
+
+			addJournal()
+			for _, step := range tt.steps {
+				if err := step(d); err != nil {
+					t.Fatalf("move disbursement: %v", err)
+				}
+
+				addJournal()
+			}
+
+			for account, amount := range balances {
+				if amount != tt.expect[account] {
+					t.Errorf("balance of %s = %d, want %d", account.Code(), amount, tt.expect[account])
+				}
+			}
+
+			if balances[reserved] != 0 || balances[ledger.PayoutInTransitAccount] != 0 {
+				t.Errorf("funds left reserved or in transit: %v", balances)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/samber/lo"
+)
+
+var (
+	ErrLimitExceeded         = stderrors.New("disbursement limit exceeded")
+	ErrInvalidLimit          = stderrors.New("invalid disbursement limit")
+	ErrMerchantLimitNotFound = stderrors.New("merchant has no disbursement limits")
+)
+
+type LimitType string
+
+const (
+	LimitPerTransaction = LimitType("PER_TRANSACTION")
+	LimitDailyTotal     = LimitType("DAILY_TOTAL")
+	LimitMonthlyTotal   = LimitType("MONTHLY_TOTAL")
+	LimitCountPerWindow = LimitType("COUNT_PER_WINDOW")
+)
+
+func (t LimitType) String() string {
+	return string(t)
+}
+
+// Limits are the disbursement rules of a merchant in a currency, a zero rule is not limited.
+// The day and the month of the totals are UTC calendar days and months,
+// the count is over the rolling countWindow before the disbursement.
+type Limits struct {
+	currency money.Currency
+
+	perTransaction money.Money
+	dailyTotal     money.Money
+	monthlyTotal   money.Money
+
+	maxCount    int
+	countWindow time.Duration
+}
+
+// LimitUsage is what the merchant already disbursed in the currency of the limits,
+// the failed, cancelled and rejected disbursements do not count since their balance is released
+type LimitUsage struct {
+	DailyTotal   money.Money
+	MonthlyTotal money.Money
+	Count        int
+}
+
+// LimitWindows are the start of the periods the usage is counted from
+type LimitWindows struct {
+	DayStart   time.Time
+	MonthStart time.Time
+	CountStart time.Time
+}
+
+// NewLimits creates the limits of a currency, every amount must be in that currency
+func NewLimits(
+	currency money.Currency,
+	perTransaction money.Money,
+	dailyTotal money.Money,
+	monthlyTotal money.Money,
+	maxCount int,
+	countWindow time.Duration,
+) (Limits, error) {
+	if !currency.IsValid() {
+		return Limits{}, newInvalidLimitError(fmt.Sprintf("currency %q is not supported", currency))
+	}
+
+	for limitType, amount := range map[LimitType]money.Money{
+		LimitPerTransaction: perTransaction,
+		LimitDailyTotal:     dailyTotal,
+		LimitMonthlyTotal:   monthlyTotal,
+	} {
+		if amount.IsZero() {
+			continue
+		}
+
+		if amount.Currency() != currency {
+			return Limits{}, newInvalidLimitError(fmt.Sprintf("%s must be in %s", limitType, currency))
+		}
+
+		if amount.IsNegative() {
+			return Limits{}, newInvalidLimitError(fmt.Sprintf("%s can not be negative", limitType))
+		}
+	}
+
+	if maxCount < 0 || countWindow < 0 {
+		return Limits{}, newInvalidLimitError(fmt.Sprintf("%s can not be negative", LimitCountPerWindow))
+	}
+
+	if (maxCount == 0) != (countWindow == 0) {
+		return Limits{}, newInvalidLimitError(fmt.Sprintf("%s needs both a count and a window", LimitCountPerWindow))
+	}
+
+	return Limits{
+		currency:       currency,
+		perTransaction: perTransaction,
+		dailyTotal:     dailyTotal,
+		monthlyTotal:   monthlyTotal,
+		maxCount:       maxCount,
+		countWindow:    countWindow,
+	}, nil
+}
+
+// UnmarshalLimitsFromDatabase unmarshals Limits from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalLimitsFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalLimitsFromDatabase(
+	currency money.Currency,
+	perTransaction money.Money,
+	dailyTotal money.Money,
+	monthlyTotal money.Money,
+	maxCount int,
+	countWindow time.Duration,
+) Limits {
+	return Limits{
+		currency:       currency,
+		perTransaction: perTransaction,
+		dailyTotal:     dailyTotal,
+		monthlyTotal:   monthlyTotal,
+		maxCount:       maxCount,
+		countWindow:    countWindow,
+	}
+}
+
+func (l Limits) Currency() money.Currency {
+	return l.currency
+}
+
+// PerTransaction returns the max amount of a single disbursement, zero Money when not limited
+func (l Limits) PerTransaction() money.Money {
+	return l.perTransaction
+}
+
+// DailyTotal returns the max amount disbursed in a UTC day, zero Money when not limited
+func (l Limits) DailyTotal() money.Money {
+	return l.dailyTotal
+}
+
+// MonthlyTotal returns the max amount disbursed in a UTC month, zero Money when not limited
+func (l Limits) MonthlyTotal() money.Money {
+	return l.monthlyTotal
+}
+
+// MaxCount returns the max number of disbursements within CountWindow, 0 when not limited
+func (l Limits) MaxCount() int {
+	return l.maxCount
+}
+
+func (l Limits) CountWindow() time.Duration {
+	return l.countWindow
+}
+
+// IsLimited checks whether any rule is set, unlimited limits do not need the usage to be checked
+func (l Limits) IsLimited() bool {
+	return !l.perTransaction.IsZero() || !l.dailyTotal.IsZero() || !l.monthlyTotal.IsZero() || l.maxCount > 0
+}
+
+// Windows returns the periods the usage is counted over for disbursements made at now
+func (l Limits) Windows(now time.Time) LimitWindows {
+	now = now.UTC()
+
+	return LimitWindows{
+		DayStart:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		MonthStart: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		CountStart: now.Add(-l.countWindow),
+	}
+}
+
+// Check checks the new disbursement amounts on top of the usage, the amounts must be in the currency of the limits.
+// Every breached limit is listed in an api.ErrorInfo with the limit type as field.
+func (l Limits) Check(usage LimitUsage, amounts ...money.Money) error {
+	errInfos := make([]api.ErrorInfo, 0, 4)
+
+	total, err := money.New(0, l.currency)
+	if err != nil {
+		return err
+	}
+
+	for _, amount := range amounts {
+		if amount.Currency() != l.currency {
+			return errors.NewDpayError(
+				money.ErrCurrencyMismatch,
+				fmt.Sprintf("%s: limits are in %s, got %s", money.ErrCurrencyMismatch.Error(), l.currency, amount.Currency()),
+				errors.DpayInternalError,
+			)
+		}
+
+		if !l.perTransaction.IsZero() && amount.MinorUnits() > l.perTransaction.MinorUnits() {
+			errInfos = append(errInfos, api.ErrorInfo{
+				Field:   LimitPerTransaction.String(),
+				Message: fmt.Sprintf("%s is over the max %s of a disbursement", amount, l.perTransaction),
+			})
+		}
+
+		total, err = total.Add(amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	errInfos = append(errInfos, checkTotal(LimitDailyTotal, l.dailyTotal, usage.DailyTotal, total, "today")...)
+	errInfos = append(errInfos, checkTotal(LimitMonthlyTotal, l.monthlyTotal, usage.MonthlyTotal, total, "this month")...)
+
+	if l.maxCount > 0 && usage.Count+len(amounts) > l.maxCount {
+		errInfos = append(errInfos, api.ErrorInfo{
+			Field: LimitCountPerWindow.String(),
+			Message: fmt.Sprintf(
+				"%d disbursements in the last %s, max %d",
+				usage.Count+len(amounts), l.countWindow, l.maxCount,
+			),
+		})
+	}
+
+	if len(errInfos) == 0 {
+		return nil
+	}
+
+	breached := lo.Uniq(lo.Map(errInfos, func(info api.ErrorInfo, _ int) string { return info.Field }))
+
+	return errors.NewUnprocessableEntityError(
+		ErrLimitExceeded,
+		fmt.Sprintf("%s: %s", ErrLimitExceeded.Error(), strings.Join(breached, ", ")),
+		errors.DpayLimitExceeded,
+		errInfos...,
+	)
+}
+
+// checkTotal checks the used amount plus the new amount against the limit of the period
+func checkTotal(limitType LimitType, limit money.Money, used money.Money, amount money.Money, period string) []api.ErrorInfo {
+	if limit.IsZero() {
+		return nil
+	}
+
+	if used.MinorUnits()+amount.MinorUnits() <= limit.MinorUnits() {
+		return nil
+	}
+
+	return []api.ErrorInfo{{
+		Field: limitType.String(),
+		Message: fmt.Sprintf(
+			"%s on top of %s disbursed %s, max %s",
+			amount, used, period, limit,
+		),
+	}}
+}
+
+func newInvalidLimitError(message string) error {
+	return errors.NewIncorrectInputError(
+		ErrInvalidLimit,
+		fmt.Sprintf("%s: %s", ErrInvalidLimit.Error(), message),
+		errors.DpayInvalidRequest,
+	)
+}
+
+type LimitRepository interface {
+	// GetMerchantLimits returns ErrMerchantLimitNotFound as not found error
+	// when the merchant has no limits of the currency
+	GetMerchantLimits(ctx context.Context, merchantID string, currency money.Currency) (Limits, error)
+
+	// SetMerchantLimits replaces the limits of the merchant in the currency of the limits
+	SetMerchantLimits(ctx context.Context, merchantID string, limits Limits) error
+
+	// LockMerchantUsage holds the usage of the merchant until the transaction of ctx ends,
+	// so concurrent disbursements of the merchant can not pass a limit together
+	LockMerchantUsage(ctx context.Context, merchantID string) error
+
+	GetLimitUsage(
+		ctx context.Context,
+		merchantID string,
+		currency money.Currency,
+		windows LimitWindows,
+	) (LimitUsage, error)
+}
+
+// DefaultLimits provides the limits of the merchants without their own limits
+type DefaultLimits interface {
+	// GetDefaultLimits returns false when the currency has no default, it is then not limited
+	GetDefaultLimits(ctx context.Context, currency money.Currency) (Limits, bool, error)
+}
+
+// GetEffectiveLimits returns the limits of the merchant in the currency, a merchant without its own limits
+// gets the default limits as a whole. isDefault tells the default limits are returned.
+func GetEffectiveLimits(
+	ctx context.Context,
+	limitRepo LimitRepository,
+	defaultLimits DefaultLimits,
+	merchantID string,
+	currency money.Currency,
+) (limits Limits, isDefault bool, err error) {
+	limits, err = limitRepo.GetMerchantLimits(ctx, merchantID, currency)
+	if err == nil {
+		return limits, false, nil
+	}
+
+	if !stderrors.Is(err, ErrMerchantLimitNotFound) {
+		return Limits{}, false, err
+	}
+
+	limits, ok, err := defaultLimits.GetDefaultLimits(ctx, currency)
+	if err != nil {
+		return Limits{}, false, err
+	}
+
+	if !ok {
+		// a currency without default is not limited
+		return Limits{currency: currency}, true, nil
+	}
+
+	return limits, true, nil
+}
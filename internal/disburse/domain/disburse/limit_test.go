@@ -0,0 +1,180 @@
+package disburse
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+func TestLimitsCheck(t *testing.T) {
+	limits, err := NewLimits(
+		money.IDR,
+		idr(t, "1000000"),
+		idr(t, "5000000"),
+		idr(t, "20000000"),
+		3,
+		time.Hour,
+	)
+	if err != nil {
+		t.Fatalf("new limits: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		usage    LimitUsage
+		amounts  []money.Money
+		breached []LimitType
+		wantErr  error
+	}{
+		{
+			name:    "within every limit",
+			usage:   LimitUsage{DailyTotal: idr(t, "1000000"), MonthlyTotal: idr(t, "1000000"), Count: 1},
+			amounts: []money.Money{idr(t, "500000")},
+		},
+		{
+			name:    "on the limits",
+			usage:   LimitUsage{DailyTotal: idr(t, "4000000"), MonthlyTotal: idr(t, "19000000"), Count: 2},
+			amounts: []money.Money{idr(t, "1000000")},
+		},
+		{
+			name:     "over the per transaction limit",
+			amounts:  []money.Money{idr(t, "1000000.01")},
+			breached: []LimitType{LimitPerTransaction},
+			wantErr:  ErrLimitExceeded,
+		},
+		{
+			name:     "over the daily total",
+			usage:    LimitUsage{DailyTotal: idr(t, "4500000"), MonthlyTotal: idr(t, "4500000")},
+			amounts:  []money.Money{idr(t, "600000")},
+			breached: []LimitType{LimitDailyTotal},
+			wantErr:  ErrLimitExceeded,
+		},
+		{
+			name:     "over the monthly total",
+			usage:    LimitUsage{DailyTotal: idr(t, "0"), MonthlyTotal: idr(t, "19500000")},
+			amounts:  []money.Money{idr(t, "600000")},
+			breached: []LimitType{LimitMonthlyTotal},
+			wantErr:  ErrLimitExceeded,
+		},
+		{
+			name:     "over the count of the window",
+			usage:    LimitUsage{Count: 3},
+			amounts:  []money.Money{idr(t, "1000")},
+			breached: []LimitType{LimitCountPerWindow},
+			wantErr:  ErrLimitExceeded,
+		},
+		{
+			name:     "batch over the totals and the count together",
+			usage:    LimitUsage{DailyTotal: idr(t, "3000000"), MonthlyTotal: idr(t, "19000000"), Count: 1},
+			amounts:  []money.Money{idr(t, "800000"), idr(t, "800000"), idr(t, "800000")},
+			breached: []LimitType{LimitDailyTotal, LimitMonthlyTotal, LimitCountPerWindow},
+			wantErr:  ErrLimitExceeded,
+		},
+		{
+			name:    "amount in another currency",
+			amounts: []money.Money{usd(t, "10")},
+			wantErr: money.ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(tt.usage, tt.amounts...)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("Check error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(tt.breached) == 0 {
+				return
+			}
+
+			var dpayErr errors.DpayError
+			if !stderrors.As(err, &dpayErr) {
+				t.Fatalf("Check error = %T, want %T", err, dpayErr)
+			}
+
+			fields := make(map[string]bool, len(dpayErr.ErrorInfos()))
+			for _, info := range dpayErr.ErrorInfos() {
+				fields[info.Field] = true
+			}
+
+			if len(fields) != len(tt.breached) {
+				t.Errorf("breached limits = %v, want %v", fields, tt.breached)
+			}
+
+			for _, limitType := range tt.breached {
+				if !fields[limitType.String()] {
+					t.Errorf("breached limits = %v, want %s", fields, limitType)
+				}
+			}
+		})
+	}
+}
+
+func TestLimitsCheckUnlimited(t *testing.T) {
+	limits, err := NewLimits(money.IDR, money.Money{}, money.Money{}, money.Money{}, 0, 0)
+	if err != nil {
+		t.Fatalf("new limits: %v", err)
+	}
+
+	if limits.IsLimited() {
+		t.Errorf("IsLimited() = true, want false")
+	}
+
+	usage := LimitUsage{DailyTotal: idr(t, "1000000000"), MonthlyTotal: idr(t, "1000000000"), Count: 1000}
+	if err := limits.Check(usage, idr(t, "1000000000")); err != nil {
+		t.Errorf("Check error = %v, want nil", err)
+	}
+}
+
+func TestNewLimitsValidates(t *testing.T) {
+	tests := []struct {
+		name           string
+		currency       money.Currency
+		perTransaction money.Money
+		maxCount       int
+		countWindow    time.Duration
+	}{
+		{name: "unknown currency", currency: "XYZ"},
+		{name: "amount in another currency", currency: money.IDR, perTransaction: usd(t, "10")},
+		{name: "negative amount", currency: money.IDR, perTransaction: idr(t, "-10")},
+		{name: "negative count", currency: money.IDR, maxCount: -1, countWindow: time.Hour},
+		{name: "count without window", currency: money.IDR, maxCount: 3},
+		{name: "window without count", currency: money.IDR, countWindow: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLimits(tt.currency, tt.perTransaction, money.Money{}, money.Money{}, tt.maxCount, tt.countWindow)
+			if !stderrors.Is(err, ErrInvalidLimit) {
+				t.Errorf("NewLimits error = %v, want %v", err, ErrInvalidLimit)
+			}
+		})
+	}
+}
+
+func TestLimitsWindows(t *testing.T) {
+	limits, err := NewLimits(money.IDR, money.Money{}, money.Money{}, money.Money{}, 3, time.Hour)
+	if err != nil {
+		t.Fatalf("new limits: %v", err)
+	}
+
+	// 01:30 in Jakarta is still the previous UTC day
+	now := time.Date(2024, time.March, 1, 1, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+	windows := limits.Windows(now)
+
+	if expect := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC); !windows.DayStart.Equal(expect) {
+		t.Errorf("DayStart = %s, want %s", windows.DayStart, expect)
+	}
+
+	if expect := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC); !windows.MonthStart.Equal(expect) {
+		t.Errorf("MonthStart = %s, want %s", windows.MonthStart, expect)
+	}
+
+	if expect := now.Add(-time.Hour); !windows.CountStart.Equal(expect) {
+		t.Errorf("CountStart = %s, want %s", windows.CountStart, expect)
+	}
+}
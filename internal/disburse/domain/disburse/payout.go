@@ -0,0 +1,33 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPayoutRejected              = stderrors.New("payout was rejected by the provider")
+	ErrPayoutProviderNotConfigured = stderrors.New("payout provider is not configured")
+)
+
+// PayoutResult is the outcome of a payout reported by the provider
+type PayoutResult struct {
+	DisbursementID uuid.UUID
+
+	// Status is PROCESSING while the provider has not settled the payout, SUCCESS or FAILED once it has
+	Status        Status
+	FailureReason string
+}
+
+// PayoutCallback receives the payout result the provider reports after Payout returned
+type PayoutCallback func(ctx context.Context, result PayoutResult) error
+
+// PayoutProvider sends the disbursement money out to the bank.
+// The disbursement id is the payout reference, so submitting the same disbursement twice pays it only once.
+type PayoutProvider interface {
+	// Payout submits the disbursement. A payout the provider refuses returns ErrPayoutRejected,
+	// any other error leaves the outcome unknown. An accepted payout may settle later through the PayoutCallback.
+	Payout(ctx context.Context, disbursement *Disbursement) (PayoutResult, error)
+}
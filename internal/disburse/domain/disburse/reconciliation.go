@@ -0,0 +1,372 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptySettlementProvider       = stderrors.New("settlement provider can not be empty")
+	ErrUnsupportedSettlementProvider = stderrors.New("settlement report of the provider is not supported")
+	ErrInvalidSettlementPeriod       = stderrors.New("settlement period end must be after its start")
+	ErrInvalidSettlementLines        = stderrors.New("settlement report has invalid lines")
+	ErrReconciliationNotFound        = stderrors.New("reconciliation run not found")
+)
+
+// SettlementLine is a payout the provider reports as settled, the reference is the disbursement id
+type SettlementLine struct {
+	// Number is the line number in the report, it is used to point to the line of a discrepancy
+	Number    int
+	Reference string
+	Amount    money.Money
+}
+
+// SettlementParser reads the settlement report of a provider, every provider has its own report format
+type SettlementParser interface {
+	// Parse returns the lines of the report, a report with an invalid line is rejected as a whole
+	Parse(ctx context.Context, report io.Reader) ([]SettlementLine, error)
+}
+
+type DiscrepancyType string
+
+const (
+	// DiscrepancyMissingInSettlement is a disbursement paid out in the period that the report does not have
+	DiscrepancyMissingInSettlement = DiscrepancyType("MISSING_IN_SETTLEMENT")
+	// DiscrepancyUnknownReference is a line that does not refer to any disbursement
+	DiscrepancyUnknownReference = DiscrepancyType("UNKNOWN_REFERENCE")
+	// DiscrepancyDuplicated is a line that refers to a disbursement an earlier line already refers to
+	DiscrepancyDuplicated = DiscrepancyType("DUPLICATED")
+	// DiscrepancyAmountMismatch is a line that settles another amount than the disbursement
+	DiscrepancyAmountMismatch = DiscrepancyType("AMOUNT_MISMATCH")
+	// DiscrepancyStatusMismatch is a line that settles a disbursement which was never paid out
+	DiscrepancyStatusMismatch = DiscrepancyType("STATUS_MISMATCH")
+)
+
+func (t DiscrepancyType) String() string {
+	return string(t)
+}
+
+// Discrepancy is a difference between the settlement report and the disbursements.
+// A zero Money has no currency, it is used for the amount a discrepancy does not have,
+// e.g. the settled amount of a disbursement missing in the report.
+type Discrepancy struct {
+	discrepancyType DiscrepancyType
+	reference       string
+
+	// disbursementID and merchantID are empty for a line that does not refer to any disbursement
+	disbursementID uuid.UUID
+	merchantID     string
+
+	// lineNumber is 0 for a disbursement missing in the report
+	lineNumber     int
+	expectedAmount money.Money
+	settledAmount  money.Money
+	detail         string
+}
+
+// UnmarshalDiscrepancyFromDatabase unmarshals Discrepancy from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalDiscrepancyFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalDiscrepancyFromDatabase(
+	discrepancyType DiscrepancyType,
+	reference string,
+	disbursementID uuid.UUID,
+	merchantID string,
+	lineNumber int,
+	expectedAmount money.Money,
+	settledAmount money.Money,
+	detail string,
+) *Discrepancy {
+	return &Discrepancy{
+		discrepancyType: discrepancyType,
+		reference:       reference,
+		disbursementID:  disbursementID,
+		merchantID:      merchantID,
+		lineNumber:      lineNumber,
+		expectedAmount:  expectedAmount,
+		settledAmount:   settledAmount,
+		detail:          detail,
+	}
+}
+
+func (d Discrepancy) Type() DiscrepancyType {
+	return d.discrepancyType
+}
+
+func (d Discrepancy) Reference() string {
+	return d.reference
+}
+
+func (d Discrepancy) DisbursementID() uuid.UUID {
+	return d.disbursementID
+}
+
+func (d Discrepancy) MerchantID() string {
+	return d.merchantID
+}
+
+func (d Discrepancy) LineNumber() int {
+	return d.lineNumber
+}
+
+// ExpectedAmount returns the amount of the disbursement, zero Money when the line refers to no disbursement
+func (d Discrepancy) ExpectedAmount() money.Money {
+	return d.expectedAmount
+}
+
+// SettledAmount returns the amount of the line, zero Money for a disbursement missing in the report
+func (d Discrepancy) SettledAmount() money.Money {
+	return d.settledAmount
+}
+
+func (d Discrepancy) Detail() string {
+	return d.detail
+}
+
+// ReconciliationRun is a settlement report of a provider checked against the disbursements paid out in its period
+type ReconciliationRun struct {
+	id          uuid.UUID
+	provider    string
+	fileName    string
+	periodStart time.Time
+	periodEnd   time.Time
+
+	lineCount        int
+	matchedCount     int
+	discrepancyCount int
+
+	createdAt time.Time
+}
+
+// Reconcile matches the settlement lines to the disbursements by reference and amount.
+// referenced holds the disbursements the lines refer to and settled the disbursements paid out
+// in [periodStart, periodEnd), a settled disbursement no line refers to is missing in the report.
+func Reconcile(
+	id uuid.UUID,
+	provider string,
+	fileName string,
+	periodStart time.Time,
+	periodEnd time.Time,
+	lines []SettlementLine,
+	referenced []*Disbursement,
+	settled []*Disbursement,
+) (*ReconciliationRun, []*Discrepancy, error) {
+	if id == uuid.Nil {
+		return nil, nil, errors.NewIncorrectInputError(
+			ErrEmptyDisbursementID,
+			ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if provider == "" {
+		return nil, nil, errors.NewIncorrectInputError(
+			ErrEmptySettlementProvider,
+			ErrEmptySettlementProvider.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if !periodEnd.After(periodStart) {
+		return nil, nil, errors.NewIncorrectInputError(
+			ErrInvalidSettlementPeriod,
+			ErrInvalidSettlementPeriod.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	var (
+		disbursements = make(map[uuid.UUID]*Disbursement, len(referenced)+len(settled))
+		firstLines    = make(map[string]int, len(lines))
+		settledLines  = make(map[uuid.UUID]bool, len(lines))
+		discrepancies []*Discrepancy
+		matched       int
+	)
+
+	for _, d := range append(referenced, settled...) {
+		disbursements[d.id] = d
+	}
+
+	for _, line := range lines {
+		var disbursement *Disbursement
+		if id, err := uuid.Parse(line.Reference); err == nil {
+			disbursement = disbursements[id]
+		}
+
+		if first, ok := firstLines[line.Reference]; ok {
+			discrepancies = append(discrepancies, newLineDiscrepancy(
+				DiscrepancyDuplicated,
+				line,
+				disbursement,
+				fmt.Sprintf("reference is already settled on line %d", first),
+			))
+			continue
+		}
+
+		firstLines[line.Reference] = line.Number
+
+		if disbursement == nil {
+			discrepancies = append(discrepancies, newLineDiscrepancy(
+				DiscrepancyUnknownReference,
+				line,
+				nil,
+				"reference does not refer to any disbursement",
+			))
+			continue
+		}
+
+		settledLines[disbursement.id] = true
+
+		switch {
+		case !disbursement.status.IsPaidOut():
+			discrepancies = append(discrepancies, newLineDiscrepancy(
+				DiscrepancyStatusMismatch,
+				line,
+				disbursement,
+				fmt.Sprintf("disbursement is %s", disbursement.status),
+			))
+		case !disbursement.amount.Equal(line.Amount):
+			discrepancies = append(discrepancies, newLineDiscrepancy(
+				DiscrepancyAmountMismatch,
+				line,
+				disbursement,
+				fmt.Sprintf("settled %s, disbursed %s", line.Amount, disbursement.amount),
+			))
+		default:
+			matched++
+		}
+	}
+
+	for _, disbursement := range settled {
+		if settledLines[disbursement.id] {
+			continue
+		}
+
+		discrepancies = append(discrepancies, &Discrepancy{
+			discrepancyType: DiscrepancyMissingInSettlement,
+			reference:       disbursement.id.String(),
+			disbursementID:  disbursement.id,
+			merchantID:      disbursement.merchantID,
+			expectedAmount:  disbursement.amount,
+			detail:          fmt.Sprintf("disbursement was paid out at %s", disbursement.completedAt.Format(time.RFC3339)),
+		})
+	}
+
+	return &ReconciliationRun{
+		id:               id,
+		provider:         provider,
+		fileName:         fileName,
+		periodStart:      periodStart.UTC(),
+		periodEnd:        periodEnd.UTC(),
+		lineCount:        len(lines),
+		matchedCount:     matched,
+		discrepancyCount: len(discrepancies),
+		createdAt:        time.Now().UTC(),
+	}, discrepancies, nil
+}
+
+func newLineDiscrepancy(
+	discrepancyType DiscrepancyType,
+	line SettlementLine,
+	disbursement *Disbursement,
+	detail string,
+) *Discrepancy {
+	discrepancy := &Discrepancy{
+		discrepancyType: discrepancyType,
+		reference:       line.Reference,
+		lineNumber:      line.Number,
+		settledAmount:   line.Amount,
+		detail:          detail,
+	}
+
+	if disbursement != nil {
+		discrepancy.disbursementID = disbursement.id
+		discrepancy.merchantID = disbursement.merchantID
+		discrepancy.expectedAmount = disbursement.amount
+	}
+
+	return discrepancy
+}
+
+// UnmarshalReconciliationRunFromDatabase unmarshals ReconciliationRun from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalReconciliationRunFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalReconciliationRunFromDatabase(
+	id uuid.UUID,
+	provider string,
+	fileName string,
+	periodStart time.Time,
+	periodEnd time.Time,
+	lineCount int,
+	matchedCount int,
+	discrepancyCount int,
+	createdAt time.Time,
+) *ReconciliationRun {
+	return &ReconciliationRun{
+		id:               id,
+		provider:         provider,
+		fileName:         fileName,
+		periodStart:      periodStart,
+		periodEnd:        periodEnd,
+		lineCount:        lineCount,
+		matchedCount:     matchedCount,
+		discrepancyCount: discrepancyCount,
+		createdAt:        createdAt,
+	}
+}
+
+func (r ReconciliationRun) ID() uuid.UUID {
+	return r.id
+}
+
+func (r ReconciliationRun) Provider() string {
+	return r.provider
+}
+
+func (r ReconciliationRun) FileName() string {
+	return r.fileName
+}
+
+func (r ReconciliationRun) PeriodStart() time.Time {
+	return r.periodStart
+}
+
+func (r ReconciliationRun) PeriodEnd() time.Time {
+	return r.periodEnd
+}
+
+func (r ReconciliationRun) LineCount() int {
+	return r.lineCount
+}
+
+// MatchedCount returns the number of lines that settle a paid out disbursement with the same amount
+func (r ReconciliationRun) MatchedCount() int {
+	return r.matchedCount
+}
+
+func (r ReconciliationRun) DiscrepancyCount() int {
+	return r.discrepancyCount
+}
+
+func (r ReconciliationRun) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+type ReconciliationRepository interface {
+	// CreateRun stores the run with all its discrepancies at once
+	CreateRun(ctx context.Context, run *ReconciliationRun, discrepancies []*Discrepancy) error
+	GetRun(ctx context.Context, id uuid.UUID) (*ReconciliationRun, error)
+
+	// ListDiscrepancies returns the discrepancies of the run in the order they were found,
+	// a non empty merchantID limits them to the disbursements of the merchant
+	ListDiscrepancies(ctx context.Context, runID uuid.UUID, merchantID string) ([]*Discrepancy, error)
+}
@@ -1,7 +1,70 @@
 package disburse
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type DisburseRepository interface {
-	CreateDisbursement(ctx context.Context, disbursement Disbursement) error
+	// CreateDisbursement returns ErrDisbursementAlreadyExists when the id or the idempotency key is already stored
+	CreateDisbursement(ctx context.Context, disbursement *Disbursement) error
+
+	// UpdateDisbursement loads the disbursement, applies updateFn and saves the result in one transaction,
+	// what updateFn writes with its ctx is saved together with the disbursement. updateFn returning
+	// a nil disbursement saves nothing. The save is rejected with ErrDisbursementConflict as conflict error
+	// when the disbursement was saved by someone else since it was loaded, the whole update can be retried.
+	// updateFn must not call anything outside the transaction, e.g. the merchant balance, since a rejected save
+	// can not undo it, such calls are made after UpdateDisbursement returns.
+	UpdateDisbursement(
+		ctx context.Context,
+		id uuid.UUID,
+		updateFn func(ctx context.Context, disbursement *Disbursement) (*Disbursement, error),
+	) error
+	GetDisbursement(ctx context.Context, id uuid.UUID) (*Disbursement, error)
+	GetDisbursementByIdempotencyKey(ctx context.Context, merchantID string, idempotencyKey string) (*Disbursement, error)
+	ListDisbursements(ctx context.Context, filter ListFilter) ([]*Disbursement, error)
+
+	// GetDisbursementsByIDs returns the stored disbursements of the ids, an unknown id is skipped
+	GetDisbursementsByIDs(ctx context.Context, ids []uuid.UUID) ([]*Disbursement, error)
+
+	// ListExpiredApprovals returns up to limit disbursements still awaiting approval with the deadline passed at now
+	ListExpiredApprovals(ctx context.Context, now time.Time, limit int) ([]*Disbursement, error)
+
+	// ListDisbursementsToDispatch returns up to limit disbursements in PENDING, oldest first
+	ListDisbursementsToDispatch(ctx context.Context, limit int) ([]*Disbursement, error)
+
+	// ClaimPayout claims the payout of the disbursement until claimUntil, it must run in the transaction
+	// moving the disbursement into PROCESSING so a disbursement is never left in PROCESSING unclaimed
+	ClaimPayout(ctx context.Context, id uuid.UUID, claimUntil time.Time) error
+
+	// ClaimStalePayouts claims again until claimUntil up to limit disbursements still in PROCESSING
+	// with their payout claim expired at now, oldest claim first
+	ClaimStalePayouts(ctx context.Context, now time.Time, claimUntil time.Time, limit int) ([]*Disbursement, error)
+
+	// ReleasePayoutClaim releases the payout claim once the payout provider has the payout
+	ReleasePayoutClaim(ctx context.Context, id uuid.UUID) error
+
+	// ListPaidOutDisbursements returns the disbursements paid out with completed_at in [from, to)
+	ListPaidOutDisbursements(ctx context.Context, from time.Time, to time.Time) ([]*Disbursement, error)
+
+	// CreateReversal stores the reversal of a disbursement, it returns ErrDisbursementAlreadyReversed
+	// when the disbursement already has one
+	CreateReversal(ctx context.Context, reversal *Reversal) error
+	GetReversal(ctx context.Context, disbursementID uuid.UUID) (*Reversal, error)
+
+	// CreateBatch stores the batch with all its items at once,
+	// it returns ErrBatchAlreadyExists when the batch id is already stored
+	CreateBatch(ctx context.Context, batch *Batch, items []*Disbursement) error
+	GetBatch(ctx context.Context, id uuid.UUID) (*Batch, error)
+	CountBatchItemsByStatus(ctx context.Context, batchID uuid.UUID) (map[Status]int, error)
+
+	// ClaimBatchReservation claims the reservation of the balance of a RESERVING batch until claimUntil, false when
+	// the batch is no longer RESERVING or its claim is held by another request at now
+	ClaimBatchReservation(ctx context.Context, id uuid.UUID, now time.Time, claimUntil time.Time) (bool, error)
+
+	// CompleteBatchReservation moves a RESERVING batch into the status and releases its reservation claim,
+	// BatchStatusReserving only releases the claim
+	CompleteBatchReservation(ctx context.Context, id uuid.UUID, status BatchStatus) error
 }
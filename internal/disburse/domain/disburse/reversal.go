@@ -0,0 +1,84 @@
+package disburse
+
+import (
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+var (
+	ErrReversalWindowExpired       = stderrors.New("disbursement can no longer be reversed")
+	ErrDisbursementAlreadyReversed = stderrors.New("disbursement is already reversed")
+)
+
+// ReversalWindow is how long after its completion a successful disbursement may still be reversed
+const ReversalWindow = 90 * 24 * time.Hour
+
+// reversalNamespace is the UUID namespace to derive the reversal id from the disbursement id
+var reversalNamespace = uuid.MustParse("b3f4c7e2-5a91-4d0e-8c36-71e2a9d84f15")
+
+// Reversal compensates the payout of a reversed disbursement, the amount is credited back to the merchant
+type Reversal struct {
+	id             uuid.UUID
+	disbursementID uuid.UUID
+	merchantID     string
+	amount         money.Money
+	reason         string
+	createdAt      time.Time
+}
+
+// NewReversalID returns the id of the reversal of the disbursement. A disbursement is reversed at most once,
+// so a retried reversal gets the same id and the merchant balance is credited only once.
+func NewReversalID(disbursementID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(reversalNamespace, disbursementID[:])
+}
+
+// UnmarshalReversalFromDatabase unmarshals Reversal from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalReversalFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalReversalFromDatabase(
+	id uuid.UUID,
+	disbursementID uuid.UUID,
+	merchantID string,
+	amount money.Money,
+	reason string,
+	createdAt time.Time,
+) *Reversal {
+	return &Reversal{
+		id:             id,
+		disbursementID: disbursementID,
+		merchantID:     merchantID,
+		amount:         amount,
+		reason:         reason,
+		createdAt:      createdAt,
+	}
+}
+
+func (r Reversal) ID() uuid.UUID {
+	return r.id
+}
+
+func (r Reversal) DisbursementID() uuid.UUID {
+	return r.disbursementID
+}
+
+func (r Reversal) MerchantID() string {
+	return r.merchantID
+}
+
+// Amount returns the amount credited back to the merchant, the full amount of the disbursement without its fee
+// in the funding currency
+func (r Reversal) Amount() money.Money {
+	return r.amount
+}
+
+func (r Reversal) Reason() string {
+	return r.reason
+}
+
+func (r Reversal) CreatedAt() time.Time {
+	return r.createdAt
+}
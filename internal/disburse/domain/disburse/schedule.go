@@ -0,0 +1,295 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	ErrInvalidRecurrence   = stderrors.New("invalid recurrence rule")
+	ErrInvalidScheduleTime = stderrors.New("first run must be in the future")
+	ErrInvalidScheduleEnd  = stderrors.New("end must be after the first run")
+	ErrScheduleNotFound    = stderrors.New("scheduled disbursement not found")
+	ErrScheduleNotActive   = stderrors.New("scheduled disbursement is not active")
+)
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    = ScheduleStatus("ACTIVE")
+	ScheduleStatusCompleted = ScheduleStatus("COMPLETED")
+	ScheduleStatusCancelled = ScheduleStatus("CANCELLED")
+)
+
+func (s ScheduleStatus) String() string {
+	return string(s)
+}
+
+// recurrenceParser accepts the standard 5 field cron rule and the descriptors @daily, @weekly and @monthly,
+// the rule is evaluated in UTC unless it starts with CRON_TZ=<zone>
+var recurrenceParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ScheduledDisbursement creates a disbursement of the merchant at nextRunAt,
+// a recurring one keeps creating them on its recurrence rule until endAt
+type ScheduledDisbursement struct {
+	id         uuid.UUID
+	merchantID string
+	amount     money.Money
+
+	// recurrence is the cron rule of the runs after the first, empty runs only once
+	recurrence string
+	endAt      time.Time
+
+	status    ScheduleStatus
+	nextRunAt time.Time
+	lastRunAt time.Time
+	runCount  int
+
+	// lastFailureReason tells why the last run did not create a disbursement, empty when it did
+	lastFailureReason string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewScheduledDisbursement creates an active schedule with its first run at firstRunAt,
+// a zero endAt keeps a recurring schedule running until it is cancelled
+func NewScheduledDisbursement(
+	id uuid.UUID,
+	merchantID string,
+	amount money.Money,
+	firstRunAt time.Time,
+	recurrence string,
+	endAt time.Time,
+) (*ScheduledDisbursement, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyDisbursementID,
+			ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if merchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyMerchantID,
+			ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if !amount.IsPositive() {
+		return nil, errors.NewIncorrectInputError(
+			ErrInvalidAmount,
+			ErrInvalidAmount.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	now := time.Now().UTC()
+
+	if !firstRunAt.After(now) {
+		return nil, errors.NewIncorrectInputError(
+			ErrInvalidScheduleTime,
+			ErrInvalidScheduleTime.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if recurrence != "" {
+		_, err := recurrenceParser.Parse(recurrence)
+		if err != nil {
+			return nil, errors.NewIncorrectInputError(
+				ErrInvalidRecurrence,
+				fmt.Sprintf("%s: %s", ErrInvalidRecurrence.Error(), err.Error()),
+				errors.DpayInvalidRequest,
+			)
+		}
+	}
+
+	if !endAt.IsZero() && !endAt.After(firstRunAt) {
+		return nil, errors.NewIncorrectInputError(
+			ErrInvalidScheduleEnd,
+			ErrInvalidScheduleEnd.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return &ScheduledDisbursement{
+		id:         id,
+		merchantID: merchantID,
+		amount:     amount,
+		recurrence: recurrence,
+		endAt:      endAt.UTC(),
+		status:     ScheduleStatusActive,
+		nextRunAt:  firstRunAt.UTC(),
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// UnmarshalScheduledDisbursementFromDatabase unmarshals ScheduledDisbursement from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalScheduledDisbursementFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalScheduledDisbursementFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	amount money.Money,
+	recurrence string,
+	endAt time.Time,
+	status ScheduleStatus,
+	nextRunAt time.Time,
+	lastRunAt time.Time,
+	runCount int,
+	lastFailureReason string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *ScheduledDisbursement {
+	return &ScheduledDisbursement{
+		id:                id,
+		merchantID:        merchantID,
+		amount:            amount,
+		recurrence:        recurrence,
+		endAt:             endAt,
+		status:            status,
+		nextRunAt:         nextRunAt,
+		lastRunAt:         lastRunAt,
+		runCount:          runCount,
+		lastFailureReason: lastFailureReason,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+	}
+}
+
+func (s ScheduledDisbursement) ID() uuid.UUID {
+	return s.id
+}
+
+func (s ScheduledDisbursement) MerchantID() string {
+	return s.merchantID
+}
+
+func (s ScheduledDisbursement) Amount() money.Money {
+	return s.amount
+}
+
+// Recurrence returns the cron rule of the runs after the first, empty for a schedule that runs once
+func (s ScheduledDisbursement) Recurrence() string {
+	return s.recurrence
+}
+
+// EndAt returns the time after which a recurring schedule stops, zero when it runs until cancelled
+func (s ScheduledDisbursement) EndAt() time.Time {
+	return s.endAt
+}
+
+func (s ScheduledDisbursement) Status() ScheduleStatus {
+	return s.status
+}
+
+func (s ScheduledDisbursement) NextRunAt() time.Time {
+	return s.nextRunAt
+}
+
+func (s ScheduledDisbursement) LastRunAt() time.Time {
+	return s.lastRunAt
+}
+
+func (s ScheduledDisbursement) RunCount() int {
+	return s.runCount
+}
+
+func (s ScheduledDisbursement) LastFailureReason() string {
+	return s.lastFailureReason
+}
+
+func (s ScheduledDisbursement) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s ScheduledDisbursement) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// RunIdempotencyKey returns the idempotency key of the disbursement of the next run,
+// a run retried after a crash gets the same key so it is not paid out twice
+func (s ScheduledDisbursement) RunIdempotencyKey() string {
+	return fmt.Sprintf("schedule:%s:%d", s.id, s.runCount+1)
+}
+
+// Advance records the run at nextRunAt and moves to the next run after now, the runs missed while
+// the scheduler was down are skipped so a long outage does not pay out a pile of runs at once.
+// failureReason is empty when the run created its disbursement.
+func (s *ScheduledDisbursement) Advance(now time.Time, failureReason string) error {
+	if s.status != ScheduleStatusActive {
+		return errors.NewUnprocessableEntityError(
+			ErrScheduleNotActive,
+			ErrScheduleNotActive.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	s.runCount++
+	s.lastRunAt = s.nextRunAt
+	s.lastFailureReason = failureReason
+	s.updatedAt = now.UTC()
+
+	if s.recurrence == "" {
+		s.status = ScheduleStatusCompleted
+		return nil
+	}
+
+	schedule, err := recurrenceParser.Parse(s.recurrence)
+	if err != nil {
+		return errors.NewDpayError(
+			err,
+			fmt.Sprintf("%s: %s", ErrInvalidRecurrence.Error(), err.Error()),
+			errors.DpayInternalError,
+		)
+	}
+
+	next := schedule.Next(now).UTC()
+	if next.IsZero() || (!s.endAt.IsZero() && next.After(s.endAt)) {
+		s.status = ScheduleStatusCompleted
+		return nil
+	}
+
+	s.nextRunAt = next
+
+	return nil
+}
+
+func (s *ScheduledDisbursement) Cancel() error {
+	if s.status != ScheduleStatusActive {
+		return errors.NewUnprocessableEntityError(
+			ErrScheduleNotActive,
+			ErrScheduleNotActive.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	s.status = ScheduleStatusCancelled
+	s.updatedAt = time.Now().UTC()
+
+	return nil
+}
+
+type ScheduleRepository interface {
+	CreateSchedule(ctx context.Context, schedule *ScheduledDisbursement) error
+	GetSchedule(ctx context.Context, id uuid.UUID) (*ScheduledDisbursement, error)
+
+	// ClaimDueSchedules claims up to limit active schedules due at now until claimUntil,
+	// a claimed schedule is skipped by the other schedulers until UpdateSchedule or the claim expires
+	ClaimDueSchedules(ctx context.Context, now time.Time, claimUntil time.Time, limit int) ([]*ScheduledDisbursement, error)
+
+	// UpdateSchedule saves the schedule and releases its claim
+	UpdateSchedule(ctx context.Context, schedule *ScheduledDisbursement) error
+}
@@ -0,0 +1,68 @@
+package disburse
+
+import "github.com/samber/lo"
+
+type Status string
+
+const (
+	StatusAwaitingApproval = Status("AWAITING_APPROVAL")
+	StatusPending          = Status("PENDING")
+	StatusProcessing       = Status("PROCESSING")
+	StatusSuccess          = Status("SUCCESS")
+	StatusFailed           = Status("FAILED")
+	StatusCancelled        = Status("CANCELLED")
+	StatusReversed         = Status("REVERSED")
+	StatusRejected         = Status("REJECTED")
+)
+
+// allowedTransitions maps every status to the statuses it may move to,
+// a status without entry is a final status
+var allowedTransitions = map[Status][]Status{
+	StatusAwaitingApproval: {StatusPending, StatusRejected, StatusCancelled},
+	StatusPending:          {StatusProcessing, StatusCancelled},
+	StatusProcessing:       {StatusSuccess, StatusFailed},
+	StatusSuccess:          {StatusReversed},
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsValid checks whether the status is one of the known disbursement statuses
+func (s Status) IsValid() bool {
+	return lo.Contains(
+		[]Status{
+			StatusAwaitingApproval,
+			StatusPending,
+			StatusProcessing,
+			StatusSuccess,
+			StatusFailed,
+			StatusCancelled,
+			StatusReversed,
+			StatusRejected,
+		},
+		s,
+	)
+}
+
+// IsFinal checks whether the status can no longer move to another status
+func (s Status) IsFinal() bool {
+	return len(allowedTransitions[s]) == 0
+}
+
+// CanTransitionTo checks whether moving from s to target is a legal move
+func (s Status) CanTransitionTo(target Status) bool {
+	return lo.Contains(allowedTransitions[s], target)
+}
+
+// ReleasesBalance checks whether the disbursement gives the reserved merchant balance back in this status,
+// it is true for a disbursement that ends without paying out
+func (s Status) ReleasesBalance() bool {
+	return s == StatusFailed || s == StatusCancelled || s == StatusRejected
+}
+
+// IsPaidOut checks whether the money of the disbursement left through the payout provider,
+// a reversed disbursement was paid out before it was reversed
+func (s Status) IsPaidOut() bool {
+	return s == StatusSuccess || s == StatusReversed
+}
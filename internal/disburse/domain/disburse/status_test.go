@@ -0,0 +1,139 @@
+package disburse
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   Status
+		to     Status
+		expect bool
+	}{
+		{name: "awaiting approval to pending", from: StatusAwaitingApproval, to: StatusPending, expect: true},
+		{name: "awaiting approval to rejected", from: StatusAwaitingApproval, to: StatusRejected, expect: true},
+		{name: "awaiting approval to cancelled", from: StatusAwaitingApproval, to: StatusCancelled, expect: true},
+		{name: "awaiting approval to processing", from: StatusAwaitingApproval, to: StatusProcessing, expect: false},
+		{name: "pending to processing", from: StatusPending, to: StatusProcessing, expect: true},
+		{name: "pending to cancelled", from: StatusPending, to: StatusCancelled, expect: true},
+		{name: "pending to success", from: StatusPending, to: StatusSuccess, expect: false},
+		{name: "processing to success", from: StatusProcessing, to: StatusSuccess, expect: true},
+		{name: "processing to failed", from: StatusProcessing, to: StatusFailed, expect: true},
+		{name: "processing to cancelled", from: StatusProcessing, to: StatusCancelled, expect: false},
+		{name: "success to reversed", from: StatusSuccess, to: StatusReversed, expect: true},
+		{name: "success to failed", from: StatusSuccess, to: StatusFailed, expect: false},
+		{name: "failed to processing", from: StatusFailed, to: StatusProcessing, expect: false},
+		{name: "cancelled to pending", from: StatusCancelled, to: StatusPending, expect: false},
+		{name: "reversed to success", from: StatusReversed, to: StatusSuccess, expect: false},
+		{name: "rejected to pending", from: StatusRejected, to: StatusPending, expect: false},
+		{name: "same status", from: StatusPending, to: StatusPending, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.expect {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestStatusIsFinal(t *testing.T) {
+	tests := []struct {
+		status Status
+		expect bool
+	}{
+		{status: StatusAwaitingApproval, expect: false},
+		{status: StatusPending, expect: false},
+		{status: StatusProcessing, expect: false},
+		{status: StatusSuccess, expect: false},
+		{status: StatusFailed, expect: true},
+		{status: StatusCancelled, expect: true},
+		{status: StatusReversed, expect: true},
+		{status: StatusRejected, expect: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if got := tt.status.IsFinal(); got != tt.expect {
+				t.Errorf("%s.IsFinal() = %v, want %v", tt.status, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestDisbursementTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []step
+		expect  Status
+		wantErr error
+	}{
+		{
+			name:   "paid out",
+			steps:  []step{startProcessing, markSuccess},
+			expect: StatusSuccess,
+		},
+		{
+			name:   "failed payout",
+			steps:  []step{startProcessing, markFailed},
+			expect: StatusFailed,
+		},
+		{
+			name:   "cancelled before processing",
+			steps:  []step{cancel},
+			expect: StatusCancelled,
+		},
+		{
+			name:    "success without processing",
+			steps:   []step{markSuccess},
+			expect:  StatusPending,
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:    "cancelled while processing",
+			steps:   []step{startProcessing, cancel},
+			expect:  StatusProcessing,
+			wantErr: ErrDisbursementInPayout,
+		},
+		{
+			name:    "processed twice",
+			steps:   []step{startProcessing, startProcessing},
+			expect:  StatusProcessing,
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:    "failed after success",
+			steps:   []step{startProcessing, markSuccess, markFailed},
+			expect:  StatusSuccess,
+			wantErr: ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDisbursement(t)
+
+			var err error
+			for _, step := range tt.steps {
+				if err = step(d); err != nil {
+					break
+				}
+			}
+
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			if d.Status() != tt.expect {
+				t.Errorf("status = %s, want %s", d.Status(), tt.expect)
+			}
+
+			if d.Status().IsFinal() && d.CompletedAt().IsZero() {
+				t.Errorf("completed at is zero for final status %s", d.Status())
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package disburse
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptyUpload              = stderrors.New("upload must have at least one row")
+	ErrUploadTooLarge           = stderrors.New("upload has too many rows")
+	ErrUploadNotFound           = stderrors.New("disbursement upload not found")
+	ErrEmptyUploadReference     = stderrors.New("reference can not be empty")
+	ErrUploadReferenceTooLong   = stderrors.New("reference is too long")
+	ErrDuplicateUploadReference = stderrors.New("reference is used by another row of the upload")
+)
+
+// MaxUploadRows is the most rows a single upload may carry
+const MaxUploadRows = 10000
+
+// uploadIdempotencyKeyPrefix scopes the idempotency keys of the rows to their upload,
+// so a reference never collides with an idempotency key the merchant sends to the API
+const uploadIdempotencyKeyPrefix = "upload:"
+
+// maxUploadReferenceLength leaves room for the upload scope in the disbursement idempotency key,
+// the scope is the prefix and the 36 characters upload id followed by a colon
+const maxUploadReferenceLength = maxIdempotencyKeyLength - len(uploadIdempotencyKeyPrefix) - 36 - 1
+
+type UploadStatus string
+
+const (
+	UploadStatusProcessing = UploadStatus("PROCESSING")
+	UploadStatusCompleted  = UploadStatus("COMPLETED")
+)
+
+func (s UploadStatus) String() string {
+	return string(s)
+}
+
+type UploadRowStatus string
+
+const (
+	UploadRowStatusPending   = UploadRowStatus("PENDING")
+	UploadRowStatusDisbursed = UploadRowStatus("DISBURSED")
+	UploadRowStatusFailed    = UploadRowStatus("FAILED")
+)
+
+func (s UploadRowStatus) String() string {
+	return string(s)
+}
+
+// Upload is a file of disbursements uploaded by the merchant, every row is disbursed on its own
+type Upload struct {
+	id         uuid.UUID
+	merchantID string
+	createdBy  string
+	fileName   string
+	rowCount   int
+	status     UploadStatus
+
+	createdAt   time.Time
+	updatedAt   time.Time
+	completedAt time.Time
+}
+
+// UploadRow is a row of the uploaded file, the reference scoped to the upload is the idempotency key
+// of its disbursement so a row retried after a failure is not paid out twice
+type UploadRow struct {
+	number      int
+	reference   string
+	amount      money.Money
+	bankAccount BankAccount
+
+	status         UploadRowStatus
+	disbursementID uuid.UUID
+	failureReason  string
+}
+
+// NewUpload creates an upload of the merchant with its rows, the references of the pending rows must be unique
+// within the upload. The invalid rows are kept failed so the uploader gets an error per row.
+// createdBy is the user uploading the file, empty is the merchant itself.
+func NewUpload(
+	id uuid.UUID,
+	merchantID string,
+	createdBy string,
+	fileName string,
+	rows []*UploadRow,
+) (*Upload, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyDisbursementID,
+			ErrEmptyDisbursementID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if merchantID == "" {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyMerchantID,
+			ErrEmptyMerchantID.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.NewIncorrectInputError(
+			ErrEmptyUpload,
+			ErrEmptyUpload.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(rows) > MaxUploadRows {
+		return nil, errors.NewIncorrectInputError(
+			ErrUploadTooLarge,
+			fmt.Sprintf("%s, max %d rows", ErrUploadTooLarge.Error(), MaxUploadRows),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	references := make(map[string]int, len(rows))
+	for _, row := range rows {
+		if row.status != UploadRowStatusPending {
+			continue
+		}
+
+		if number, ok := references[row.reference]; ok {
+			return nil, errors.NewIncorrectInputError(
+				ErrDuplicateUploadReference,
+				fmt.Sprintf("%s: row %d and row %d", ErrDuplicateUploadReference.Error(), number, row.number),
+				errors.DpayInvalidRequest,
+			)
+		}
+
+		references[row.reference] = row.number
+	}
+
+	now := time.Now().UTC()
+
+	return &Upload{
+		id:         id,
+		merchantID: merchantID,
+		createdBy:  createdBy,
+		fileName:   fileName,
+		rowCount:   len(rows),
+		status:     UploadStatusProcessing,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// NewUploadRow creates a pending row, number is the row number in the file to point the uploader to it
+func NewUploadRow(number int, reference string, amount money.Money, bankAccount BankAccount) (*UploadRow, error) {
+	err := ValidateUploadReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if !amount.IsPositive() {
+		return nil, errors.NewIncorrectInputError(
+			ErrInvalidAmount,
+			ErrInvalidAmount.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return &UploadRow{
+		number:      number,
+		reference:   reference,
+		amount:      amount,
+		bankAccount: bankAccount,
+		status:      UploadRowStatusPending,
+	}, nil
+}
+
+// NewInvalidUploadRow creates a failed row for a row of the file that could not be read as a disbursement,
+// the reference is kept only when it is usable so the uploader can still find the row by it
+func NewInvalidUploadRow(number int, reference string, reason string) *UploadRow {
+	if ValidateUploadReference(reference) != nil {
+		reference = ""
+	}
+
+	return &UploadRow{
+		number:        number,
+		reference:     reference,
+		status:        UploadRowStatusFailed,
+		failureReason: reason,
+	}
+}
+
+// ValidateUploadReference checks the reference can be used as the idempotency key of the row disbursement
+func ValidateUploadReference(reference string) error {
+	if reference == "" {
+		return errors.NewIncorrectInputError(
+			ErrEmptyUploadReference,
+			ErrEmptyUploadReference.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if len(reference) > maxUploadReferenceLength {
+		return errors.NewIncorrectInputError(
+			ErrUploadReferenceTooLong,
+			fmt.Sprintf("%s, max %d characters", ErrUploadReferenceTooLong.Error(), maxUploadReferenceLength),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return nil
+}
+
+// UnmarshalUploadFromDatabase unmarshals Upload from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalUploadFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalUploadFromDatabase(
+	id uuid.UUID,
+	merchantID string,
+	createdBy string,
+	fileName string,
+	rowCount int,
+	status UploadStatus,
+	createdAt time.Time,
+	updatedAt time.Time,
+	completedAt time.Time,
+) *Upload {
+	return &Upload{
+		id:          id,
+		merchantID:  merchantID,
+		createdBy:   createdBy,
+		fileName:    fileName,
+		rowCount:    rowCount,
+		status:      status,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		completedAt: completedAt,
+	}
+}
+
+// UnmarshalUploadRowFromDatabase unmarshals UploadRow from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalUploadRowFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalUploadRowFromDatabase(
+	number int,
+	reference string,
+	amount money.Money,
+	bankAccount BankAccount,
+	status UploadRowStatus,
+	disbursementID uuid.UUID,
+	failureReason string,
+) *UploadRow {
+	return &UploadRow{
+		number:         number,
+		reference:      reference,
+		amount:         amount,
+		bankAccount:    bankAccount,
+		status:         status,
+		disbursementID: disbursementID,
+		failureReason:  failureReason,
+	}
+}
+
+func (u Upload) ID() uuid.UUID {
+	return u.id
+}
+
+func (u Upload) MerchantID() string {
+	return u.merchantID
+}
+
+// CreatedBy returns the user who uploaded the file, empty when the merchant uploaded it itself
+func (u Upload) CreatedBy() string {
+	return u.createdBy
+}
+
+func (u Upload) FileName() string {
+	return u.fileName
+}
+
+func (u Upload) RowCount() int {
+	return u.rowCount
+}
+
+func (u Upload) Status() UploadStatus {
+	return u.status
+}
+
+// RowIdempotencyKey returns the idempotency key of the disbursement of the row
+func (u Upload) RowIdempotencyKey(row *UploadRow) string {
+	return uploadIdempotencyKeyPrefix + u.id.String() + ":" + row.reference
+}
+
+func (u Upload) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+func (u Upload) UpdatedAt() time.Time {
+	return u.updatedAt
+}
+
+// CompletedAt returns the time every row was handled, zero while the upload is processing
+func (u Upload) CompletedAt() time.Time {
+	return u.completedAt
+}
+
+// Complete marks every row of the upload as handled, it does not say whether the rows were disbursed
+func (u *Upload) Complete() {
+	now := time.Now().UTC()
+
+	u.status = UploadStatusCompleted
+	u.updatedAt = now
+	u.completedAt = now
+}
+
+func (r UploadRow) Number() int {
+	return r.number
+}
+
+func (r UploadRow) Reference() string {
+	return r.reference
+}
+
+// Amount returns the amount to disburse, zero for a row created by NewInvalidUploadRow
+func (r UploadRow) Amount() money.Money {
+	return r.amount
+}
+
+// BankAccount returns the account to disburse to, zero for a row created by NewInvalidUploadRow
+func (r UploadRow) BankAccount() BankAccount {
+	return r.bankAccount
+}
+
+func (r UploadRow) Status() UploadRowStatus {
+	return r.status
+}
+
+// DisbursementID returns the disbursement created for the row, uuid.Nil until the row is disbursed
+func (r UploadRow) DisbursementID() uuid.UUID {
+	return r.disbursementID
+}
+
+func (r UploadRow) FailureReason() string {
+	return r.failureReason
+}
+
+func (r *UploadRow) MarkDisbursed(disbursementID uuid.UUID) {
+	r.status = UploadRowStatusDisbursed
+	r.disbursementID = disbursementID
+	r.failureReason = ""
+}
+
+func (r *UploadRow) MarkFailed(reason string) {
+	r.status = UploadRowStatusFailed
+	r.failureReason = reason
+}
+
+type UploadRepository interface {
+	// CreateUpload stores the upload with all its rows at once
+	CreateUpload(ctx context.Context, upload *Upload, rows []*UploadRow) error
+	GetUpload(ctx context.Context, id uuid.UUID) (*Upload, error)
+
+	// ClaimProcessingUploads claims up to limit uploads still processing at now until claimUntil, oldest first,
+	// a claimed upload is skipped by the other schedulers until the claim expires
+	ClaimProcessingUploads(ctx context.Context, now time.Time, claimUntil time.Time, limit int) ([]*Upload, error)
+
+	UpdateUploadRow(ctx context.Context, uploadID uuid.UUID, row *UploadRow) error
+	CompleteUpload(ctx context.Context, upload *Upload) error
+	CountUploadRowsByStatus(ctx context.Context, uploadID uuid.UUID) (map[UploadRowStatus]int, error)
+
+	// ListUploadRows returns the rows of the upload in the given status ordered by row number
+	ListUploadRows(ctx context.Context, uploadID uuid.UUID, status UploadRowStatus) ([]*UploadRow, error)
+}
@@ -0,0 +1,119 @@
+package ledger
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptyAccountCode   = stderrors.New("account code can not be empty")
+	ErrInvalidAccountType = stderrors.New("invalid account type")
+)
+
+type AccountType string
+
+const (
+	AccountTypeAsset     = AccountType("ASSET")
+	AccountTypeLiability = AccountType("LIABILITY")
+	AccountTypeRevenue   = AccountType("REVENUE")
+)
+
+func (t AccountType) String() string {
+	return string(t)
+}
+
+func (t AccountType) IsValid() bool {
+	return t == AccountTypeAsset || t == AccountTypeLiability || t == AccountTypeRevenue
+}
+
+// NormalSide returns the side that increases the balance, debit for an asset and credit for a liability or revenue
+func (t AccountType) NormalSide() Direction {
+	if t == AccountTypeAsset {
+		return DirectionDebit
+	}
+
+	return DirectionCredit
+}
+
+// the platform accounts shared by every merchant
+var (
+	// PayoutInTransitAccount holds what is owed to the beneficiaries while the payout is with the provider
+	PayoutInTransitAccount = Account{code: "platform:payout_in_transit", accountType: AccountTypeLiability}
+
+	// SettlementAccount is the cash at the payout provider, a payout takes from it and a reversal brings it back
+	SettlementAccount = Account{code: "platform:settlement", accountType: AccountTypeAsset}
+
+	// FeeRevenueAccount is what the platform earns from the disbursement fees charged to the merchants
+	FeeRevenueAccount = Account{code: "platform:fee_revenue", accountType: AccountTypeRevenue}
+
+	// FXConversionAccount is the currency position of the platform, it takes the funds in one currency
+	// and gives them in another when a disbursement is funded in another currency than it is paid out in
+	FXConversionAccount = Account{code: "platform:fx_conversion", accountType: AccountTypeAsset}
+)
+
+// Account is a ledger account, it is identified by its code and keeps a balance per currency
+type Account struct {
+	code        string
+	accountType AccountType
+}
+
+func NewAccount(code string, accountType AccountType) (Account, error) {
+	if code == "" {
+		return Account{}, errors.NewIncorrectInputError(
+			ErrEmptyAccountCode,
+			ErrEmptyAccountCode.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if !accountType.IsValid() {
+		return Account{}, errors.NewIncorrectInputError(
+			ErrInvalidAccountType,
+			fmt.Sprintf("%s: %q", ErrInvalidAccountType.Error(), accountType),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return Account{
+		code:        code,
+		accountType: accountType,
+	}, nil
+}
+
+// MerchantAvailableAccount is what the merchant can disburse. The merchant service owns the funding,
+// so this account only carries the net effect of the disbursements on the merchant funds.
+func MerchantAvailableAccount(merchantID string) Account {
+	return Account{
+		code:        fmt.Sprintf("merchant:%s:available", merchantID),
+		accountType: AccountTypeLiability,
+	}
+}
+
+// MerchantReservedAccount holds the merchant funds of the disbursements not sent for payout yet
+func MerchantReservedAccount(merchantID string) Account {
+	return Account{
+		code:        fmt.Sprintf("merchant:%s:reserved", merchantID),
+		accountType: AccountTypeLiability,
+	}
+}
+
+// UnmarshalAccountFromDatabase unmarshals Account from the database.
+//
+// It should be used only for unmarshalling from the database!
+// You can't use UnmarshalAccountFromDatabase as constructor - It may put domain into the invalid state!
+func UnmarshalAccountFromDatabase(code string, accountType AccountType) Account {
+	return Account{
+		code:        code,
+		accountType: accountType,
+	}
+}
+
+func (a Account) Code() string {
+	return a.code
+}
+
+func (a Account) Type() AccountType {
+	return a.accountType
+}
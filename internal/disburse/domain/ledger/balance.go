@@ -0,0 +1,58 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+// Balance is the total of the postings of an account in one currency
+type Balance struct {
+	account Account
+	debit   money.Money
+	credit  money.Money
+}
+
+// NewBalance creates the balance of the account from its debit and credit totals in the same currency
+func NewBalance(account Account, debit money.Money, credit money.Money) Balance {
+	return Balance{
+		account: account,
+		debit:   debit,
+		credit:  credit,
+	}
+}
+
+func (b Balance) Account() Account {
+	return b.account
+}
+
+func (b Balance) Currency() money.Currency {
+	return b.debit.Currency()
+}
+
+func (b Balance) Debit() money.Money {
+	return b.debit
+}
+
+func (b Balance) Credit() money.Money {
+	return b.credit
+}
+
+// Amount returns the balance on the normal side of the account, negative when the other side is larger
+func (b Balance) Amount() (money.Money, error) {
+	if b.account.accountType.NormalSide() == DirectionDebit {
+		return b.debit.Subtract(b.credit)
+	}
+
+	return b.credit.Subtract(b.debit)
+}
+
+type Repository interface {
+	// AddJournalEntry writes the entry with its postings, it must run in the transaction of the change it records.
+	// It returns ErrJournalAlreadyExists when the entry id is already written.
+	AddJournalEntry(ctx context.Context, entry *JournalEntry) error
+
+	// ListBalances returns the balance of the accounts in every currency they have postings in,
+	// an account without postings has no balance
+	ListBalances(ctx context.Context, accountCodes []string) ([]Balance, error)
+}
@@ -0,0 +1,183 @@
+package ledger
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrEmptyJournalID       = stderrors.New("journal entry id can not be empty")
+	ErrTooFewPostings       = stderrors.New("journal entry must have at least one debit and one credit")
+	ErrInvalidPosting       = stderrors.New("invalid posting")
+	ErrUnbalancedJournal    = stderrors.New("journal entry debits and credits do not balance")
+	ErrJournalAlreadyExists = stderrors.New("journal entry already exists")
+)
+
+type Direction string
+
+const (
+	DirectionDebit  = Direction("DEBIT")
+	DirectionCredit = Direction("CREDIT")
+)
+
+func (d Direction) String() string {
+	return string(d)
+}
+
+// Posting moves a positive amount into one side of an account
+type Posting struct {
+	account   Account
+	direction Direction
+	amount    money.Money
+}
+
+func Debit(account Account, amount money.Money) Posting {
+	return Posting{account: account, direction: DirectionDebit, amount: amount}
+}
+
+func Credit(account Account, amount money.Money) Posting {
+	return Posting{account: account, direction: DirectionCredit, amount: amount}
+}
+
+func (p Posting) Account() Account {
+	return p.account
+}
+
+func (p Posting) Direction() Direction {
+	return p.direction
+}
+
+func (p Posting) Amount() money.Money {
+	return p.amount
+}
+
+// JournalEntry records one business event as postings, the debits and credits of every currency must balance.
+// An entry is never changed once written, a correction is a new entry.
+type JournalEntry struct {
+	id uuid.UUID
+
+	// referenceID is the disbursement the entry is about
+	referenceID uuid.UUID
+	description string
+	postings    []Posting
+
+	createdAt time.Time
+}
+
+// NewJournalEntry checks the ledger invariants, a journal that does not hold them is a bug of the caller
+func NewJournalEntry(
+	id uuid.UUID,
+	referenceID uuid.UUID,
+	description string,
+	postings []Posting,
+	createdAt time.Time,
+) (*JournalEntry, error) {
+	if id == uuid.Nil {
+		return nil, errors.NewDpayError(
+			ErrEmptyJournalID,
+			ErrEmptyJournalID.Error(),
+			errors.DpayInternalError,
+		)
+	}
+
+	err := checkBalanced(postings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JournalEntry{
+		id:          id,
+		referenceID: referenceID,
+		description: description,
+		postings:    postings,
+		createdAt:   createdAt.UTC(),
+	}, nil
+}
+
+// checkBalanced checks every posting is a positive debit or credit and the debits equal the credits per currency
+func checkBalanced(postings []Posting) error {
+	var (
+		totals     = make(map[money.Currency]int64)
+		hasDebit   bool
+		hasCredit  bool
+		currencies []money.Currency
+	)
+
+	for _, posting := range postings {
+		if posting.account.code == "" || !posting.amount.IsPositive() {
+			return errors.NewDpayError(
+				ErrInvalidPosting,
+				fmt.Sprintf(
+					"%s: %s %s on account %q must be positive",
+					ErrInvalidPosting.Error(), posting.direction, posting.amount, posting.account.code,
+				),
+				errors.DpayInternalError,
+			)
+		}
+
+		currency := posting.amount.Currency()
+		if _, ok := totals[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+
+		switch posting.direction {
+		case DirectionDebit:
+			hasDebit = true
+			totals[currency] += posting.amount.MinorUnits()
+		case DirectionCredit:
+			hasCredit = true
+			totals[currency] -= posting.amount.MinorUnits()
+		default:
+			return errors.NewDpayError(
+				ErrInvalidPosting,
+				fmt.Sprintf("%s: unknown direction %q", ErrInvalidPosting.Error(), posting.direction),
+				errors.DpayInternalError,
+			)
+		}
+	}
+
+	if !hasDebit || !hasCredit {
+		return errors.NewDpayError(
+			ErrTooFewPostings,
+			ErrTooFewPostings.Error(),
+			errors.DpayInternalError,
+		)
+	}
+
+	for _, currency := range currencies {
+		if totals[currency] != 0 {
+			return errors.NewDpayError(
+				ErrUnbalancedJournal,
+				fmt.Sprintf("%s in %s", ErrUnbalancedJournal.Error(), currency),
+				errors.DpayInternalError,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (j JournalEntry) ID() uuid.UUID {
+	return j.id
+}
+
+func (j JournalEntry) ReferenceID() uuid.UUID {
+	return j.referenceID
+}
+
+func (j JournalEntry) Description() string {
+	return j.description
+}
+
+func (j JournalEntry) Postings() []Posting {
+	return j.postings
+}
+
+func (j JournalEntry) CreatedAt() time.Time {
+	return j.createdAt
+}
@@ -0,0 +1,170 @@
+package ledger
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+func TestNewJournalEntry(t *testing.T) {
+	merchant := MerchantAvailableAccount("merchant-1")
+	reserved := MerchantReservedAccount("merchant-1")
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		postings []Posting
+		wantErr  error
+	}{
+		{
+			name:     "balanced transfer",
+			id:       uuid.New(),
+			postings: []Posting{Debit(merchant, idr(t, 10000)), Credit(reserved, idr(t, 10000))},
+		},
+		{
+			name: "split credit",
+			id:   uuid.New(),
+			postings: []Posting{
+				Debit(PayoutInTransitAccount, idr(t, 10500)),
+				Credit(SettlementAccount, idr(t, 10000)),
+				Credit(FeeRevenueAccount, idr(t, 500)),
+			},
+		},
+		{
+			name: "balanced in every currency",
+			id:   uuid.New(),
+			postings: []Posting{
+				Debit(reserved, idr(t, 160000)),
+				Credit(FXConversionAccount, idr(t, 160000)),
+				Debit(FXConversionAccount, usd(t, 1000)),
+				Credit(PayoutInTransitAccount, usd(t, 1000)),
+			},
+		},
+		{
+			name:     "empty id",
+			id:       uuid.Nil,
+			postings: []Posting{Debit(merchant, idr(t, 10000)), Credit(reserved, idr(t, 10000))},
+			wantErr:  ErrEmptyJournalID,
+		},
+		{
+			name:    "no posting",
+			id:      uuid.New(),
+			wantErr: ErrTooFewPostings,
+		},
+		{
+			name:     "debits only",
+			id:       uuid.New(),
+			postings: []Posting{Debit(merchant, idr(t, 10000)), Debit(reserved, idr(t, 10000))},
+			wantErr:  ErrTooFewPostings,
+		},
+		{
+			name:     "debits over the credits",
+			id:       uuid.New(),
+			postings: []Posting{Debit(merchant, idr(t, 10001)), Credit(reserved, idr(t, 10000))},
+			wantErr:  ErrUnbalancedJournal,
+		},
+		{
+			name: "balanced in total but not per currency",
+			id:   uuid.New(),
+			postings: []Posting{
+				Debit(reserved, idr(t, 10000)),
+				Credit(PayoutInTransitAccount, usd(t, 10000)),
+			},
+			wantErr: ErrUnbalancedJournal,
+		},
+		{
+			name:     "zero amount",
+			id:       uuid.New(),
+			postings: []Posting{Debit(merchant, idr(t, 0)), Credit(reserved, idr(t, 0))},
+			wantErr:  ErrInvalidPosting,
+		},
+		{
+			name:     "negative amount",
+			id:       uuid.New(),
+			postings: []Posting{Debit(merchant, idr(t, -10000)), Credit(reserved, idr(t, -10000))},
+			wantErr:  ErrInvalidPosting,
+		},
+		{
+			name:     "account without code",
+			id:       uuid.New(),
+			postings: []Posting{Debit(Account{}, idr(t, 10000)), Credit(reserved, idr(t, 10000))},
+			wantErr:  ErrInvalidPosting,
+		},
+		{
+			name: "unknown direction",
+			id:   uuid.New(),
+			postings: []Posting{
+				Debit(merchant, idr(t, 10000)),
+				Credit(reserved, idr(t, 10000)),
+				{account: reserved, direction: Direction("SIDEWAYS"), amount: idr(t, 10000)},
+			},
+			wantErr: ErrInvalidPosting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewJournalEntry(tt.id, uuid.New(), "test", tt.postings, time.Now())
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("NewJournalEntry error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && len(entry.Postings()) != len(tt.postings) {
+				t.Errorf("postings = %d, want %d", len(entry.Postings()), len(tt.postings))
+			}
+		})
+	}
+}
+
+func TestBalanceAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		account Account
+		debit   int64
+		credit  int64
+		expect  int64
+	}{
+		{name: "asset grows with debits", account: SettlementAccount, debit: 10000, credit: 2500, expect: 7500},
+		{name: "liability grows with credits", account: PayoutInTransitAccount, debit: 2500, credit: 10000, expect: 7500},
+		{name: "revenue grows with credits", account: FeeRevenueAccount, credit: 500, expect: 500},
+		{name: "overdrawn liability", account: MerchantAvailableAccount("merchant-1"), debit: 10000, expect: -10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBalance(tt.account, idr(t, tt.debit), idr(t, tt.credit)).Amount()
+			if err != nil {
+				t.Fatalf("Amount error = %v", err)
+			}
+
+			if got.MinorUnits() != tt.expect {
+				t.Errorf("Amount() = %d, want %d", got.MinorUnits(), tt.expect)
+			}
+		})
+	}
+}
+
+func idr(t *testing.T, minorUnits int64) money.Money {
+	t.Helper()
+
+	m, err := money.New(minorUnits, money.IDR)
+	if err != nil {
+		t.Fatalf("new money: %v", err)
+	}
+
+	return m
+}
+
+func usd(t *testing.T, minorUnits int64) money.Money {
+	t.Helper()
+
+	m, err := money.New(minorUnits, money.USD)
+	if err != nil {
+		t.Fatalf("new money: %v", err)
+	}
+
+	return m
+}
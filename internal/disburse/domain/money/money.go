@@ -0,0 +1,222 @@
+package money
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var (
+	ErrInvalidCurrency  = stderrors.New("invalid currency")
+	ErrInvalidAmount    = stderrors.New("invalid amount")
+	ErrCurrencyMismatch = stderrors.New("currency mismatch")
+)
+
+var decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// basisPointsPerUnit is the number of basis points in a whole, 1 basis point is 0.01%
+const basisPointsPerUnit = 10000
+
+// Money is an exact amount kept in the minor unit of its currency, e.g. 10000.50 IDR is kept as 1000050
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New creates Money from an amount already expressed in minor units
+func New(minorUnits int64, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, newInvalidCurrencyError(currency.String())
+	}
+
+	return Money{
+		amount:   minorUnits,
+		currency: currency,
+	}, nil
+}
+
+// Parse creates Money from a decimal string in major units, e.g. "10000.50".
+// It never rounds, an amount with more fraction digits than the currency allows is rejected.
+func Parse(amount string, currencyCode string) (Money, error) {
+	currency, err := ParseCurrency(currencyCode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount = strings.TrimSpace(amount)
+	if !decimalPattern.MatchString(amount) {
+		return Money{}, newInvalidAmountError(fmt.Sprintf("%s: %q is not a decimal number", ErrInvalidAmount.Error(), amount))
+	}
+
+	integerPart, fractionPart, _ := strings.Cut(amount, ".")
+
+	// trailing zeros don't change the value, e.g. "100.500" from a DECIMAL column is still exact for IDR
+	if len(fractionPart) > currency.Exponent() {
+		fractionPart = strings.TrimRight(fractionPart, "0")
+	}
+
+	if len(fractionPart) > currency.Exponent() {
+		return Money{}, newInvalidAmountError(
+			fmt.Sprintf(
+				"%s: %s supports at most %d decimal places",
+				ErrInvalidAmount.Error(), currency, currency.Exponent(),
+			),
+		)
+	}
+
+	fractionPart += strings.Repeat("0", currency.Exponent()-len(fractionPart))
+
+	minorUnits, ok := new(big.Int).SetString(integerPart+fractionPart, 10)
+	if !ok || !minorUnits.IsInt64() {
+		return Money{}, newInvalidAmountError(fmt.Sprintf("%s: %q is out of range", ErrInvalidAmount.Error(), amount))
+	}
+
+	return Money{
+		amount:   minorUnits.Int64(),
+		currency: currency,
+	}, nil
+}
+
+// MinorUnits returns the amount in the minor unit of the currency
+func (m Money) MinorUnits() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Decimal returns the amount in major units as exact decimal string, e.g. "10000.50"
+func (m Money) Decimal() string {
+	exponent := m.currency.Exponent()
+
+	digits := new(big.Int).Abs(big.NewInt(m.amount)).String()
+	if exponent == 0 {
+		if m.amount < 0 {
+			return "-" + digits
+		}
+
+		return digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	sign := ""
+	if m.amount < 0 {
+		sign = "-"
+	}
+
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Decimal(), m.currency)
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+// Compare returns -1, 0 or +1 comparing m to other, both must be in the same currency
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+
+	sum := new(big.Int).Add(big.NewInt(m.amount), big.NewInt(other.amount))
+	if !sum.IsInt64() {
+		return Money{}, newInvalidAmountError(fmt.Sprintf("%s: addition overflows", ErrInvalidAmount.Error()))
+	}
+
+	return Money{amount: sum.Int64(), currency: m.currency}, nil
+}
+
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.assertSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+
+	diff := new(big.Int).Sub(big.NewInt(m.amount), big.NewInt(other.amount))
+	if !diff.IsInt64() {
+		return Money{}, newInvalidAmountError(fmt.Sprintf("%s: subtraction overflows", ErrInvalidAmount.Error()))
+	}
+
+	return Money{amount: diff.Int64(), currency: m.currency}, nil
+}
+
+// BasisPoints returns basisPoints / 10000 of m, a part of a minor unit is rounded half away from zero
+func (m Money) BasisPoints(basisPoints int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(basisPoints))
+
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(basisPointsPerUnit), new(big.Int))
+	if twice := new(big.Int).Abs(remainder); twice.Lsh(twice, 1).Cmp(big.NewInt(basisPointsPerUnit)) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, newInvalidAmountError(fmt.Sprintf("%s: multiplication overflows", ErrInvalidAmount.Error()))
+	}
+
+	return Money{amount: quotient.Int64(), currency: m.currency}, nil
+}
+
+func (m Money) assertSameCurrency(other Money) error {
+	if m.currency == other.currency {
+		return nil
+	}
+
+	return errors.NewUnprocessableEntityError(
+		ErrCurrencyMismatch,
+		fmt.Sprintf("%s: %s and %s", ErrCurrencyMismatch.Error(), m.currency, other.currency),
+		errors.DpayInvalidRequest,
+	)
+}
+
+func newInvalidCurrencyError(code string) error {
+	return errors.NewIncorrectInputError(
+		ErrInvalidCurrency,
+		fmt.Sprintf("%s: %q is not a supported ISO-4217 currency", ErrInvalidCurrency.Error(), code),
+		errors.DpayInvalidRequest,
+	)
+}
+
+func newInvalidAmountError(message string) error {
+	return errors.NewIncorrectInputError(
+		ErrInvalidAmount,
+		message,
+		errors.DpayInvalidRequest,
+	)
+}
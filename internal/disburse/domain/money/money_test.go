@@ -0,0 +1,139 @@
+package money
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     string
+		currency   string
+		minorUnits int64
+		expect     Currency
+		wantErr    error
+	}{
+		{name: "whole amount", amount: "10000", currency: "IDR", minorUnits: 1000000, expect: IDR},
+		{name: "fraction amount", amount: "10000.50", currency: "IDR", minorUnits: 1000050, expect: IDR},
+		{name: "single fraction digit", amount: "0.5", currency: "USD", minorUnits: 50, expect: USD},
+		{name: "lower case currency", amount: "1", currency: "usd", minorUnits: 100, expect: USD},
+		{name: "surrounding spaces", amount: " 12.34 ", currency: " USD ", minorUnits: 1234, expect: USD},
+		{name: "trailing zeros past the exponent", amount: "100.500", currency: "IDR", minorUnits: 10050, expect: IDR},
+		{name: "zero exponent currency", amount: "1500", currency: "JPY", minorUnits: 1500, expect: "JPY"},
+		{name: "three digits exponent currency", amount: "1.234", currency: "KWD", minorUnits: 1234, expect: "KWD"},
+		{name: "negative amount", amount: "-1.25", currency: "USD", minorUnits: -125, expect: USD},
+		{name: "too many fraction digits", amount: "1.005", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "fraction for zero exponent currency", amount: "1.5", currency: "JPY", wantErr: ErrInvalidAmount},
+		{name: "not a number", amount: "ten", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "exponent notation", amount: "1e3", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "empty amount", amount: "", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "out of range", amount: "92233720368547758.08", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "unknown currency", amount: "1", currency: "XYZ", wantErr: ErrInvalidCurrency},
+		{name: "empty currency", amount: "1", currency: "", wantErr: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.amount, tt.currency)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q, %q) error = %v, want %v", tt.amount, tt.currency, err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.MinorUnits() != tt.minorUnits || got.Currency() != tt.expect {
+				t.Errorf(
+					"Parse(%q, %q) = %d %s, want %d %s",
+					tt.amount, tt.currency, got.MinorUnits(), got.Currency(), tt.minorUnits, tt.expect,
+				)
+			}
+		})
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		minorUnits int64
+		currency   Currency
+		expect     string
+	}{
+		{minorUnits: 1000050, currency: IDR, expect: "10000.50"},
+		{minorUnits: 5, currency: USD, expect: "0.05"},
+		{minorUnits: 0, currency: USD, expect: "0.00"},
+		{minorUnits: -125, currency: USD, expect: "-1.25"},
+		{minorUnits: 1500, currency: "JPY", expect: "1500"},
+		{minorUnits: -1500, currency: "JPY", expect: "-1500"},
+		{minorUnits: 1234, currency: "KWD", expect: "1.234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expect+" "+tt.currency.String(), func(t *testing.T) {
+			m, err := New(tt.minorUnits, tt.currency)
+			if err != nil {
+				t.Fatalf("New(%d, %s) error = %v", tt.minorUnits, tt.currency, err)
+			}
+
+			if got := m.Decimal(); got != tt.expect {
+				t.Errorf("Decimal() = %q, want %q", got, tt.expect)
+			}
+
+			// the decimal is what is stored, it must parse back to the same amount
+			parsed, err := Parse(m.Decimal(), m.Currency().String())
+			if err != nil || !parsed.Equal(m) {
+				t.Errorf("Parse(Decimal()) = %v, %v, want %v", parsed, err, m)
+			}
+		})
+	}
+}
+
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	idr, _ := New(100, IDR)
+	usd, _ := New(100, USD)
+
+	if _, err := idr.Add(usd); !stderrors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+
+	if _, err := idr.Subtract(usd); !stderrors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Subtract error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+
+	if _, err := idr.Compare(usd); !stderrors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Compare error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
+func TestMoneyBasisPoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		minorUnits  int64
+		basisPoints int64
+		expect      int64
+	}{
+		{name: "exact", minorUnits: 10000, basisPoints: 250, expect: 250},
+		{name: "round half up", minorUnits: 150, basisPoints: 100, expect: 2},
+		{name: "round down", minorUnits: 149, basisPoints: 100, expect: 1},
+		{name: "round half away from zero", minorUnits: -150, basisPoints: 100, expect: -2},
+		{name: "zero", minorUnits: 10000, basisPoints: 0, expect: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.minorUnits, USD)
+			if err != nil {
+				t.Fatalf("New error = %v", err)
+			}
+
+			got, err := m.BasisPoints(tt.basisPoints)
+			if err != nil {
+				t.Fatalf("BasisPoints error = %v", err)
+			}
+
+			if got.MinorUnits() != tt.expect {
+				t.Errorf("BasisPoints(%d) of %d = %d, want %d", tt.basisPoints, tt.minorUnits, got.MinorUnits(), tt.expect)
+			}
+		})
+	}
+}
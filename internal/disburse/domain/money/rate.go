@@ -0,0 +1,137 @@
+package money
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+var ErrInvalidRate = stderrors.New("invalid exchange rate")
+
+// maxRateFractionDigits is the precision a rate is kept and stored with
+const maxRateFractionDigits = 10
+
+// Rate is an exact exchange rate, 1 unit of the source currency is worth value units of the target currency
+type Rate struct {
+	source Currency
+	target Currency
+	value  *big.Rat
+}
+
+// NewRate creates the rate from a positive decimal string, e.g. "15850.25" for USD to IDR
+func NewRate(source Currency, target Currency, value string) (Rate, error) {
+	for _, currency := range []Currency{source, target} {
+		if !currency.IsValid() {
+			return Rate{}, newInvalidCurrencyError(currency.String())
+		}
+	}
+
+	value = strings.TrimSpace(value)
+	if !decimalPattern.MatchString(value) {
+		return Rate{}, newInvalidRateError(fmt.Sprintf("%q is not a decimal number", value))
+	}
+
+	if _, fractionPart, _ := strings.Cut(value, "."); len(strings.TrimRight(fractionPart, "0")) > maxRateFractionDigits {
+		return Rate{}, newInvalidRateError(fmt.Sprintf("%q has more than %d fraction digits", value, maxRateFractionDigits))
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok || rat.Sign() <= 0 {
+		return Rate{}, newInvalidRateError(fmt.Sprintf("%q must be greater than zero", value))
+	}
+
+	return Rate{
+		source: source,
+		target: target,
+		value:  rat,
+	}, nil
+}
+
+func (r Rate) Source() Currency {
+	return r.source
+}
+
+func (r Rate) Target() Currency {
+	return r.target
+}
+
+// IsZero checks whether the rate is unset, the zero Rate converts nothing
+func (r Rate) IsZero() bool {
+	return r.value == nil
+}
+
+// Decimal returns the rate as a decimal string without trailing zeros, e.g. "15850.25"
+func (r Rate) Decimal() string {
+	if r.value == nil {
+		return "0"
+	}
+
+	decimal := r.value.FloatString(maxRateFractionDigits)
+	decimal = strings.TrimRight(decimal, "0")
+
+	return strings.TrimSuffix(decimal, ".")
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%s/%s %s", r.source, r.target, r.Decimal())
+}
+
+// Inverse returns the rate from the target currency back to the source currency
+func (r Rate) Inverse() Rate {
+	if r.value == nil {
+		return Rate{source: r.target, target: r.source}
+	}
+
+	return Rate{
+		source: r.target,
+		target: r.source,
+		value:  new(big.Rat).Inv(r.value),
+	}
+}
+
+// Convert returns the amount in the source currency worth in the target currency,
+// a part of a minor unit of the target currency is rounded half away from zero
+func (r Rate) Convert(amount Money) (Money, error) {
+	if amount.currency != r.source {
+		return Money{}, errors.NewUnprocessableEntityError(
+			ErrCurrencyMismatch,
+			fmt.Sprintf("%s: rate is from %s, got %s", ErrCurrencyMismatch.Error(), r.source, amount.currency),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if r.value == nil {
+		return Money{}, newInvalidRateError("rate is not set")
+	}
+
+	// minor units of the target = minor units of the source / 10^source exponent * rate * 10^target exponent
+	converted := new(big.Rat).SetInt64(amount.amount)
+	converted.Mul(converted, r.value)
+	converted.Mul(converted, new(big.Rat).SetFrac(pow10(r.target.Exponent()), pow10(r.source.Exponent())))
+
+	quotient, remainder := new(big.Int).QuoRem(converted.Num(), converted.Denom(), new(big.Int))
+	if twice := new(big.Int).Abs(remainder); twice.Lsh(twice, 1).Cmp(converted.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(converted.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, newInvalidAmountError(fmt.Sprintf("%s: conversion overflows", ErrInvalidAmount.Error()))
+	}
+
+	return Money{amount: quotient.Int64(), currency: r.target}, nil
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+func newInvalidRateError(message string) error {
+	return errors.NewIncorrectInputError(
+		ErrInvalidRate,
+		fmt.Sprintf("%s: %s", ErrInvalidRate.Error(), message),
+		errors.DpayInvalidRequest,
+	)
+}
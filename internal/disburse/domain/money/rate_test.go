@@ -0,0 +1,141 @@
+package money
+
+import (
+	stderrors "errors"
+	"math"
+	"testing"
+)
+
+func TestNewRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  Currency
+		target  Currency
+		value   string
+		expect  string
+		wantErr error
+	}{
+		{name: "decimal rate", source: USD, target: IDR, value: "15850.25", expect: "15850.25"},
+		{name: "trailing zeros are dropped", source: USD, target: IDR, value: "15850.2500", expect: "15850.25"},
+		{name: "whole rate", source: USD, target: IDR, value: "16000", expect: "16000"},
+		{name: "max fraction digits", source: IDR, target: USD, value: "0.0000630905", expect: "0.0000630905"},
+		{name: "trailing zeros past the max fraction digits", source: IDR, target: USD, value: "0.000063090500", expect: "0.0000630905"},
+		{name: "surrounding spaces", source: USD, target: IDR, value: " 15850.25 ", expect: "15850.25"},
+		{name: "too many fraction digits", source: IDR, target: USD, value: "0.00006309051", wantErr: ErrInvalidRate},
+		{name: "zero", source: USD, target: IDR, value: "0", wantErr: ErrInvalidRate},
+		{name: "negative", source: USD, target: IDR, value: "-15850.25", wantErr: ErrInvalidRate},
+		{name: "not a number", source: USD, target: IDR, value: "high", wantErr: ErrInvalidRate},
+		{name: "exponent notation", source: USD, target: IDR, value: "1.585e4", wantErr: ErrInvalidRate},
+		{name: "empty", source: USD, target: IDR, value: "", wantErr: ErrInvalidRate},
+		{name: "unknown source", source: "XYZ", target: IDR, value: "1", wantErr: ErrInvalidCurrency},
+		{name: "unknown target", source: USD, target: "XYZ", value: "1", wantErr: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := NewRate(tt.source, tt.target, tt.value)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("NewRate(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && rate.Decimal() != tt.expect {
+				t.Errorf("NewRate(%q).Decimal() = %q, want %q", tt.value, rate.Decimal(), tt.expect)
+			}
+		})
+	}
+}
+
+func TestRateConvert(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     Currency
+		target     Currency
+		rate       string
+		minorUnits int64
+		expect     int64
+		wantErr    error
+	}{
+		{name: "exact", source: USD, target: IDR, rate: "15850.25", minorUnits: 1000, expect: 15850250},
+		{name: "to a zero exponent currency", source: USD, target: "JPY", rate: "149.25", minorUnits: 1000, expect: 1493},
+		{name: "from a zero exponent currency", source: "JPY", target: USD, rate: "0.0067", minorUnits: 1500, expect: 1005},
+		{name: "to a three digits exponent currency", source: USD, target: "KWD", rate: "0.30725", minorUnits: 100, expect: 307},
+		{name: "round half away from zero", source: USD, target: "KWD", rate: "0.30725", minorUnits: 200, expect: 615},
+		{name: "round down below the half", source: USD, target: "JPY", rate: "149.49", minorUnits: 100, expect: 149},
+		{name: "round up on the half", source: USD, target: "JPY", rate: "149.5", minorUnits: 100, expect: 150},
+		{name: "negative rounds half away from zero", source: USD, target: "JPY", rate: "149.5", minorUnits: -100, expect: -150},
+		{name: "small rate", source: IDR, target: USD, rate: "0.0000630905", minorUnits: 1000000, expect: 63},
+		{name: "zero amount", source: USD, target: IDR, rate: "15850.25", minorUnits: 0, expect: 0},
+		{name: "other currency", source: USD, target: IDR, rate: "15850.25", minorUnits: 1000, wantErr: ErrCurrencyMismatch},
+		{name: "overflow", source: USD, target: IDR, rate: "15850.25", minorUnits: math.MaxInt64, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := NewRate(tt.source, tt.target, tt.rate)
+			if err != nil {
+				t.Fatalf("NewRate(%q) error = %v", tt.rate, err)
+			}
+
+			currency := tt.source
+			if stderrors.Is(tt.wantErr, ErrCurrencyMismatch) {
+				currency = tt.target
+			}
+
+			amount, err := New(tt.minorUnits, currency)
+			if err != nil {
+				t.Fatalf("New error = %v", err)
+			}
+
+			got, err := rate.Convert(amount)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("Convert(%s) error = %v, want %v", amount, err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.MinorUnits() != tt.expect || got.Currency() != tt.target {
+				t.Errorf(
+					"Convert(%s) = %d %s, want %d %s",
+					amount, got.MinorUnits(), got.Currency(), tt.expect, tt.target,
+				)
+			}
+		})
+	}
+}
+
+func TestRateInverse(t *testing.T) {
+	rate, err := NewRate(USD, IDR, "16000")
+	if err != nil {
+		t.Fatalf("NewRate error = %v", err)
+	}
+
+	inverse := rate.Inverse()
+	if inverse.Source() != IDR || inverse.Target() != USD || inverse.Decimal() != "0.0000625" {
+		t.Errorf("Inverse() = %s, want IDR/USD 0.0000625", inverse)
+	}
+
+	if back := inverse.Inverse(); back.Source() != USD || back.Target() != IDR || back.Decimal() != rate.Decimal() {
+		t.Errorf("Inverse().Inverse() = %s, want %s", back, rate)
+	}
+
+	amount, _ := New(1600000, IDR)
+
+	got, err := inverse.Convert(amount)
+	if err != nil || got.MinorUnits() != 100 || got.Currency() != USD {
+		t.Errorf("Inverse().Convert(%s) = %v, %v, want 1.00 USD", amount, got, err)
+	}
+}
+
+func TestZeroRateConvert(t *testing.T) {
+	rate := Rate{source: USD, target: IDR}
+	if !rate.IsZero() || !rate.Inverse().IsZero() {
+		t.Errorf("IsZero() = false, want true")
+	}
+
+	amount, _ := New(100, USD)
+	if _, err := rate.Convert(amount); !stderrors.Is(err, ErrInvalidRate) {
+		t.Errorf("Convert error = %v, want %v", err, ErrInvalidRate)
+	}
+}
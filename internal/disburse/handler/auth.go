@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/utils"
+)
+
+var (
+	ErrMerchantNotAuthenticated = stderrors.New("merchant is not authenticated")
+	ErrUserNotAuthenticated     = stderrors.New("user is not authenticated")
+	ErrNotInternalUser          = stderrors.New("only an internal user can manage the merchant settings")
+)
+
+// User is the authenticated user of the merchant acting on the request
+type User struct {
+	ID   string
+	Role string
+}
+
+// MerchantIDFromContext returns the id of the merchant authenticated for the request
+func MerchantIDFromContext(ctx context.Context) (string, error) {
+	merchantID := utils.GetFromContext[string](ctx, constants.MerchantIDKey)
+	if merchantID == "" {
+		return "", errors.NewAuthorizationError(
+			ErrMerchantNotAuthenticated,
+			ErrMerchantNotAuthenticated.Error(),
+			errors.DpayUnauthorized,
+		)
+	}
+
+	return merchantID, nil
+}
+
+// UserFromContext returns the user authenticated for the request, false when the merchant acts without user
+func UserFromContext(ctx context.Context) (User, bool) {
+	user := User{
+		ID:   utils.GetFromContext[string](ctx, constants.UserIDKey),
+		Role: utils.GetFromContext[string](ctx, constants.UserRoleKey),
+	}
+
+	return user, user.ID != ""
+}
+
+// ApproverFromContext returns the user authenticated for the request, an approval always needs a user
+func ApproverFromContext(ctx context.Context) (User, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return User{}, errors.NewAuthorizationError(
+			ErrUserNotAuthenticated,
+			ErrUserNotAuthenticated.Error(),
+			errors.DpayUnauthorized,
+		)
+	}
+
+	return user, nil
+}
+
+// InternalUserIDFromContext returns the id of the internal user authenticated for the request, the settings of the
+// merchants are managed by the internal users only and never by a merchant or its users
+func InternalUserIDFromContext(ctx context.Context) (string, error) {
+	userID := utils.GetFromContext[string](ctx, constants.InternalUserIDKey)
+	if userID == "" {
+		return "", errors.NewForbiddenError(
+			ErrNotInternalUser,
+			ErrNotInternalUser.Error(),
+			errors.DpayActionNotAllowed,
+		)
+	}
+
+	return userID, nil
+}
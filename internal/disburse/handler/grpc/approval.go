@@ -0,0 +1,115 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (g GRPCServer) ApproveDisbursement(
+	ctx context.Context,
+	req *protogen.DisbursementActionRequest,
+) (*protogen.Disbursement, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	approver, err := handler.ApproverFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseDisbursementID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.ApproveDisbursement.Handle(ctx, &command.ApproveDisbursementParam{
+		ID:           id,
+		MerchantID:   merchantID,
+		ApproverID:   approver.ID,
+		ApproverRole: approver.Role,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getDisbursement(ctx, id, merchantID)
+}
+
+func (g GRPCServer) RejectDisbursement(
+	ctx context.Context,
+	req *protogen.DisbursementActionRequest,
+) (*protogen.Disbursement, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	approver, err := handler.ApproverFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseDisbursementID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.RejectDisbursement.Handle(ctx, &command.RejectDisbursementParam{
+		ID:           id,
+		MerchantID:   merchantID,
+		ApproverID:   approver.ID,
+		ApproverRole: approver.Role,
+		Reason:       req.GetReason(),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getDisbursement(ctx, id, merchantID)
+}
+
+func (g GRPCServer) ListDisbursementApprovals(
+	ctx context.Context,
+	req *protogen.GetDisbursementRequest,
+) (*protogen.ListDisbursementApprovalsResponse, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseDisbursementID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	steps, err := g.app.Queries.GetDisbursementApprovals.Handle(ctx, &query.GetDisbursementApprovalsParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.ListDisbursementApprovalsResponse{
+		Approvals: lo.Map(steps, func(s *disburse.ApprovalStep, _ int) *protogen.DisbursementApproval {
+			return &protogen.DisbursementApproval{
+				Id:        s.ID().String(),
+				Action:    s.Action().String(),
+				Actor:     s.Actor(),
+				Role:      s.Role(),
+				Reason:    s.Reason(),
+				CreatedAt: timestamppb.New(s.CreatedAt()),
+			}
+		}),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package grpchandler
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+)
+
+func (g GRPCServer) GetApprovalPolicy(
+	ctx context.Context,
+	req *protogen.GetApprovalPolicyRequest,
+) (*protogen.ApprovalPolicy, error) {
+	_, err := handler.InternalUserIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getApprovalPolicy(ctx, req.GetMerchantId(), req.GetCurrency())
+}
+
+func (g GRPCServer) SetApprovalPolicy(
+	ctx context.Context,
+	req *protogen.SetApprovalPolicyRequest,
+) (*protogen.ApprovalPolicy, error) {
+	_, err := handler.InternalUserIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.SetApprovalPolicy.Handle(ctx, &command.SetApprovalPolicyParam{
+		MerchantID:        req.GetMerchantId(),
+		Currency:          req.GetCurrency(),
+		Threshold:         req.GetThreshold(),
+		ApproverRoles:     req.GetApproverRoles(),
+		RequiredApprovals: int(req.GetRequiredApprovals()),
+		ApprovalWindow:    time.Duration(req.GetApprovalWindowSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getApprovalPolicy(ctx, req.GetMerchantId(), req.GetCurrency())
+}
+
+func (g GRPCServer) getApprovalPolicy(
+	ctx context.Context,
+	merchantID string,
+	currency string,
+) (*protogen.ApprovalPolicy, error) {
+	policy, err := g.app.Queries.GetApprovalPolicy.Handle(ctx, &query.GetApprovalPolicyParam{
+		MerchantID: merchantID,
+		Currency:   currency,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.ApprovalPolicy{
+		MerchantId:            merchantID,
+		Currency:              policy.Currency().String(),
+		Threshold:             policy.Threshold().Decimal(),
+		ApproverRoles:         policy.ApproverRoles(),
+		RequiredApprovals:     int32(policy.RequiredApprovals()),
+		ApprovalWindowSeconds: int64(policy.Window() / time.Second),
+	}, nil
+}
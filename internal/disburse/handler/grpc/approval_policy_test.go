@@ -0,0 +1,114 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetApprovalPolicy(t *testing.T) {
+	threshold, err := money.Parse("100000000", "IDR")
+	if err != nil {
+		t.Fatalf("parse threshold: %v", err)
+	}
+
+	policy, err := disburse.NewApprovalPolicy(threshold, []string{"finance_manager"}, 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("new approval policy: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{
+			name: "internal user",
+			ctx:  context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+			code: codes.OK,
+		},
+		{
+			// a merchant can not drop the approvals its disbursements need
+			name: "merchant",
+			ctx:  context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setHandler := &fakeCommandHandler[*command.SetApprovalPolicyParam]{}
+			getHandler := &fakeQueryHandler[*query.GetApprovalPolicyParam, disburse.ApprovalPolicy]{result: policy}
+
+			g := GRPCServer{app: &app.Application{
+				Commands: app.Commands{SetApprovalPolicy: setHandler},
+				Queries:  app.Queries{GetApprovalPolicy: getHandler},
+			}}
+
+			resp, err := g.SetApprovalPolicy(tt.ctx, &protogen.SetApprovalPolicyRequest{
+				MerchantId:            "merchant-2",
+				Currency:              "IDR",
+				Threshold:             "100000000",
+				ApproverRoles:         []string{"finance_manager"},
+				RequiredApprovals:     2,
+				ApprovalWindowSeconds: 86400,
+			})
+			if status.Code(err) != tt.code {
+				t.Fatalf("SetApprovalPolicy error = %v, want %s", err, tt.code)
+			}
+
+			if tt.code != codes.OK {
+				if len(setHandler.calls) != 0 {
+					t.Errorf("SetApprovalPolicy called %d times, want 0", len(setHandler.calls))
+				}
+
+				return
+			}
+
+			if len(setHandler.calls) != 1 {
+				t.Fatalf("SetApprovalPolicy called %d times, want 1", len(setHandler.calls))
+			}
+
+			param := setHandler.calls[0]
+			if param.MerchantID != "merchant-2" || param.RequiredApprovals != 2 || param.ApprovalWindow != 24*time.Hour {
+				t.Errorf("SetApprovalPolicy param = %+v, want 2 approvals within 24h for merchant-2", param)
+			}
+
+			if resp.GetThreshold() != "100000000.00" || resp.GetApprovalWindowSeconds() != 86400 {
+				t.Errorf("response = %+v, want the policy read back", resp)
+			}
+		})
+	}
+}
+
+func TestGetApprovalPolicyNotFound(t *testing.T) {
+	g := GRPCServer{app: &app.Application{
+		Queries: app.Queries{GetApprovalPolicy: &fakeQueryHandler[*query.GetApprovalPolicyParam, disburse.ApprovalPolicy]{
+			err: errors.NewNotFoundError(
+				disburse.ErrApprovalPolicyNotFound,
+				disburse.ErrApprovalPolicyNotFound.Error(),
+				errors.DpayNotFound,
+			),
+		}},
+	}}
+
+	// the disbursements of a merchant without policy need no approval
+	_, err := g.GetApprovalPolicy(
+		context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+		&protogen.GetApprovalPolicyRequest{MerchantId: "merchant-1", Currency: "IDR"},
+	)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetApprovalPolicy error = %v, want %s", err, codes.NotFound)
+	}
+}
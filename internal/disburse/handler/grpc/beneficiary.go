@@ -0,0 +1,175 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (g GRPCServer) CreateBeneficiary(
+	ctx context.Context,
+	req *protogen.BeneficiaryRequest,
+) (*protogen.Beneficiary, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id := uuid.New()
+
+	err = g.app.Commands.CreateBeneficiary.Handle(ctx, &command.CreateBeneficiaryParam{
+		ID:            id,
+		MerchantID:    merchantID,
+		BankCode:      req.GetBankCode(),
+		AccountNumber: req.GetAccountNumber(),
+		HolderName:    req.GetHolderName(),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getBeneficiary(ctx, id, merchantID)
+}
+
+func (g GRPCServer) GetBeneficiary(
+	ctx context.Context,
+	req *protogen.GetBeneficiaryRequest,
+) (*protogen.Beneficiary, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseBeneficiaryID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getBeneficiary(ctx, id, merchantID)
+}
+
+func (g GRPCServer) ListBeneficiaries(
+	ctx context.Context,
+	req *protogen.ListBeneficiariesRequest,
+) (*protogen.ListBeneficiariesResponse, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	result, err := g.app.Queries.ListBeneficiaries.Handle(ctx, &query.ListBeneficiariesParam{
+		MerchantID: merchantID,
+		BankCode:   req.GetBankCode(),
+		Cursor:     req.GetCursor(),
+		Limit:      int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.ListBeneficiariesResponse{
+		Beneficiaries: lo.Map(result.Beneficiaries, func(b *disburse.Beneficiary, _ int) *protogen.Beneficiary {
+			return toBeneficiaryProto(b)
+		}),
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
+func (g GRPCServer) UpdateBeneficiary(
+	ctx context.Context,
+	req *protogen.BeneficiaryRequest,
+) (*protogen.Beneficiary, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseBeneficiaryID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.UpdateBeneficiary.Handle(ctx, &command.UpdateBeneficiaryParam{
+		ID:            id,
+		MerchantID:    merchantID,
+		BankCode:      req.GetBankCode(),
+		AccountNumber: req.GetAccountNumber(),
+		HolderName:    req.GetHolderName(),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getBeneficiary(ctx, id, merchantID)
+}
+
+func (g GRPCServer) DeleteBeneficiary(
+	ctx context.Context,
+	req *protogen.DeleteBeneficiaryRequest,
+) (*emptypb.Empty, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseBeneficiaryID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.DeleteBeneficiary.Handle(ctx, &command.DeleteBeneficiaryParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (g GRPCServer) getBeneficiary(ctx context.Context, id uuid.UUID, merchantID string) (*protogen.Beneficiary, error) {
+	beneficiary, err := g.app.Queries.GetBeneficiary.Handle(ctx, &query.GetBeneficiaryParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return toBeneficiaryProto(beneficiary), nil
+}
+
+func toBeneficiaryProto(b *disburse.Beneficiary) *protogen.Beneficiary {
+	return &protogen.Beneficiary{
+		Id:            b.ID().String(),
+		BankCode:      b.BankAccount().BankCode(),
+		AccountNumber: b.BankAccount().AccountNumber(),
+		HolderName:    b.HolderName(),
+		CreatedAt:     timestamppb.New(b.CreatedAt()),
+		UpdatedAt:     timestamppb.New(b.UpdatedAt()),
+	}
+}
+
+func parseBeneficiaryID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, errors.NewIncorrectInputError(
+			err,
+			"invalid beneficiary id",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return id, nil
+}
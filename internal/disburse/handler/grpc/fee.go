@@ -0,0 +1,54 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+)
+
+func (g GRPCServer) PreviewFee(
+	ctx context.Context,
+	req *protogen.PreviewFeeRequest,
+) (*protogen.FeePreview, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	var beneficiaryID uuid.UUID
+	if req.GetBeneficiaryId() != "" {
+		beneficiaryID, err = parseBeneficiaryID(req.GetBeneficiaryId())
+		if err != nil {
+			return nil, grpcerr.TransformToGRPCErr(err)
+		}
+	}
+
+	preview, err := g.app.Queries.PreviewFee.Handle(ctx, &query.PreviewFeeParam{
+		MerchantID:    merchantID,
+		Amount:        req.GetAmount(),
+		Currency:      req.GetCurrency(),
+		BeneficiaryID: beneficiaryID,
+		BankCode:      req.GetBankCode(),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	response := &protogen.FeePreview{
+		Amount:   preview.Amount.Decimal(),
+		Fee:      preview.Fee.Decimal(),
+		Total:    preview.Total.Decimal(),
+		Currency: preview.Amount.Currency().String(),
+	}
+
+	if preview.Schedule != nil {
+		response.FeeScheduleId = preview.Schedule.ID().String()
+		response.FeeScheduleVersion = int32(preview.Schedule.Version())
+	}
+
+	return response, nil
+}
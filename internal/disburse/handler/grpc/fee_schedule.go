@@ -0,0 +1,117 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+)
+
+func (g GRPCServer) GetFeeSchedule(
+	ctx context.Context,
+	req *protogen.GetFeeScheduleRequest,
+) (*protogen.FeeSchedule, error) {
+	_, err := handler.InternalUserIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	schedule, err := g.app.Queries.GetFeeSchedule.Handle(ctx, &query.GetFeeScheduleParam{
+		MerchantID: req.GetMerchantId(),
+		Currency:   req.GetCurrency(),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return toFeeScheduleProto(schedule), nil
+}
+
+func (g GRPCServer) CreateFeeSchedule(
+	ctx context.Context,
+	req *protogen.CreateFeeScheduleRequest,
+) (*protogen.CreateFeeScheduleResponse, error) {
+	_, err := handler.InternalUserIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	rules := make([]command.FeeRuleParam, 0, len(req.GetRules()))
+	for _, rule := range req.GetRules() {
+		tiers := make([]command.FeeTierParam, 0, len(rule.GetTiers()))
+		for _, tier := range rule.GetTiers() {
+			tiers = append(tiers, command.FeeTierParam{
+				UpTo: tier.GetUpTo(),
+				Flat: tier.GetFlat(),
+				Rate: tier.GetRate(),
+			})
+		}
+
+		rules = append(rules, command.FeeRuleParam{
+			BankCode: rule.GetBankCode(),
+			Type:     rule.GetType(),
+			Flat:     rule.GetFlat(),
+			Rate:     rule.GetRate(),
+			Tiers:    tiers,
+			Min:      rule.GetMin(),
+			Max:      rule.GetMax(),
+		})
+	}
+
+	param := &command.CreateFeeScheduleParam{
+		ID:         uuid.New(),
+		MerchantID: req.GetMerchantId(),
+		Currency:   req.GetCurrency(),
+		Rules:      rules,
+	}
+
+	if req.GetEffectiveFrom() != nil {
+		param.EffectiveFrom = req.GetEffectiveFrom().AsTime()
+	}
+
+	err = g.app.Commands.CreateFeeSchedule.Handle(ctx, param)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.CreateFeeScheduleResponse{FeeScheduleId: param.ID.String()}, nil
+}
+
+func toFeeScheduleProto(s *disburse.FeeSchedule) *protogen.FeeSchedule {
+	rules := make([]*protogen.FeeRule, 0, len(s.Rules()))
+	for _, rule := range s.Rules() {
+		tiers := make([]*protogen.FeeTier, 0, len(rule.Tiers()))
+		for _, tier := range rule.Tiers() {
+			tiers = append(tiers, &protogen.FeeTier{
+				UpTo: optionalAmount(tier.UpTo()),
+				Flat: optionalAmount(tier.Flat()),
+				Rate: tier.Rate(),
+			})
+		}
+
+		rules = append(rules, &protogen.FeeRule{
+			BankCode: rule.BankCode(),
+			Type:     rule.Type().String(),
+			Flat:     optionalAmount(rule.Flat()),
+			Rate:     rule.Rate(),
+			Tiers:    tiers,
+			Min:      optionalAmount(rule.Min()),
+			Max:      optionalAmount(rule.Max()),
+		})
+	}
+
+	return &protogen.FeeSchedule{
+		Id:            s.ID().String(),
+		MerchantId:    s.MerchantID(),
+		Currency:      s.Currency().String(),
+		Version:       int32(s.Version()),
+		EffectiveFrom: toTimestamp(s.EffectiveFrom()),
+		Rules:         rules,
+		CreatedAt:     toTimestamp(s.CreatedAt()),
+	}
+}
@@ -0,0 +1,138 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateFeeSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{
+			name: "internal user",
+			ctx:  context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+			code: codes.OK,
+		},
+		{
+			// a merchant can not lower its own fees
+			name: "merchant",
+			ctx:  context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createHandler := &fakeCommandHandler[*command.CreateFeeScheduleParam]{}
+
+			g := GRPCServer{app: &app.Application{
+				Commands: app.Commands{CreateFeeSchedule: createHandler},
+			}}
+
+			resp, err := g.CreateFeeSchedule(tt.ctx, &protogen.CreateFeeScheduleRequest{
+				MerchantId: "merchant-2",
+				Currency:   "IDR",
+				Rules: []*protogen.FeeRule{
+					{Type: "PERCENTAGE", Rate: 50, Min: "1000"},
+					{BankCode: "BCA", Type: "TIERED", Tiers: []*protogen.FeeTier{{UpTo: "1000000", Flat: "1000"}, {Rate: 10}}},
+				},
+			})
+			if status.Code(err) != tt.code {
+				t.Fatalf("CreateFeeSchedule error = %v, want %s", err, tt.code)
+			}
+
+			if tt.code != codes.OK {
+				if len(createHandler.calls) != 0 {
+					t.Errorf("CreateFeeSchedule called %d times, want 0", len(createHandler.calls))
+				}
+
+				return
+			}
+
+			if len(createHandler.calls) != 1 {
+				t.Fatalf("CreateFeeSchedule called %d times, want 1", len(createHandler.calls))
+			}
+
+			param := createHandler.calls[0]
+			if param.ID == uuid.Nil || resp.GetFeeScheduleId() != param.ID.String() {
+				t.Errorf("created schedule %s, response %q, want the id of the created schedule",
+					param.ID, resp.GetFeeScheduleId())
+			}
+
+			// a schedule without effective time applies right away
+			if !param.EffectiveFrom.IsZero() || len(param.Rules) != 2 {
+				t.Fatalf("schedule effective from %s with %d rules, want right away with 2", param.EffectiveFrom, len(param.Rules))
+			}
+
+			if rule := param.Rules[0]; rule.Type != "PERCENTAGE" || rule.Rate != 50 || rule.Min != "1000" {
+				t.Errorf("default rule = %+v, want 50 basis points with a min of 1000", rule)
+			}
+
+			if rule := param.Rules[1]; rule.BankCode != "BCA" || len(rule.Tiers) != 2 || rule.Tiers[1].Rate != 10 {
+				t.Errorf("BCA rule = %+v, want 2 tiers", rule)
+			}
+		})
+	}
+}
+
+func TestGetFeeSchedule(t *testing.T) {
+	currency, err := money.ParseCurrency("IDR")
+	if err != nil {
+		t.Fatalf("parse currency: %v", err)
+	}
+
+	flat, err := money.Parse("2500", "IDR")
+	if err != nil {
+		t.Fatalf("parse flat fee: %v", err)
+	}
+
+	schedule, err := disburse.NewFeeSchedule(uuid.New(), "merchant-1", currency, 3, time.Time{}, []disburse.FeeRule{
+		disburse.NewFeeRule("", disburse.FeeRuleFlat, flat, 0, nil, money.Money{}, money.Money{}),
+	})
+	if err != nil {
+		t.Fatalf("new fee schedule: %v", err)
+	}
+
+	getHandler := &fakeQueryHandler[*query.GetFeeScheduleParam, *disburse.FeeSchedule]{result: schedule}
+
+	g := GRPCServer{app: &app.Application{
+		Queries: app.Queries{GetFeeSchedule: getHandler},
+	}}
+
+	req := &protogen.GetFeeScheduleRequest{MerchantId: "merchant-1", Currency: "IDR"}
+
+	_, err = g.GetFeeSchedule(context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"), req)
+	if status.Code(err) != codes.PermissionDenied || len(getHandler.calls) != 0 {
+		t.Fatalf("GetFeeSchedule as merchant error = %v after %d calls, want permission denied",
+			err, len(getHandler.calls))
+	}
+
+	resp, err := g.GetFeeSchedule(context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"), req)
+	if err != nil {
+		t.Fatalf("GetFeeSchedule error = %v", err)
+	}
+
+	if resp.GetId() != schedule.ID().String() || resp.GetVersion() != 3 || len(resp.GetRules()) != 1 {
+		t.Fatalf("response = %+v, want version 3 of the schedule with 1 rule", resp)
+	}
+
+	// the fee of a flat rule is not capped
+	if rule := resp.GetRules()[0]; rule.GetType() != "FLAT" || rule.GetFlat() != "2500.00" || rule.GetMin() != "" {
+		t.Errorf("rule = %+v, want an uncapped flat fee of 2500.00", rule)
+	}
+}
@@ -0,0 +1,94 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (g GRPCServer) CreateFXQuote(
+	ctx context.Context,
+	req *protogen.CreateFXQuoteRequest,
+) (*protogen.FXQuote, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id := uuid.New()
+
+	err = g.app.Commands.CreateFXQuote.Handle(ctx, &command.CreateFXQuoteParam{
+		ID:             id,
+		MerchantID:     merchantID,
+		SourceCurrency: req.GetSourceCurrency(),
+		TargetCurrency: req.GetTargetCurrency(),
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getFXQuote(ctx, id, merchantID)
+}
+
+func (g GRPCServer) GetFXQuote(
+	ctx context.Context,
+	req *protogen.GetFXQuoteRequest,
+) (*protogen.FXQuote, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseFXQuoteID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getFXQuote(ctx, id, merchantID)
+}
+
+func (g GRPCServer) getFXQuote(ctx context.Context, id uuid.UUID, merchantID string) (*protogen.FXQuote, error) {
+	quote, err := g.app.Queries.GetFXQuote.Handle(ctx, &query.GetFXQuoteParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return toFXQuoteProto(quote), nil
+}
+
+func toFXQuoteProto(q *disburse.FXQuote) *protogen.FXQuote {
+	return &protogen.FXQuote{
+		Id:             q.ID().String(),
+		SourceCurrency: q.Rate().Source().String(),
+		TargetCurrency: q.Rate().Target().String(),
+		Rate:           q.Rate().Decimal(),
+		ExpiresAt:      timestamppb.New(q.ExpiresAt()),
+		DisbursementId: lo.Ternary(q.DisbursementID() != uuid.Nil, q.DisbursementID().String(), ""),
+		CreatedAt:      timestamppb.New(q.CreatedAt()),
+	}
+}
+
+func parseFXQuoteID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, errors.NewIncorrectInputError(
+			err,
+			"invalid fx quote id",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return id, nil
+}
@@ -2,14 +2,29 @@ package grpchandler
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// idempotencyKeyMetadata is the metadata key used when the request doesn't carry idempotency_key
+const idempotencyKeyMetadata = "idempotency-key"
+
 type GRPCServer struct {
 	app *app.Application
 }
@@ -21,13 +36,374 @@ func NewGrpcServer(
 	return GRPCServer{app: application}
 }
 
-func (g GRPCServer) Disburse(ctx context.Context, req *protogen.DisburseRequest) (*emptypb.Empty, error) {
-	err := g.app.Commands.Disburse.Handle(ctx, &command.DisburseParam{
-		Amount: req.Amount,
+func (g GRPCServer) Disburse(ctx context.Context, req *protogen.DisburseRequest) (*protogen.DisburseResponse, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	var beneficiaryID uuid.UUID
+	if req.GetBeneficiaryId() != "" {
+		beneficiaryID, err = parseBeneficiaryID(req.GetBeneficiaryId())
+		if err != nil {
+			return nil, grpcerr.TransformToGRPCErr(err)
+		}
+	}
+
+	var fxQuoteID uuid.UUID
+	if req.GetFxQuoteId() != "" {
+		fxQuoteID, err = parseFXQuoteID(req.GetFxQuoteId())
+		if err != nil {
+			return nil, grpcerr.TransformToGRPCErr(err)
+		}
+	}
+
+	idempotencyKey := getIdempotencyKey(ctx, req.GetIdempotencyKey())
+	disbursementID := disburse.NewDisbursementID(merchantID, idempotencyKey)
+
+	// a request of the merchant without user is created by the merchant itself
+	user, _ := handler.UserFromContext(ctx)
+
+	err = g.app.Commands.Disburse.Handle(ctx, &command.DisburseParam{
+		ID:             disbursementID,
+		MerchantID:     merchantID,
+		IdempotencyKey: idempotencyKey,
+		Amount:         req.GetAmount(),
+		Currency:       req.GetCurrency(),
+		BeneficiaryID:  beneficiaryID,
+		FXQuoteID:      fxQuoteID,
+		CreatedBy:      user.ID,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.DisburseResponse{
+		DisbursementId: disbursementID.String(),
+	}, nil
+}
+
+func (g GRPCServer) GetDisbursement(
+	ctx context.Context,
+	req *protogen.GetDisbursementRequest,
+) (*protogen.Disbursement, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseDisbursementID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getDisbursement(ctx, id, merchantID)
+}
+
+func (g GRPCServer) ListDisbursements(
+	ctx context.Context,
+	req *protogen.ListDisbursementsRequest,
+) (*protogen.ListDisbursementsResponse, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	param := &query.ListDisbursementsParam{
+		MerchantID: merchantID,
+		Statuses:   req.GetStatuses(),
+		MinAmount:  req.GetMinAmount(),
+		MaxAmount:  req.GetMaxAmount(),
+		Currency:   req.GetCurrency(),
+		Cursor:     req.GetCursor(),
+		Limit:      int(req.GetLimit()),
+	}
+
+	if req.GetCreatedFrom() != nil {
+		param.CreatedFrom = lo.ToPtr(req.GetCreatedFrom().AsTime())
+	}
+
+	if req.GetCreatedTo() != nil {
+		param.CreatedTo = lo.ToPtr(req.GetCreatedTo().AsTime())
+	}
+
+	result, err := g.app.Queries.ListDisbursements.Handle(ctx, param)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.ListDisbursementsResponse{
+		Disbursements: lo.Map(result.Disbursements, func(d *disburse.Disbursement, _ int) *protogen.Disbursement {
+			return toProto(d)
+		}),
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
+func (g GRPCServer) CancelDisbursement(
+	ctx context.Context,
+	req *protogen.DisbursementActionRequest,
+) (*protogen.Disbursement, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseDisbursementID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.CancelDisbursement.Handle(ctx, &command.CancelDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+		Reason:     req.GetReason(),
+		Actor:      merchantID,
+		Source:     disburse.AuditSourceGRPC,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getDisbursement(ctx, id, merchantID)
+}
+
+func (g GRPCServer) ReverseDisbursement(
+	ctx context.Context,
+	req *protogen.DisbursementActionRequest,
+) (*protogen.Disbursement, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := parseDisbursementID(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.ReverseDisbursement.Handle(ctx, &command.ReverseDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+		Reason:     req.GetReason(),
+		Actor:      merchantID,
+		Source:     disburse.AuditSourceGRPC,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getDisbursement(ctx, id, merchantID)
+}
+
+func (g GRPCServer) getDisbursement(ctx context.Context, id uuid.UUID, merchantID string) (*protogen.Disbursement, error) {
+	disbursement, err := g.app.Queries.GetDisbursement.Handle(ctx, &query.GetDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
 	})
 	if err != nil {
 		return nil, grpcerr.TransformToGRPCErr(err)
 	}
 
-	return &emptypb.Empty{}, nil
+	return toProto(disbursement), nil
+}
+
+func (g GRPCServer) DisburseBatch(
+	ctx context.Context,
+	req *protogen.DisburseBatchRequest,
+) (*protogen.DisburseBatchResponse, error) {
+	resp, err := g.disburseBatch(ctx, getIdempotencyKey(ctx, req.GetIdempotencyKey()), req.GetItems())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return resp, nil
+}
+
+func (g GRPCServer) StreamDisburseBatch(stream protogen.DisbursementService_StreamDisburseBatchServer) error {
+	ctx := stream.Context()
+
+	var items []*protogen.DisburseBatchItem
+
+	for {
+		item, err := stream.Recv()
+		if stderrors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// stop reading early instead of buffering a batch that is rejected anyway
+		if len(items) == disburse.MaxBatchItems {
+			return grpcerr.TransformToGRPCErr(
+				errors.NewIncorrectInputError(
+					disburse.ErrBatchTooLarge,
+					fmt.Sprintf("%s, max %d items", disburse.ErrBatchTooLarge.Error(), disburse.MaxBatchItems),
+					errors.DpayInvalidRequest,
+				),
+			)
+		}
+
+		items = append(items, item)
+	}
+
+	resp, err := g.disburseBatch(ctx, getIdempotencyKey(ctx, ""), items)
+	if err != nil {
+		return grpcerr.TransformToGRPCErr(err)
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+func (g GRPCServer) disburseBatch(
+	ctx context.Context,
+	idempotencyKey string,
+	items []*protogen.DisburseBatchItem,
+) (*protogen.DisburseBatchResponse, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batchID := disburse.NewBatchID(merchantID, idempotencyKey)
+
+	// a request of the merchant without user is created by the merchant itself
+	user, _ := handler.UserFromContext(ctx)
+
+	err = g.app.Commands.DisburseBatch.Handle(ctx, &command.DisburseBatchParam{
+		ID:             batchID,
+		MerchantID:     merchantID,
+		IdempotencyKey: idempotencyKey,
+		Items: lo.Map(items, func(item *protogen.DisburseBatchItem, _ int) command.DisburseBatchItem {
+			return command.DisburseBatchItem{
+				Amount:   item.GetAmount(),
+				Currency: item.GetCurrency(),
+			}
+		}),
+		CreatedBy: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protogen.DisburseBatchResponse{
+		BatchId: batchID.String(),
+		Items: lo.Times(len(items), func(i int) *protogen.DisburseBatchItemResult {
+			return &protogen.DisburseBatchItemResult{
+				Index:          int32(i),
+				DisbursementId: disburse.NewBatchItemID(batchID, i).String(),
+			}
+		}),
+	}, nil
+}
+
+func (g GRPCServer) GetDisbursementBatch(
+	ctx context.Context,
+	req *protogen.GetDisbursementBatchRequest,
+) (*protogen.DisbursementBatch, error) {
+	merchantID, err := handler.MerchantIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(
+			errors.NewIncorrectInputError(
+				err,
+				"invalid batch id",
+				errors.DpayInvalidRequest,
+			),
+		)
+	}
+
+	progress, err := g.app.Queries.GetDisbursementBatch.Handle(ctx, &query.GetDisbursementBatchParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.DisbursementBatch{
+		Id:             progress.Batch.ID().String(),
+		ItemCount:      int32(progress.Batch.ItemCount()),
+		CompletedCount: int32(progress.CompletedCount()),
+		StatusCounts: lo.MapEntries(progress.StatusCounts, func(status disburse.Status, count int) (string, int32) {
+			return status.String(), int32(count)
+		}),
+		CreatedAt: timestamppb.New(progress.Batch.CreatedAt()),
+	}, nil
+}
+
+func toProto(d *disburse.Disbursement) *protogen.Disbursement {
+	return &protogen.Disbursement{
+		Id:            d.ID().String(),
+		Amount:        d.Amount().Decimal(),
+		Currency:      d.Amount().Currency().String(),
+		Status:        d.Status().String(),
+		BeneficiaryId: lo.Ternary(d.BeneficiaryID() != uuid.Nil, d.BeneficiaryID().String(), ""),
+		FailureReason: d.FailureReason(),
+		CreatedAt:     timestamppb.New(d.CreatedAt()),
+		UpdatedAt:     timestamppb.New(d.UpdatedAt()),
+		ProcessedAt:   toTimestamp(d.ProcessedAt()),
+		CompletedAt:   toTimestamp(d.CompletedAt()),
+
+		RequiredApprovals: int32(d.RequiredApprovals()),
+		ApprovalDeadline:  toTimestamp(d.ApprovalDeadline()),
+
+		Fee:           d.Fee().Decimal(),
+		FeeScheduleId: lo.Ternary(d.FeeScheduleID() != uuid.Nil, d.FeeScheduleID().String(), ""),
+
+		FundingCurrency: d.FundingCurrency().String(),
+		FxQuoteId:       lo.Ternary(d.FXQuoteID() != uuid.Nil, d.FXQuoteID().String(), ""),
+		FxRate:          lo.Ternary(d.FXRate().IsZero(), "", d.FXRate().Decimal()),
+	}
+}
+
+// toTimestamp keeps the zero time unset instead of sending 0001-01-01
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func parseDisbursementID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, errors.NewIncorrectInputError(
+			err,
+			"invalid disbursement id",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return id, nil
+}
+
+// getIdempotencyKey prefers the key from the request body and falls back to the incoming metadata
+func getIdempotencyKey(ctx context.Context, fromRequest string) string {
+	if fromRequest != "" {
+		return fromRequest
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, idempotencyKeyMetadata)
+	if len(values) != 1 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// optionalAmount is the decimal amount, empty when it is zero like a rule that is not limited or capped
+func optionalAmount(amount money.Money) string {
+	if amount.IsZero() {
+		return ""
+	}
+
+	return amount.Decimal()
 }
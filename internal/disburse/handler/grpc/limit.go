@@ -0,0 +1,74 @@
+package grpchandler
+
+import (
+	"context"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/grpcerr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+)
+
+func (g GRPCServer) GetMerchantLimits(
+	ctx context.Context,
+	req *protogen.GetMerchantLimitsRequest,
+) (*protogen.MerchantLimits, error) {
+	_, err := handler.InternalUserIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getMerchantLimits(ctx, req.GetMerchantId(), req.GetCurrency())
+}
+
+func (g GRPCServer) SetMerchantLimits(
+	ctx context.Context,
+	req *protogen.SetMerchantLimitsRequest,
+) (*protogen.MerchantLimits, error) {
+	_, err := handler.InternalUserIDFromContext(ctx)
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	err = g.app.Commands.SetMerchantLimits.Handle(ctx, &command.SetMerchantLimitsParam{
+		MerchantID:     req.GetMerchantId(),
+		Currency:       req.GetCurrency(),
+		PerTransaction: req.GetPerTransaction(),
+		DailyTotal:     req.GetDailyTotal(),
+		MonthlyTotal:   req.GetMonthlyTotal(),
+		MaxCount:       int(req.GetMaxCount()),
+		CountWindow:    time.Duration(req.GetCountWindowSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return g.getMerchantLimits(ctx, req.GetMerchantId(), req.GetCurrency())
+}
+
+func (g GRPCServer) getMerchantLimits(
+	ctx context.Context,
+	merchantID string,
+	currency string,
+) (*protogen.MerchantLimits, error) {
+	limits, err := g.app.Queries.GetMerchantLimits.Handle(ctx, &query.GetMerchantLimitsParam{
+		MerchantID: merchantID,
+		Currency:   currency,
+	})
+	if err != nil {
+		return nil, grpcerr.TransformToGRPCErr(err)
+	}
+
+	return &protogen.MerchantLimits{
+		MerchantId:         merchantID,
+		Currency:           limits.Limits.Currency().String(),
+		PerTransaction:     optionalAmount(limits.Limits.PerTransaction()),
+		DailyTotal:         optionalAmount(limits.Limits.DailyTotal()),
+		MonthlyTotal:       optionalAmount(limits.Limits.MonthlyTotal()),
+		MaxCount:           int32(limits.Limits.MaxCount()),
+		CountWindowSeconds: int64(limits.Limits.CountWindow() / time.Second),
+		IsDefault:          limits.IsDefault,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetMerchantLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{
+			name: "internal user",
+			ctx:  context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+			code: codes.OK,
+		},
+		{
+			// a merchant can not raise its own limits
+			name: "merchant",
+			ctx:  context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setHandler := &fakeCommandHandler[*command.SetMerchantLimitsParam]{}
+			getHandler := &fakeQueryHandler[*query.GetMerchantLimitsParam, *query.MerchantLimits]{
+				result: &query.MerchantLimits{Limits: testLimits(t)},
+			}
+
+			g := GRPCServer{app: &app.Application{
+				Commands: app.Commands{SetMerchantLimits: setHandler},
+				Queries:  app.Queries{GetMerchantLimits: getHandler},
+			}}
+
+			resp, err := g.SetMerchantLimits(tt.ctx, &protogen.SetMerchantLimitsRequest{
+				MerchantId:         "merchant-2",
+				Currency:           "IDR",
+				PerTransaction:     "50000000",
+				MaxCount:           100,
+				CountWindowSeconds: 3600,
+			})
+			if status.Code(err) != tt.code {
+				t.Fatalf("SetMerchantLimits error = %v, want %s", err, tt.code)
+			}
+
+			if tt.code != codes.OK {
+				if len(setHandler.calls) != 0 {
+					t.Errorf("SetMerchantLimits called %d times, want 0", len(setHandler.calls))
+				}
+
+				return
+			}
+
+			if len(setHandler.calls) != 1 {
+				t.Fatalf("SetMerchantLimits called %d times, want 1", len(setHandler.calls))
+			}
+
+			param := setHandler.calls[0]
+			if param.MerchantID != "merchant-2" || param.PerTransaction != "50000000" || param.CountWindow != time.Hour {
+				t.Errorf("SetMerchantLimits param = %+v, want merchant-2 limited to 50000000 and 100 per hour", param)
+			}
+
+			// a rule that is not limited is left empty
+			if resp.GetPerTransaction() != "50000000.00" || resp.GetDailyTotal() != "" || resp.GetCountWindowSeconds() != 3600 {
+				t.Errorf("response = %+v, want the limits read back", resp)
+			}
+		})
+	}
+}
+
+func TestGetMerchantLimits(t *testing.T) {
+	getHandler := &fakeQueryHandler[*query.GetMerchantLimitsParam, *query.MerchantLimits]{
+		result: &query.MerchantLimits{Limits: testLimits(t), IsDefault: true},
+	}
+
+	g := GRPCServer{app: &app.Application{
+		Queries: app.Queries{GetMerchantLimits: getHandler},
+	}}
+
+	req := &protogen.GetMerchantLimitsRequest{MerchantId: "merchant-1", Currency: "IDR"}
+
+	_, err := g.GetMerchantLimits(context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"), req)
+	if status.Code(err) != codes.PermissionDenied || len(getHandler.calls) != 0 {
+		t.Fatalf("GetMerchantLimits as merchant error = %v after %d calls, want permission denied",
+			err, len(getHandler.calls))
+	}
+
+	resp, err := g.GetMerchantLimits(context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"), req)
+	if err != nil {
+		t.Fatalf("GetMerchantLimits error = %v", err)
+	}
+
+	if resp.GetMerchantId() != "merchant-1" || resp.GetCurrency() != "IDR" || !resp.GetIsDefault() {
+		t.Errorf("response = %+v, want the default limits of merchant-1 in IDR", resp)
+	}
+}
+
+func testLimits(t *testing.T) disburse.Limits {
+	t.Helper()
+
+	currency, err := money.ParseCurrency("IDR")
+	if err != nil {
+		t.Fatalf("parse currency: %v", err)
+	}
+
+	perTransaction, err := money.Parse("50000000", "IDR")
+	if err != nil {
+		t.Fatalf("parse amount: %v", err)
+	}
+
+	limits, err := disburse.NewLimits(currency, perTransaction, money.Money{}, money.Money{}, 100, time.Hour)
+	if err != nil {
+		t.Fatalf("new limits: %v", err)
+	}
+
+	return limits
+}
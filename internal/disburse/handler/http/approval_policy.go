@@ -0,0 +1,85 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+)
+
+// (GET /admin/merchants/{merchant_id}/approval-policies/{currency})
+func (h httpServer) GetApprovalPolicy(w http.ResponseWriter, r *http.Request, merchantID MerchantID, currency Currency) {
+	_, err := handler.InternalUserIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithApprovalPolicy(w, r, merchantID, currency)
+}
+
+// (PUT /admin/merchants/{merchant_id}/approval-policies/{currency})
+func (h httpServer) SetApprovalPolicy(w http.ResponseWriter, r *http.Request, merchantID MerchantID, currency Currency) {
+	_, err := handler.InternalUserIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	var body SetApprovalPolicyJSONRequestBody
+
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	err = h.app.Commands.SetApprovalPolicy.Handle(r.Context(), &command.SetApprovalPolicyParam{
+		MerchantID:        merchantID,
+		Currency:          currency,
+		Threshold:         body.Threshold,
+		ApproverRoles:     body.ApproverRoles,
+		RequiredApprovals: body.RequiredApprovals,
+		ApprovalWindow:    time.Duration(body.ApprovalWindowSeconds) * time.Second,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithApprovalPolicy(w, r, merchantID, currency)
+}
+
+func (h httpServer) respondWithApprovalPolicy(w http.ResponseWriter, r *http.Request, merchantID string, currency string) {
+	policy, err := h.app.Queries.GetApprovalPolicy.Handle(r.Context(), &query.GetApprovalPolicyParam{
+		MerchantID: merchantID,
+		Currency:   currency,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, ApprovalPolicy{
+		MerchantId:            merchantID,
+		Currency:              policy.Currency().String(),
+		Threshold:             policy.Threshold().Decimal(),
+		ApproverRoles:         policy.ApproverRoles(),
+		RequiredApprovals:     policy.RequiredApprovals(),
+		ApprovalWindowSeconds: int(policy.Window() / time.Second),
+	})
+}
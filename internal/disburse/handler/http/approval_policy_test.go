@@ -0,0 +1,126 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+)
+
+func TestSetApprovalPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+
+		// set tells the policy is replaced, a caller other than an internal user is forbidden
+		set bool
+	}{
+		{
+			name: "internal user",
+			ctx:  context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+			set:  true,
+		},
+		{
+			// a merchant can not drop the approvals its disbursements need
+			name: "merchant",
+			ctx:  context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+		},
+		{
+			name: "user of the merchant",
+			ctx: context.WithValue(
+				context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+				constants.UserIDKey, "user-1",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setHandler := &fakeCommandHandler[*command.SetApprovalPolicyParam]{}
+			getHandler := &fakeQueryHandler[*query.GetApprovalPolicyParam, disburse.ApprovalPolicy]{}
+
+			h := httpServer{app: &app.Application{
+				Commands: app.Commands{SetApprovalPolicy: setHandler},
+				Queries:  app.Queries{GetApprovalPolicy: getHandler},
+			}}
+
+			r := httptest.NewRequest(
+				http.MethodPut,
+				"/admin/merchants/merchant-2/approval-policies/IDR",
+				strings.NewReader(`{"threshold":"100000000","approver_roles":["finance_manager"],`+
+					`"required_approvals":2,"approval_window_seconds":86400}`),
+			).WithContext(tt.ctx)
+
+			h.SetApprovalPolicy(httptest.NewRecorder(), r, "merchant-2", "IDR")
+
+			if !tt.set {
+				if len(setHandler.calls) != 0 || len(getHandler.calls) != 0 {
+					t.Errorf("policy set %d and read %d times, want 0", len(setHandler.calls), len(getHandler.calls))
+				}
+
+				return
+			}
+
+			if len(setHandler.calls) != 1 {
+				t.Fatalf("SetApprovalPolicy called %d times, want 1", len(setHandler.calls))
+			}
+
+			param := setHandler.calls[0]
+			if param.MerchantID != "merchant-2" || param.Currency != "IDR" || param.Threshold != "100000000" {
+				t.Errorf("policy set for %s in %s above %s, want merchant-2 in IDR above 100000000",
+					param.MerchantID, param.Currency, param.Threshold)
+			}
+
+			if len(param.ApproverRoles) != 1 || param.ApproverRoles[0] != "finance_manager" ||
+				param.RequiredApprovals != 2 || param.ApprovalWindow != 24*time.Hour {
+				t.Errorf("policy = %v roles, %d approvals within %s, want 2 finance_manager approvals within 24h",
+					param.ApproverRoles, param.RequiredApprovals, param.ApprovalWindow)
+			}
+
+			// the response is the policy read back after it is set
+			if len(getHandler.calls) != 1 || getHandler.calls[0].MerchantID != "merchant-2" {
+				t.Errorf("GetApprovalPolicy calls = %+v, want one for merchant-2", getHandler.calls)
+			}
+		})
+	}
+}
+
+func TestGetApprovalPolicy(t *testing.T) {
+	getHandler := &fakeQueryHandler[*query.GetApprovalPolicyParam, disburse.ApprovalPolicy]{}
+
+	h := httpServer{app: &app.Application{
+		Queries: app.Queries{GetApprovalPolicy: getHandler},
+	}}
+
+	merchantCtx := context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1")
+	r := httptest.NewRequest(http.MethodGet, "/admin/merchants/merchant-1/approval-policies/IDR", nil).
+		WithContext(merchantCtx)
+
+	h.GetApprovalPolicy(httptest.NewRecorder(), r, "merchant-1", "IDR")
+
+	if len(getHandler.calls) != 0 {
+		t.Fatalf("GetApprovalPolicy called %d times for a merchant, want 0", len(getHandler.calls))
+	}
+
+	internalUserCtx := context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1")
+	r = httptest.NewRequest(http.MethodGet, "/admin/merchants/merchant-1/approval-policies/IDR", nil).
+		WithContext(internalUserCtx)
+
+	h.GetApprovalPolicy(httptest.NewRecorder(), r, "merchant-1", "IDR")
+
+	if len(getHandler.calls) != 1 {
+		t.Fatalf("GetApprovalPolicy called %d times for an internal user, want 1", len(getHandler.calls))
+	}
+
+	if param := getHandler.calls[0]; param.MerchantID != "merchant-1" || param.Currency != "IDR" {
+		t.Errorf("policy read for %s in %s, want merchant-1 in IDR", param.MerchantID, param.Currency)
+	}
+}
@@ -0,0 +1,183 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// (GET /beneficiaries)
+func (h httpServer) ListBeneficiaries(w http.ResponseWriter, r *http.Request, params ListBeneficiariesParams) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	result, err := h.app.Queries.ListBeneficiaries.Handle(r.Context(), &query.ListBeneficiariesParam{
+		MerchantID: merchantID,
+		BankCode:   lo.FromPtr(params.BankCode),
+		Cursor:     lo.FromPtr(params.Cursor),
+		Limit:      lo.FromPtr(params.Limit),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, ListBeneficiariesResponse{
+		Data: lo.Map(result.Beneficiaries, func(b *disburse.Beneficiary, _ int) Beneficiary {
+			return toBeneficiaryResponse(b)
+		}),
+		NextCursor: lo.EmptyableToPtr(result.NextCursor),
+	})
+}
+
+// (POST /beneficiaries)
+func (h httpServer) CreateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeBeneficiaryBody(w, r)
+	if !ok {
+		return
+	}
+
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	beneficiaryID := uuid.New()
+
+	err = h.app.Commands.CreateBeneficiary.Handle(r.Context(), &command.CreateBeneficiaryParam{
+		ID:            beneficiaryID,
+		MerchantID:    merchantID,
+		BankCode:      body.BankCode,
+		AccountNumber: body.AccountNumber,
+		HolderName:    lo.FromPtr(body.HolderName),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithBeneficiary(w, r, http.StatusCreated, beneficiaryID, merchantID)
+}
+
+// (GET /beneficiaries/{id})
+func (h httpServer) GetBeneficiary(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithBeneficiary(w, r, http.StatusOK, id, merchantID)
+}
+
+// (PUT /beneficiaries/{id})
+func (h httpServer) UpdateBeneficiary(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	body, ok := decodeBeneficiaryBody(w, r)
+	if !ok {
+		return
+	}
+
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.UpdateBeneficiary.Handle(r.Context(), &command.UpdateBeneficiaryParam{
+		ID:            id,
+		MerchantID:    merchantID,
+		BankCode:      body.BankCode,
+		AccountNumber: body.AccountNumber,
+		HolderName:    lo.FromPtr(body.HolderName),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithBeneficiary(w, r, http.StatusOK, id, merchantID)
+}
+
+// (DELETE /beneficiaries/{id})
+func (h httpServer) DeleteBeneficiary(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.DeleteBeneficiary.Handle(r.Context(), &command.DeleteBeneficiaryParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h httpServer) respondWithBeneficiary(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	id uuid.UUID,
+	merchantID string,
+) {
+	beneficiary, err := h.app.Queries.GetBeneficiary.Handle(r.Context(), &query.GetBeneficiaryParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, status, toBeneficiaryResponse(beneficiary))
+}
+
+func decodeBeneficiaryBody(w http.ResponseWriter, r *http.Request) (BeneficiaryBody, bool) {
+	var body BeneficiaryBody
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return BeneficiaryBody{}, false
+	}
+
+	return body, true
+}
+
+func toBeneficiaryResponse(b *disburse.Beneficiary) Beneficiary {
+	return Beneficiary{
+		Id:            b.ID(),
+		BankCode:      b.BankAccount().BankCode(),
+		AccountNumber: b.BankAccount().AccountNumber(),
+		HolderName:    b.HolderName(),
+		CreatedAt:     b.CreatedAt(),
+		UpdatedAt:     b.UpdatedAt(),
+	}
+}
@@ -0,0 +1,205 @@
+package httphandler
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// (POST /disbursements/{id}/cancel)
+func (h httpServer) CancelDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	body, err := decodeDisbursementActionBody(r)
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.CancelDisbursement.Handle(r.Context(), &command.CancelDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+		Reason:     lo.FromPtr(body.Reason),
+		Actor:      merchantID,
+		Source:     disburse.AuditSourceHTTP,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithDisbursement(w, r, id, merchantID)
+}
+
+// (POST /disbursements/{id}/reverse)
+func (h httpServer) ReverseDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	body, err := decodeDisbursementActionBody(r)
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.ReverseDisbursement.Handle(r.Context(), &command.ReverseDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+		Reason:     lo.FromPtr(body.Reason),
+		Actor:      merchantID,
+		Source:     disburse.AuditSourceHTTP,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithDisbursement(w, r, id, merchantID)
+}
+
+// (POST /disbursements/{id}/approve)
+func (h httpServer) ApproveDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	approver, err := handler.ApproverFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.ApproveDisbursement.Handle(r.Context(), &command.ApproveDisbursementParam{
+		ID:           id,
+		MerchantID:   merchantID,
+		ApproverID:   approver.ID,
+		ApproverRole: approver.Role,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithDisbursement(w, r, id, merchantID)
+}
+
+// (POST /disbursements/{id}/reject)
+func (h httpServer) RejectDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	approver, err := handler.ApproverFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	body, err := decodeDisbursementActionBody(r)
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.RejectDisbursement.Handle(r.Context(), &command.RejectDisbursementParam{
+		ID:           id,
+		MerchantID:   merchantID,
+		ApproverID:   approver.ID,
+		ApproverRole: approver.Role,
+		Reason:       lo.FromPtr(body.Reason),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithDisbursement(w, r, id, merchantID)
+}
+
+// (GET /disbursements/{id}/approvals)
+func (h httpServer) ListDisbursementApprovals(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	steps, err := h.app.Queries.GetDisbursementApprovals.Handle(r.Context(), &query.GetDisbursementApprovalsParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, ListDisbursementApprovalsResponse{
+		Data: lo.Map(steps, func(s *disburse.ApprovalStep, _ int) DisbursementApproval {
+			return DisbursementApproval{
+				Id:        s.ID(),
+				Action:    DisbursementApprovalAction(s.Action()),
+				Actor:     s.Actor(),
+				Role:      lo.EmptyableToPtr(s.Role()),
+				Reason:    lo.EmptyableToPtr(s.Reason()),
+				CreatedAt: s.CreatedAt(),
+			}
+		}),
+	})
+}
+
+// decodeDisbursementActionBody decodes the optional body of an action, an empty body has no reason
+func decodeDisbursementActionBody(r *http.Request) (DisbursementActionRequest, error) {
+	var body DisbursementActionRequest
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil && !stderrors.Is(err, io.EOF) {
+		return body, errors.NewIncorrectInputError(
+			dcerrors.ErrReadingRequestBody,
+			dcerrors.ErrReadingRequestBody.Error(),
+			dcerrors.DpayInvalidRequest,
+		)
+	}
+
+	return body, nil
+}
+
+func (h httpServer) respondWithDisbursement(
+	w http.ResponseWriter,
+	r *http.Request,
+	id openapi_types.UUID,
+	merchantID string,
+) {
+	disbursement, err := h.app.Queries.GetDisbursement.Handle(r.Context(), &query.GetDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, toResponse(disbursement))
+}
@@ -0,0 +1,47 @@
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	"github.com/samber/lo"
+)
+
+// (GET /fees/preview)
+func (h httpServer) PreviewFee(w http.ResponseWriter, r *http.Request, params PreviewFeeParams) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	preview, err := h.app.Queries.PreviewFee.Handle(r.Context(), &query.PreviewFeeParam{
+		MerchantID:    merchantID,
+		Amount:        params.Amount,
+		Currency:      params.Currency,
+		BeneficiaryID: lo.FromPtr(params.BeneficiaryId),
+		BankCode:      lo.FromPtr(params.BankCode),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	response := FeePreview{
+		Amount:   preview.Amount.Decimal(),
+		Fee:      preview.Fee.Decimal(),
+		Total:    preview.Total.Decimal(),
+		Currency: preview.Amount.Currency().String(),
+	}
+
+	if preview.Schedule != nil {
+		response.FeeScheduleId = lo.ToPtr[uuid.UUID](preview.Schedule.ID())
+		response.FeeScheduleVersion = lo.ToPtr(preview.Schedule.Version())
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, response)
+}
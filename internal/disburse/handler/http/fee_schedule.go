@@ -0,0 +1,147 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	"github.com/samber/lo"
+)
+
+// (GET /admin/merchants/{merchant_id}/fee-schedules/{currency})
+func (h httpServer) GetFeeSchedule(w http.ResponseWriter, r *http.Request, merchantID MerchantID, currency Currency) {
+	_, err := handler.InternalUserIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	schedule, err := h.app.Queries.GetFeeSchedule.Handle(r.Context(), &query.GetFeeScheduleParam{
+		MerchantID: merchantID,
+		Currency:   currency,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, toFeeScheduleResponse(schedule))
+}
+
+// (POST /admin/merchants/{merchant_id}/fee-schedules/{currency})
+func (h httpServer) CreateFeeSchedule(w http.ResponseWriter, r *http.Request, merchantID MerchantID, currency Currency) {
+	_, err := handler.InternalUserIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	var body CreateFeeScheduleJSONRequestBody
+
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	rules := make([]command.FeeRuleParam, 0, len(body.Rules))
+	for _, rule := range body.Rules {
+		tiers := make([]command.FeeTierParam, 0, len(lo.FromPtr(rule.Tiers)))
+		for _, tier := range lo.FromPtr(rule.Tiers) {
+			tiers = append(tiers, command.FeeTierParam{
+				UpTo: lo.FromPtr(tier.UpTo),
+				Flat: lo.FromPtr(tier.Flat),
+				Rate: lo.FromPtr(tier.Rate),
+			})
+		}
+
+		rules = append(rules, command.FeeRuleParam{
+			BankCode: lo.FromPtr(rule.BankCode),
+			Type:     string(rule.Type),
+			Flat:     lo.FromPtr(rule.Flat),
+			Rate:     lo.FromPtr(rule.Rate),
+			Tiers:    tiers,
+			Min:      lo.FromPtr(rule.Min),
+			Max:      lo.FromPtr(rule.Max),
+		})
+	}
+
+	scheduleID := uuid.New()
+
+	err = h.app.Commands.CreateFeeSchedule.Handle(r.Context(), &command.CreateFeeScheduleParam{
+		ID:            scheduleID,
+		MerchantID:    merchantID,
+		Currency:      currency,
+		EffectiveFrom: lo.FromPtr(body.EffectiveFrom),
+		Rules:         rules,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusCreated, FeeScheduleCreatedResponse{
+		Message:       "Fee schedule created.",
+		FeeScheduleId: scheduleID,
+	})
+}
+
+func toFeeScheduleResponse(s *disburse.FeeSchedule) FeeSchedule {
+	rules := make([]FeeRule, 0, len(s.Rules()))
+	for _, rule := range s.Rules() {
+		response := FeeRule{
+			Type: FeeRuleType(rule.Type()),
+			Flat: optionalAmount(rule.Flat()),
+			Min:  optionalAmount(rule.Min()),
+			Max:  optionalAmount(rule.Max()),
+		}
+
+		if rule.BankCode() != "" {
+			response.BankCode = lo.ToPtr(rule.BankCode())
+		}
+
+		if rule.Rate() != 0 {
+			response.Rate = lo.ToPtr(rule.Rate())
+		}
+
+		if len(rule.Tiers()) > 0 {
+			tiers := make([]FeeTier, 0, len(rule.Tiers()))
+			for _, tier := range rule.Tiers() {
+				tiers = append(tiers, FeeTier{
+					UpTo: optionalAmount(tier.UpTo()),
+					Flat: optionalAmount(tier.Flat()),
+					Rate: lo.ToPtr(tier.Rate()),
+				})
+			}
+
+			response.Tiers = &tiers
+		}
+
+		rules = append(rules, response)
+	}
+
+	return FeeSchedule{
+		Id:            s.ID(),
+		MerchantId:    s.MerchantID(),
+		Currency:      s.Currency().String(),
+		Version:       s.Version(),
+		EffectiveFrom: s.EffectiveFrom(),
+		Rules:         rules,
+		CreatedAt:     s.CreatedAt(),
+	}
+}
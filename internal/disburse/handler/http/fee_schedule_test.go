@@ -0,0 +1,93 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+)
+
+func TestCreateFeeSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+
+		// created tells the schedule is created, a caller other than an internal user is forbidden
+		created bool
+	}{
+		{
+			name:    "internal user",
+			ctx:     context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+			created: true,
+		},
+		{
+			// a merchant can not lower its own fees
+			name: "merchant",
+			ctx:  context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createHandler := &fakeCommandHandler[*command.CreateFeeScheduleParam]{}
+
+			h := httpServer{app: &app.Application{
+				Commands: app.Commands{CreateFeeSchedule: createHandler},
+			}}
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/admin/merchants/merchant-2/fee-schedules/IDR",
+				strings.NewReader(`{"rules":[`+
+					`{"type":"FLAT","flat":"2500"},`+
+					`{"bank_code":"BCA","type":"TIERED","tiers":[{"up_to":"1000000","flat":"1000"},{"rate":10}],"max":"5000"}`+
+					`]}`),
+			).WithContext(tt.ctx)
+
+			h.CreateFeeSchedule(httptest.NewRecorder(), r, "merchant-2", "IDR")
+
+			if !tt.created {
+				if len(createHandler.calls) != 0 {
+					t.Errorf("CreateFeeSchedule called %d times, want 0", len(createHandler.calls))
+				}
+
+				return
+			}
+
+			if len(createHandler.calls) != 1 {
+				t.Fatalf("CreateFeeSchedule called %d times, want 1", len(createHandler.calls))
+			}
+
+			param := createHandler.calls[0]
+			if param.ID == uuid.Nil || param.MerchantID != "merchant-2" || param.Currency != "IDR" {
+				t.Errorf("schedule %s created for %s in %s, want an id for merchant-2 in IDR",
+					param.ID, param.MerchantID, param.Currency)
+			}
+
+			// a schedule without effective time applies right away
+			if !param.EffectiveFrom.IsZero() || len(param.Rules) != 2 {
+				t.Fatalf("schedule effective from %s with %d rules, want right away with 2", param.EffectiveFrom, len(param.Rules))
+			}
+
+			if rule := param.Rules[0]; rule.BankCode != "" || rule.Type != "FLAT" || rule.Flat != "2500" {
+				t.Errorf("default rule = %+v, want a flat fee of 2500", rule)
+			}
+
+			rule := param.Rules[1]
+			if rule.BankCode != "BCA" || rule.Type != "TIERED" || rule.Max != "5000" || len(rule.Tiers) != 2 {
+				t.Fatalf("BCA rule = %+v, want 2 tiers capped at 5000", rule)
+			}
+
+			if rule.Tiers[0].UpTo != "1000000" || rule.Tiers[0].Flat != "1000" || rule.Tiers[1].UpTo != "" ||
+				rule.Tiers[1].Rate != 10 {
+				t.Errorf("tiers = %+v, want a flat 1000 up to 1000000 then 10 basis points", rule.Tiers)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// (POST /fx/quotes)
+func (h httpServer) CreateFXQuote(w http.ResponseWriter, r *http.Request) {
+	var body PostFXQuoteBody
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	quoteID := uuid.New()
+
+	err = h.app.Commands.CreateFXQuote.Handle(r.Context(), &command.CreateFXQuoteParam{
+		ID:             quoteID,
+		MerchantID:     merchantID,
+		SourceCurrency: body.SourceCurrency,
+		TargetCurrency: body.TargetCurrency,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithFXQuote(w, r, http.StatusCreated, quoteID, merchantID)
+}
+
+// (GET /fx/quotes/{id})
+func (h httpServer) GetFXQuote(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithFXQuote(w, r, http.StatusOK, id, merchantID)
+}
+
+func (h httpServer) respondWithFXQuote(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	id uuid.UUID,
+	merchantID string,
+) {
+	quote, err := h.app.Queries.GetFXQuote.Handle(r.Context(), &query.GetFXQuoteParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, status, toFXQuoteResponse(quote))
+}
+
+func toFXQuoteResponse(q *disburse.FXQuote) FXQuote {
+	return FXQuote{
+		Id:             q.ID(),
+		SourceCurrency: q.Rate().Source().String(),
+		TargetCurrency: q.Rate().Target().String(),
+		Rate:           q.Rate().Decimal(),
+		ExpiresAt:      q.ExpiresAt(),
+		DisbursementId: lo.EmptyableToPtr(q.DisbursementID()),
+		CreatedAt:      q.CreatedAt(),
+	}
+}
@@ -0,0 +1,26 @@
+package httphandler
+
+import "context"
+
+// fakeCommandHandler records the commands it handles and answers them with err
+type fakeCommandHandler[C any] struct {
+	calls []C
+	err   error
+}
+
+func (h *fakeCommandHandler[C]) Handle(_ context.Context, cmd C) error {
+	h.calls = append(h.calls, cmd)
+	return h.err
+}
+
+// fakeQueryHandler records the queries it handles and answers them with result and err
+type fakeQueryHandler[Q any, R any] struct {
+	calls  []Q
+	result R
+	err    error
+}
+
+func (h *fakeQueryHandler[Q, R]) Handle(_ context.Context, q Q) (R, error) {
+	h.calls = append(h.calls, q)
+	return h.result, h.err
+}
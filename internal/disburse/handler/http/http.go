@@ -2,28 +2,46 @@ package httphandler
 
 import (
 	"encoding/json"
+	stderrors "errors"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/durianpay/dpay-common/api"
 	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/layarda-durianpay/go-skeleton/internal/config"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/webhook"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
 )
 
+// maxCallbackBodySize limits the callback body read before the signature is verified
+const maxCallbackBodySize = 1 << 20
+
+var ErrInvalidCallbackSignature = stderrors.New("invalid callback signature")
+
 type httpServer struct {
-	app *app.Application
+	app              *app.Application
+	disbursementConf config.DisbursementServiceConfig
 }
 
-func NewHTTPServer(apps *app.Application) ServerInterface {
+func NewHTTPServer(apps *app.Application, disbursementConf config.DisbursementServiceConfig) ServerInterface {
 	return &httpServer{
-		app: apps,
+		app:              apps,
+		disbursementConf: disbursementConf,
 	}
 }
 
 // (POST /disburse)
-func (h httpServer) Disburse(w http.ResponseWriter, r *http.Request) {
+func (h httpServer) Disburse(w http.ResponseWriter, r *http.Request, params DisburseParams) {
 	var body PostDisburseBody
 
 	err := json.NewDecoder(r.Body).Decode(&body)
@@ -39,15 +57,273 @@ func (h httpServer) Disburse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	idempotencyKey := lo.FromPtr(params.IdempotencyKey)
+	disbursementID := disburse.NewDisbursementID(merchantID, idempotencyKey)
+
+	// a request of the merchant without user is created by the merchant itself
+	user, _ := handler.UserFromContext(r.Context())
+
 	err = h.app.Commands.Disburse.Handle(r.Context(), &command.DisburseParam{
-		Amount: body.Amount,
+		ID:             disbursementID,
+		MerchantID:     merchantID,
+		IdempotencyKey: idempotencyKey,
+		Amount:         body.Amount,
+		Currency:       body.Currency,
+		BeneficiaryID:  lo.FromPtr(body.BeneficiaryId),
+		FXQuoteID:      lo.FromPtr(body.FxQuoteId),
+		CreatedBy:      user.ID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusCreated, CreatedResponse{
+		Message:        "Success process your disburse.",
+		DisbursementId: disbursementID,
+	})
+}
+
+// (POST /disbursements/batches)
+func (h httpServer) CreateDisbursementBatch(w http.ResponseWriter, r *http.Request, params CreateDisbursementBatchParams) {
+	var body PostDisbursementBatchBody
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	idempotencyKey := lo.FromPtr(params.IdempotencyKey)
+	batchID := disburse.NewBatchID(merchantID, idempotencyKey)
+
+	// a request of the merchant without user is created by the merchant itself
+	user, _ := handler.UserFromContext(r.Context())
+
+	err = h.app.Commands.DisburseBatch.Handle(r.Context(), &command.DisburseBatchParam{
+		ID:             batchID,
+		MerchantID:     merchantID,
+		IdempotencyKey: idempotencyKey,
+		Items: lo.Map(body.Items, func(item PostDisbursementBatchItemRequest, _ int) command.DisburseBatchItem {
+			return command.DisburseBatchItem{
+				Amount:   item.Amount,
+				Currency: item.Currency,
+			}
+		}),
+		CreatedBy: user.ID,
 	})
 	if err != nil {
 		httperr.ResponseWithError(err, w, r)
 		return
 	}
 
-	api.RespondWithJSON(w, http.StatusCreated, api.Response{
-		Message: "Success process your disburse.",
+	api.RespondWithJSON(w, http.StatusCreated, DisbursementBatchCreatedResponse{
+		Message: "Success process your disbursement batch.",
+		BatchId: batchID,
+		Items: lo.Times(len(body.Items), func(i int) DisbursementBatchItem {
+			return DisbursementBatchItem{
+				Index:          i,
+				DisbursementId: disburse.NewBatchItemID(batchID, i),
+			}
+		}),
 	})
 }
+
+// (GET /disbursements/batches/{id})
+func (h httpServer) GetDisbursementBatch(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	progress, err := h.app.Queries.GetDisbursementBatch.Handle(r.Context(), &query.GetDisbursementBatchParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, DisbursementBatch{
+		Id:             progress.Batch.ID(),
+		ItemCount:      progress.Batch.ItemCount(),
+		CompletedCount: progress.CompletedCount(),
+		StatusCounts: lo.MapKeys(progress.StatusCounts, func(_ int, status disburse.Status) string {
+			return status.String()
+		}),
+		CreatedAt: progress.Batch.CreatedAt(),
+	})
+}
+
+// (GET /disbursements)
+func (h httpServer) ListDisbursements(w http.ResponseWriter, r *http.Request, params ListDisbursementsParams) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	result, err := h.app.Queries.ListDisbursements.Handle(r.Context(), &query.ListDisbursementsParam{
+		MerchantID: merchantID,
+		Statuses: lo.Map(lo.FromPtr(params.Status), func(s DisbursementStatus, _ int) string {
+			return string(s)
+		}),
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		MinAmount:   lo.FromPtr(params.MinAmount),
+		MaxAmount:   lo.FromPtr(params.MaxAmount),
+		Currency:    lo.FromPtr(params.Currency),
+		Cursor:      lo.FromPtr(params.Cursor),
+		Limit:       lo.FromPtr(params.Limit),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, ListDisbursementsResponse{
+		Data:       lo.Map(result.Disbursements, func(d *disburse.Disbursement, _ int) Disbursement { return toResponse(d) }),
+		NextCursor: lo.EmptyableToPtr(result.NextCursor),
+	})
+}
+
+// (GET /disbursements/{id})
+func (h httpServer) GetDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	disbursement, err := h.app.Queries.GetDisbursement.Handle(r.Context(), &query.GetDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, toResponse(disbursement))
+}
+
+// (POST /webhooks/payouts/{provider})
+func (h httpServer) ReceivePayoutCallback(
+	w http.ResponseWriter,
+	r *http.Request,
+	provider string,
+	params ReceivePayoutCallbackParams,
+) {
+	// the signature covers the raw body, so it is verified before decoding
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCallbackBodySize))
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	secret := h.disbursementConf.GetPayoutWebhookSecret(provider)
+	if !webhook.VerifySignature([]byte(secret), payload, params.XCallbackSignature) {
+		httperr.ResponseWithError(
+			errors.NewAuthorizationError(
+				ErrInvalidCallbackSignature,
+				ErrInvalidCallbackSignature.Error(),
+				errors.DpayUnauthorized,
+			),
+			w, r,
+		)
+		return
+	}
+
+	var body PayoutCallbackRequest
+
+	err = json.Unmarshal(payload, &body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	err = h.app.Commands.HandlePayoutCallback.Handle(r.Context(), &command.HandlePayoutCallbackParam{
+		Provider:       provider,
+		EventID:        body.EventId,
+		DisbursementID: body.DisbursementId,
+		Status:         string(body.Status),
+		FailureReason:  lo.FromPtr(body.FailureReason),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, PayoutCallbackResponse{
+		Message: "Callback received.",
+	})
+}
+
+func toResponse(d *disburse.Disbursement) Disbursement {
+	return Disbursement{
+		Id:            d.ID(),
+		Amount:        d.Amount().Decimal(),
+		Fee:           d.Fee().Decimal(),
+		FeeScheduleId: lo.EmptyableToPtr(d.FeeScheduleID()),
+		Currency:      d.Amount().Currency().String(),
+		Status:        DisbursementStatus(d.Status()),
+		BeneficiaryId: lo.EmptyableToPtr(d.BeneficiaryID()),
+		FailureReason: lo.EmptyableToPtr(d.FailureReason()),
+
+		FundingCurrency: d.FundingCurrency().String(),
+		FxQuoteId:       lo.EmptyableToPtr(d.FXQuoteID()),
+		FxRate:          lo.Ternary(d.FXRate().IsZero(), nil, lo.ToPtr(d.FXRate().Decimal())),
+
+		RequiredApprovals: lo.EmptyableToPtr(d.RequiredApprovals()),
+		ApprovalDeadline:  lo.EmptyableToPtr[time.Time](d.ApprovalDeadline()),
+
+		CreatedAt:   d.CreatedAt(),
+		UpdatedAt:   d.UpdatedAt(),
+		ProcessedAt: lo.EmptyableToPtr[time.Time](d.ProcessedAt()),
+		CompletedAt: lo.EmptyableToPtr[time.Time](d.CompletedAt()),
+	}
+}
+
+// optionalAmount is the decimal amount, nil when it is zero like a rule that is not limited or capped
+func optionalAmount(amount money.Money) *string {
+	if amount.IsZero() {
+		return nil
+	}
+
+	return lo.ToPtr(amount.Decimal())
+}
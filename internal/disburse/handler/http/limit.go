@@ -0,0 +1,89 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	"github.com/samber/lo"
+)
+
+// (GET /admin/merchants/{merchant_id}/limits/{currency})
+func (h httpServer) GetMerchantLimits(w http.ResponseWriter, r *http.Request, merchantID MerchantID, currency Currency) {
+	_, err := handler.InternalUserIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithMerchantLimits(w, r, merchantID, currency)
+}
+
+// (PUT /admin/merchants/{merchant_id}/limits/{currency})
+func (h httpServer) SetMerchantLimits(w http.ResponseWriter, r *http.Request, merchantID MerchantID, currency Currency) {
+	_, err := handler.InternalUserIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	var body SetMerchantLimitsJSONRequestBody
+
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	err = h.app.Commands.SetMerchantLimits.Handle(r.Context(), &command.SetMerchantLimitsParam{
+		MerchantID:     merchantID,
+		Currency:       currency,
+		PerTransaction: lo.FromPtr(body.PerTransaction),
+		DailyTotal:     lo.FromPtr(body.DailyTotal),
+		MonthlyTotal:   lo.FromPtr(body.MonthlyTotal),
+		MaxCount:       lo.FromPtr(body.MaxCount),
+		CountWindow:    time.Duration(lo.FromPtr(body.CountWindowSeconds)) * time.Second,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	h.respondWithMerchantLimits(w, r, merchantID, currency)
+}
+
+func (h httpServer) respondWithMerchantLimits(w http.ResponseWriter, r *http.Request, merchantID string, currency string) {
+	limits, err := h.app.Queries.GetMerchantLimits.Handle(r.Context(), &query.GetMerchantLimitsParam{
+		MerchantID: merchantID,
+		Currency:   currency,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, MerchantLimits{
+		MerchantId:         merchantID,
+		Currency:           limits.Limits.Currency().String(),
+		PerTransaction:     optionalAmount(limits.Limits.PerTransaction()),
+		DailyTotal:         optionalAmount(limits.Limits.DailyTotal()),
+		MonthlyTotal:       optionalAmount(limits.Limits.MonthlyTotal()),
+		MaxCount:           limits.Limits.MaxCount(),
+		CountWindowSeconds: int(limits.Limits.CountWindow() / time.Second),
+		IsDefault:          limits.IsDefault,
+	})
+}
@@ -0,0 +1,148 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+)
+
+func TestSetMerchantLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+
+		// set tells the limits are replaced, a caller other than an internal user is forbidden
+		set bool
+	}{
+		{
+			name: "internal user",
+			ctx:  context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1"),
+			set:  true,
+		},
+		{
+			// a merchant can not raise its own limits
+			name: "merchant",
+			ctx:  context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1"),
+		},
+		{
+			name: "anonymous",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setHandler := &fakeCommandHandler[*command.SetMerchantLimitsParam]{}
+			getHandler := &fakeQueryHandler[*query.GetMerchantLimitsParam, *query.MerchantLimits]{
+				result: &query.MerchantLimits{Limits: testLimits(t)},
+			}
+
+			h := httpServer{app: &app.Application{
+				Commands: app.Commands{SetMerchantLimits: setHandler},
+				Queries:  app.Queries{GetMerchantLimits: getHandler},
+			}}
+
+			r := httptest.NewRequest(
+				http.MethodPut,
+				"/admin/merchants/merchant-2/limits/IDR",
+				strings.NewReader(`{"per_transaction":"50000000","max_count":100,"count_window_seconds":3600}`),
+			).WithContext(tt.ctx)
+
+			h.SetMerchantLimits(httptest.NewRecorder(), r, "merchant-2", "IDR")
+
+			if !tt.set {
+				if len(setHandler.calls) != 0 || len(getHandler.calls) != 0 {
+					t.Errorf("limits set %d and read %d times, want 0", len(setHandler.calls), len(getHandler.calls))
+				}
+
+				return
+			}
+
+			if len(setHandler.calls) != 1 {
+				t.Fatalf("SetMerchantLimits called %d times, want 1", len(setHandler.calls))
+			}
+
+			param := setHandler.calls[0]
+			if param.MerchantID != "merchant-2" || param.Currency != "IDR" {
+				t.Errorf("limits set for %s in %s, want merchant-2 in IDR", param.MerchantID, param.Currency)
+			}
+
+			if param.PerTransaction != "50000000" || param.DailyTotal != "" || param.MonthlyTotal != "" {
+				t.Errorf("amounts = %q, %q, %q, want only the per transaction amount",
+					param.PerTransaction, param.DailyTotal, param.MonthlyTotal)
+			}
+
+			if param.MaxCount != 100 || param.CountWindow != time.Hour {
+				t.Errorf("count = %d within %s, want 100 within 1h", param.MaxCount, param.CountWindow)
+			}
+
+			// the response is the limits read back after they are set
+			if len(getHandler.calls) != 1 || getHandler.calls[0].MerchantID != "merchant-2" {
+				t.Errorf("GetMerchantLimits calls = %+v, want one for merchant-2", getHandler.calls)
+			}
+		})
+	}
+}
+
+func TestGetMerchantLimits(t *testing.T) {
+	getHandler := &fakeQueryHandler[*query.GetMerchantLimitsParam, *query.MerchantLimits]{
+		result: &query.MerchantLimits{Limits: testLimits(t), IsDefault: true},
+	}
+
+	h := httpServer{app: &app.Application{
+		Queries: app.Queries{GetMerchantLimits: getHandler},
+	}}
+
+	merchantCtx := context.WithValue(context.Background(), constants.MerchantIDKey, "merchant-1")
+	r := httptest.NewRequest(http.MethodGet, "/admin/merchants/merchant-1/limits/IDR", nil).WithContext(merchantCtx)
+
+	h.GetMerchantLimits(httptest.NewRecorder(), r, "merchant-1", "IDR")
+
+	if len(getHandler.calls) != 0 {
+		t.Fatalf("GetMerchantLimits called %d times for a merchant, want 0", len(getHandler.calls))
+	}
+
+	internalUserCtx := context.WithValue(context.Background(), constants.InternalUserIDKey, "ops-1")
+	r = httptest.NewRequest(http.MethodGet, "/admin/merchants/merchant-1/limits/IDR", nil).WithContext(internalUserCtx)
+
+	h.GetMerchantLimits(httptest.NewRecorder(), r, "merchant-1", "IDR")
+
+	if len(getHandler.calls) != 1 {
+		t.Fatalf("GetMerchantLimits called %d times for an internal user, want 1", len(getHandler.calls))
+	}
+
+	if param := getHandler.calls[0]; param.MerchantID != "merchant-1" || param.Currency != "IDR" {
+		t.Errorf("limits read for %s in %s, want merchant-1 in IDR", param.MerchantID, param.Currency)
+	}
+}
+
+func testLimits(t *testing.T) disburse.Limits {
+	t.Helper()
+
+	currency, err := money.ParseCurrency("IDR")
+	if err != nil {
+		t.Fatalf("parse currency: %v", err)
+	}
+
+	perTransaction, err := money.Parse("50000000", "IDR")
+	if err != nil {
+		t.Fatalf("parse amount: %v", err)
+	}
+
+	limits, err := disburse.NewLimits(currency, perTransaction, money.Money{}, money.Money{}, 100, time.Hour)
+	if err != nil {
+		t.Fatalf("new limits: %v", err)
+	}
+
+	return limits
+}
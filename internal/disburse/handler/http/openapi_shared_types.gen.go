@@ -11,6 +11,9 @@ type BadRequestError struct {
 	// ErrorCode durianpay error code
 	ErrorCode *string `json:"error_code,omitempty"`
 
+	// Errors the failed fields, e.g. every invalid item of a batch
+	Errors *[]ErrorInfo `json:"errors,omitempty"`
+
 	// RequestId durianpay request_id for reconciliation
 	RequestId *string `json:"request_id,omitempty"`
 }
@@ -23,10 +26,19 @@ type Error struct {
 	// ErrorCode durianpay error code
 	ErrorCode *string `json:"error_code,omitempty"`
 
+	// Errors the failed fields, e.g. every invalid item of a batch
+	Errors *[]ErrorInfo `json:"errors,omitempty"`
+
 	// RequestId durianpay request_id for reconciliation
 	RequestId *string `json:"request_id,omitempty"`
 }
 
+// ErrorInfo defines model for ErrorInfo.
+type ErrorInfo struct {
+	Field   *string `json:"field,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
 // NotFoundError defines model for NotFoundError.
 type NotFoundError struct {
 	// Error message error description
@@ -35,6 +47,9 @@ type NotFoundError struct {
 	// ErrorCode durianpay error code
 	ErrorCode *string `json:"error_code,omitempty"`
 
+	// Errors the failed fields, e.g. every invalid item of a batch
+	Errors *[]ErrorInfo `json:"errors,omitempty"`
+
 	// RequestId durianpay request_id for reconciliation
 	RequestId *string `json:"request_id,omitempty"`
 }
@@ -42,8 +57,20 @@ type NotFoundError struct {
 // BadRequestResponse defines model for BadRequestResponse.
 type BadRequestResponse = BadRequestError
 
+// ConflictResponse defines model for ConflictResponse.
+type ConflictResponse = Error
+
+// ForbiddenResponse defines model for ForbiddenResponse.
+type ForbiddenResponse = Error
+
 // NotFoundRequest defines model for NotFoundRequest.
 type NotFoundRequest = NotFoundError
 
+// UnauthorizedResponse defines model for UnauthorizedResponse.
+type UnauthorizedResponse = Error
+
 // UnexpectedErrorRequest defines model for UnexpectedErrorRequest.
 type UnexpectedErrorRequest = Error
+
+// UnprocessableEntityResponse defines model for UnprocessableEntityResponse.
+type UnprocessableEntityResponse = Error
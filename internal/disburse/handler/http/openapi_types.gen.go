@@ -3,18 +3,702 @@
 // Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.0 DO NOT EDIT.
 package httphandler
 
+import (
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for DisbursementApprovalAction.
+const (
+	APPROVE DisbursementApprovalAction = "APPROVE"
+	EXPIRE  DisbursementApprovalAction = "EXPIRE"
+	REJECT  DisbursementApprovalAction = "REJECT"
+	SUBMIT  DisbursementApprovalAction = "SUBMIT"
+)
+
+// Defines values for DisbursementStatus.
+const (
+	DisbursementStatusAWAITINGAPPROVAL DisbursementStatus = "AWAITING_APPROVAL"
+	DisbursementStatusCANCELLED        DisbursementStatus = "CANCELLED"
+	DisbursementStatusFAILED           DisbursementStatus = "FAILED"
+	DisbursementStatusPENDING          DisbursementStatus = "PENDING"
+	DisbursementStatusPROCESSING       DisbursementStatus = "PROCESSING"
+	DisbursementStatusREJECTED         DisbursementStatus = "REJECTED"
+	DisbursementStatusREVERSED         DisbursementStatus = "REVERSED"
+	DisbursementStatusSUCCESS          DisbursementStatus = "SUCCESS"
+)
+
+// Defines values for DisbursementUploadStatus.
+const (
+	DisbursementUploadStatusCOMPLETED  DisbursementUploadStatus = "COMPLETED"
+	DisbursementUploadStatusPROCESSING DisbursementUploadStatus = "PROCESSING"
+)
+
+// Defines values for FeeRuleType.
+const (
+	FLAT       FeeRuleType = "FLAT"
+	PERCENTAGE FeeRuleType = "PERCENTAGE"
+	TIERED     FeeRuleType = "TIERED"
+)
+
+// Defines values for PayoutCallbackRequestStatus.
+const (
+	FAILED  PayoutCallbackRequestStatus = "FAILED"
+	SUCCESS PayoutCallbackRequestStatus = "SUCCESS"
+)
+
+// Defines values for ReconciliationDiscrepancyType.
+const (
+	AMOUNTMISMATCH      ReconciliationDiscrepancyType = "AMOUNT_MISMATCH"
+	DUPLICATED          ReconciliationDiscrepancyType = "DUPLICATED"
+	MISSINGINSETTLEMENT ReconciliationDiscrepancyType = "MISSING_IN_SETTLEMENT"
+	STATUSMISMATCH      ReconciliationDiscrepancyType = "STATUS_MISMATCH"
+	UNKNOWNREFERENCE    ReconciliationDiscrepancyType = "UNKNOWN_REFERENCE"
+)
+
+// Defines values for ScheduledDisbursementStatus.
+const (
+	ACTIVE    ScheduledDisbursementStatus = "ACTIVE"
+	CANCELLED ScheduledDisbursementStatus = "CANCELLED"
+	COMPLETED ScheduledDisbursementStatus = "COMPLETED"
+)
+
+// ApprovalPolicy defines model for ApprovalPolicy.
+type ApprovalPolicy struct {
+	ApprovalWindowSeconds int      `json:"approval_window_seconds"`
+	ApproverRoles         []string `json:"approver_roles"`
+	Currency              string   `json:"currency"`
+	MerchantId            string   `json:"merchant_id"`
+	RequiredApprovals     int      `json:"required_approvals"`
+	Threshold             string   `json:"threshold"`
+}
+
+// ApprovalPolicyRequest defines model for ApprovalPolicyRequest.
+type ApprovalPolicyRequest struct {
+	// ApprovalWindowSeconds the disbursement is rejected when it is not approved within the window
+	ApprovalWindowSeconds int `json:"approval_window_seconds"`
+
+	// ApproverRoles case insensitive roles of the users allowed to approve
+	ApproverRoles []string `json:"approver_roles"`
+
+	// RequiredApprovals number of approvers other than the creator the disbursement needs
+	RequiredApprovals int `json:"required_approvals"`
+
+	// Threshold exact decimal amount in major units a disbursement must be above to need approval
+	Threshold string `json:"threshold"`
+}
+
+// Beneficiary defines model for Beneficiary.
+type Beneficiary struct {
+	AccountNumber string    `json:"account_number"`
+	BankCode      string    `json:"bank_code"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// HolderName the name registered at the bank
+	HolderName string             `json:"holder_name"`
+	Id         openapi_types.UUID `json:"id"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+// BeneficiaryRequest defines model for BeneficiaryRequest.
+type BeneficiaryRequest struct {
+	// AccountNumber digits of the account number, spaces and dashes are ignored. The number of digits must match
+	// the format of the bank when the bank has a known format
+	AccountNumber string `json:"account_number"`
+
+	// BankCode case insensitive code of the bank
+	BankCode string `json:"bank_code"`
+
+	// HolderName checked against the name registered at the bank, case, punctuation and spacing are ignored
+	HolderName *string `json:"holder_name,omitempty"`
+}
+
 // CreatedResponse defines model for CreatedResponse.
 type CreatedResponse struct {
+	DisbursementId openapi_types.UUID `json:"disbursement_id"`
+	Message        string             `json:"message"`
+}
+
+// Disbursement defines model for Disbursement.
+type Disbursement struct {
+	// Amount exact decimal amount in major units
+	Amount string `json:"amount"`
+
+	// ApprovalDeadline the disbursement is rejected when it is not approved by this time, absent when it needs no approval
+	ApprovalDeadline *time.Time          `json:"approval_deadline,omitempty"`
+	BeneficiaryId    *openapi_types.UUID `json:"beneficiary_id,omitempty"`
+	CompletedAt      *time.Time          `json:"completed_at,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+	Currency         string              `json:"currency"`
+	FailureReason    *string             `json:"failure_reason,omitempty"`
+
+	// Fee exact decimal fee in major units charged on top of the amount
+	Fee string `json:"fee"`
+
+	// FeeScheduleId fee schedule the fee is calculated with, absent when the merchant has none
+	FeeScheduleId *openapi_types.UUID `json:"fee_schedule_id,omitempty"`
+
+	// FundingCurrency currency the amount and the fee are charged from the merchant balance in
+	FundingCurrency string `json:"funding_currency"`
+
+	// FxQuoteId quote the disbursement is funded with, absent when it is funded in currency
+	FxQuoteId *openapi_types.UUID `json:"fx_quote_id,omitempty"`
+
+	// FxRate exact decimal worth of 1 unit of funding_currency in currency, absent without fx quote
+	FxRate      *string            `json:"fx_rate,omitempty"`
+	Id          openapi_types.UUID `json:"id"`
+	ProcessedAt *time.Time         `json:"processed_at,omitempty"`
+
+	// RequiredApprovals approvals the disbursement needs before it is sent for payout, absent when it needs none
+	RequiredApprovals *int               `json:"required_approvals,omitempty"`
+	Status            DisbursementStatus `json:"status"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+// DisbursementActionRequest defines model for DisbursementActionRequest.
+type DisbursementActionRequest struct {
+	// Reason why the action is taken, kept in the audit trail
+	Reason *string `json:"reason,omitempty"`
+}
+
+// DisbursementApproval defines model for DisbursementApproval.
+type DisbursementApproval struct {
+	Action DisbursementApprovalAction `json:"action"`
+
+	// Actor the user who did the step, the creator for SUBMIT and system for EXPIRE
+	Actor     string             `json:"actor"`
+	CreatedAt time.Time          `json:"created_at"`
+	Id        openapi_types.UUID `json:"id"`
+	Reason    *string            `json:"reason,omitempty"`
+
+	// Role the approver role of the actor, absent for SUBMIT and EXPIRE
+	Role *string `json:"role,omitempty"`
+}
+
+// DisbursementApprovalAction defines model for DisbursementApproval.Action.
+type DisbursementApprovalAction string
+
+// DisbursementBatch defines model for DisbursementBatch.
+type DisbursementBatch struct {
+	// CompletedCount number of items in a final status
+	CompletedCount int                `json:"completed_count"`
+	CreatedAt      time.Time          `json:"created_at"`
+	Id             openapi_types.UUID `json:"id"`
+	ItemCount      int                `json:"item_count"`
+
+	// StatusCounts number of items per status, a status without item is absent
+	StatusCounts map[string]int `json:"status_counts"`
+}
+
+// DisbursementBatchCreatedResponse defines model for DisbursementBatchCreatedResponse.
+type DisbursementBatchCreatedResponse struct {
+	BatchId openapi_types.UUID      `json:"batch_id"`
+	Items   []DisbursementBatchItem `json:"items"`
+	Message string                  `json:"message"`
+}
+
+// DisbursementBatchItem defines model for DisbursementBatchItem.
+type DisbursementBatchItem struct {
+	DisbursementId openapi_types.UUID `json:"disbursement_id"`
+
+	// Index position of the item in the request
+	Index int `json:"index"`
+}
+
+// DisbursementStatus defines model for DisbursementStatus.
+type DisbursementStatus string
+
+// DisbursementUpload defines model for DisbursementUpload.
+type DisbursementUpload struct {
+	CompletedAt *time.Time                    `json:"completed_at,omitempty"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	FailedRows  []DisbursementUploadFailedRow `json:"failed_rows"`
+	FileName    string                        `json:"file_name"`
+	Id          openapi_types.UUID            `json:"id"`
+	RowCount    int                           `json:"row_count"`
+
+	// RowCounts number of rows per status, a status without row is absent
+	RowCounts map[string]int           `json:"row_counts"`
+	Status    DisbursementUploadStatus `json:"status"`
+}
+
+// DisbursementUploadStatus defines model for DisbursementUpload.Status.
+type DisbursementUploadStatus string
+
+// DisbursementUploadAcceptedResponse defines model for DisbursementUploadAcceptedResponse.
+type DisbursementUploadAcceptedResponse struct {
+	Message  string             `json:"message"`
+	RowCount int                `json:"row_count"`
+	UploadId openapi_types.UUID `json:"upload_id"`
+}
+
+// DisbursementUploadFailedRow defines model for DisbursementUploadFailedRow.
+type DisbursementUploadFailedRow struct {
+	FailureReason string `json:"failure_reason"`
+	Reference     string `json:"reference"`
+
+	// Row row number in the file
+	Row int `json:"row"`
+}
+
+// FXQuote defines model for FXQuote.
+type FXQuote struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// DisbursementId disbursement that used the quote, absent while it is not used
+	DisbursementId *openapi_types.UUID `json:"disbursement_id,omitempty"`
+
+	// ExpiresAt the quote can not be used by a disbursement from this time
+	ExpiresAt time.Time          `json:"expires_at"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// Rate exact decimal worth of 1 unit of source_currency in target_currency
+	Rate           string `json:"rate"`
+	SourceCurrency string `json:"source_currency"`
+	TargetCurrency string `json:"target_currency"`
+}
+
+// FeePreview defines model for FeePreview.
+type FeePreview struct {
+	// Amount exact decimal amount in major units
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+
+	// Fee exact decimal fee in major units charged on top of the amount
+	Fee string `json:"fee"`
+
+	// FeeScheduleId fee schedule the fee is calculated with, absent when the merchant has none
+	FeeScheduleId      *openapi_types.UUID `json:"fee_schedule_id,omitempty"`
+	FeeScheduleVersion *int                `json:"fee_schedule_version,omitempty"`
+
+	// Total amount with the fee, what is reserved from the merchant balance
+	Total string `json:"total"`
+}
+
+// FeeRule defines model for FeeRule.
+type FeeRule struct {
+	// BankCode bank the rule applies to, absent is the rule of the banks without their own rule
+	BankCode *string `json:"bank_code,omitempty"`
+
+	// Flat exact decimal fee in major units of a FLAT rule
+	Flat *string `json:"flat,omitempty"`
+
+	// Max exact decimal amount in major units the fee is lowered to, absent is not capped
+	Max *string `json:"max,omitempty"`
+
+	// Min exact decimal amount in major units the fee is raised to, absent is not capped
+	Min *string `json:"min,omitempty"`
+
+	// Rate rate in basis points of a PERCENTAGE rule, 100 is 1%
+	Rate *int64 `json:"rate,omitempty"`
+
+	// Tiers tiers of a TIERED rule
+	Tiers *[]FeeTier  `json:"tiers,omitempty"`
+	Type  FeeRuleType `json:"type"`
+}
+
+// FeeRuleType defines model for FeeRule.Type.
+type FeeRuleType string
+
+// FeeSchedule defines model for FeeSchedule.
+type FeeSchedule struct {
+	CreatedAt     time.Time          `json:"created_at"`
+	Currency      string             `json:"currency"`
+	EffectiveFrom time.Time          `json:"effective_from"`
+	Id            openapi_types.UUID `json:"id"`
+	MerchantId    string             `json:"merchant_id"`
+	Rules         []FeeRule          `json:"rules"`
+	Version       int                `json:"version"`
+}
+
+// FeeScheduleCreatedResponse defines model for FeeScheduleCreatedResponse.
+type FeeScheduleCreatedResponse struct {
+	FeeScheduleId openapi_types.UUID `json:"fee_schedule_id"`
+	Message       string             `json:"message"`
+}
+
+// FeeScheduleRequest defines model for FeeScheduleRequest.
+type FeeScheduleRequest struct {
+	// EffectiveFrom time the schedule applies from, absent applies it right away
+	EffectiveFrom *time.Time `json:"effective_from,omitempty"`
+
+	// Rules at most one rule per bank code
+	Rules []FeeRule `json:"rules"`
+}
+
+// FeeTier defines model for FeeTier.
+type FeeTier struct {
+	Flat *string `json:"flat,omitempty"`
+
+	// Rate rate in basis points, 100 is 1%
+	Rate *int64 `json:"rate,omitempty"`
+
+	// UpTo max amount of the tier, absent for the last tier and only for it
+	UpTo *string `json:"up_to,omitempty"`
+}
+
+// ListBeneficiariesResponse defines model for ListBeneficiariesResponse.
+type ListBeneficiariesResponse struct {
+	Data []Beneficiary `json:"data"`
+
+	// NextCursor cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// ListDisbursementApprovalsResponse defines model for ListDisbursementApprovalsResponse.
+type ListDisbursementApprovalsResponse struct {
+	Data []DisbursementApproval `json:"data"`
+}
+
+// ListDisbursementsResponse defines model for ListDisbursementsResponse.
+type ListDisbursementsResponse struct {
+	Data []Disbursement `json:"data"`
+
+	// NextCursor cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// MerchantLimits defines model for MerchantLimits.
+type MerchantLimits struct {
+	CountWindowSeconds int    `json:"count_window_seconds"`
+	Currency           string `json:"currency"`
+
+	// DailyTotal absent when not limited
+	DailyTotal *string `json:"daily_total,omitempty"`
+
+	// IsDefault the merchant has no limits of its own and gets the default limits of the currency
+	IsDefault bool `json:"is_default"`
+
+	// MaxCount zero when not limited
+	MaxCount   int    `json:"max_count"`
+	MerchantId string `json:"merchant_id"`
+
+	// MonthlyTotal absent when not limited
+	MonthlyTotal *string `json:"monthly_total,omitempty"`
+
+	// PerTransaction absent when not limited
+	PerTransaction *string `json:"per_transaction,omitempty"`
+}
+
+// MerchantLimitsRequest a rule left out is not limited
+type MerchantLimitsRequest struct {
+	// CountWindowSeconds rolling window max_count is counted over
+	CountWindowSeconds *int `json:"count_window_seconds,omitempty"`
+
+	// DailyTotal exact decimal amount in major units disbursed within a UTC day
+	DailyTotal *string `json:"daily_total,omitempty"`
+
+	// MaxCount number of disbursements allowed within count_window_seconds
+	MaxCount *int `json:"max_count,omitempty"`
+
+	// MonthlyTotal exact decimal amount in major units disbursed within a UTC month
+	MonthlyTotal *string `json:"monthly_total,omitempty"`
+
+	// PerTransaction exact decimal amount in major units a single disbursement can not exceed
+	PerTransaction *string `json:"per_transaction,omitempty"`
+}
+
+// PayoutCallbackRequest defines model for PayoutCallbackRequest.
+type PayoutCallbackRequest struct {
+	// DisbursementId the disbursement id sent as payout reference
+	DisbursementId openapi_types.UUID `json:"disbursement_id"`
+
+	// EventId unique id of the callback, a redelivered callback carries the same id
+	EventId       string                      `json:"event_id"`
+	FailureReason *string                     `json:"failure_reason,omitempty"`
+	Status        PayoutCallbackRequestStatus `json:"status"`
+}
+
+// PayoutCallbackRequestStatus defines model for PayoutCallbackRequest.Status.
+type PayoutCallbackRequestStatus string
+
+// PayoutCallbackResponse defines model for PayoutCallbackResponse.
+type PayoutCallbackResponse struct {
 	Message string `json:"message"`
 }
 
 // PostDisburseRequest defines model for PostDisburseRequest.
 type PostDisburseRequest struct {
-	Amount float32 `json:"amount"`
+	// Amount exact decimal amount in major units, sent as string to avoid float rounding
+	Amount string `json:"amount"`
+
+	// BeneficiaryId active beneficiary to pay to
+	BeneficiaryId *openapi_types.UUID `json:"beneficiary_id,omitempty"`
+
+	// Currency ISO-4217 currency code
+	Currency string `json:"currency"`
+
+	// FxQuoteId quote converting into currency, the disbursement is funded in its source currency at the locked rate.
+	// Absent funds the disbursement in currency
+	FxQuoteId *openapi_types.UUID `json:"fx_quote_id,omitempty"`
+}
+
+// PostDisbursementBatchItemRequest defines model for PostDisbursementBatchItemRequest.
+type PostDisbursementBatchItemRequest struct {
+	// Amount exact decimal amount in major units, sent as string to avoid float rounding
+	Amount string `json:"amount"`
+
+	// Currency ISO-4217 currency code
+	Currency string `json:"currency"`
+}
+
+// PostDisbursementBatchRequest defines model for PostDisbursementBatchRequest.
+type PostDisbursementBatchRequest struct {
+	Items []PostDisbursementBatchItemRequest `json:"items"`
+}
+
+// PostFXQuoteRequest defines model for PostFXQuoteRequest.
+type PostFXQuoteRequest struct {
+	// SourceCurrency ISO-4217 currency code charged from the merchant balance
+	SourceCurrency string `json:"source_currency"`
+
+	// TargetCurrency ISO-4217 currency code the disbursement is paid out in
+	TargetCurrency string `json:"target_currency"`
 }
 
+// PostScheduledDisbursementRequest defines model for PostScheduledDisbursementRequest.
+type PostScheduledDisbursementRequest struct {
+	// Amount exact decimal amount in major units, sent as string to avoid float rounding
+	Amount string `json:"amount"`
+
+	// Currency ISO-4217 currency code
+	Currency string `json:"currency"`
+
+	// EndAt no run after this time, absent runs until cancelled
+	EndAt      *time.Time `json:"end_at,omitempty"`
+	FirstRunAt time.Time  `json:"first_run_at"`
+
+	// Recurrence cron rule of the runs after the first, in the standard 5 fields or one of @daily, @weekly and @monthly.
+	// It is evaluated in UTC unless it starts with CRON_TZ=<zone>, absent runs only once
+	Recurrence *string `json:"recurrence,omitempty"`
+}
+
+// Reconciliation defines model for Reconciliation.
+type Reconciliation struct {
+	CreatedAt     time.Time                   `json:"created_at"`
+	Discrepancies []ReconciliationDiscrepancy `json:"discrepancies"`
+	Id            openapi_types.UUID          `json:"id"`
+
+	// PeriodEnd exclusive end of the period the disbursements were completed in
+	PeriodEnd   time.Time `json:"period_end"`
+	PeriodStart time.Time `json:"period_start"`
+	Provider    string    `json:"provider"`
+}
+
+// ReconciliationDiscrepancy defines model for ReconciliationDiscrepancy.
+type ReconciliationDiscrepancy struct {
+	Detail         string              `json:"detail"`
+	DisbursementId *openapi_types.UUID `json:"disbursement_id,omitempty"`
+
+	// ExpectedAmount amount of the disbursement
+	ExpectedAmount   *string `json:"expected_amount,omitempty"`
+	ExpectedCurrency *string `json:"expected_currency,omitempty"`
+
+	// Line line number in the settlement report, absent for a disbursement missing in the report
+	Line *int `json:"line,omitempty"`
+
+	// Reference reference of the settlement line, the disbursement id for a disbursement missing in the report
+	Reference string `json:"reference"`
+
+	// SettledAmount amount of the settlement line
+	SettledAmount   *string                       `json:"settled_amount,omitempty"`
+	SettledCurrency *string                       `json:"settled_currency,omitempty"`
+	Type            ReconciliationDiscrepancyType `json:"type"`
+}
+
+// ReconciliationDiscrepancyType defines model for ReconciliationDiscrepancy.Type.
+type ReconciliationDiscrepancyType string
+
+// ScheduledDisbursement defines model for ScheduledDisbursement.
+type ScheduledDisbursement struct {
+	Amount    string             `json:"amount"`
+	CreatedAt time.Time          `json:"created_at"`
+	Currency  string             `json:"currency"`
+	EndAt     *time.Time         `json:"end_at,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// LastFailureReason why the last run did not create a disbursement, absent when it did
+	LastFailureReason *string    `json:"last_failure_reason,omitempty"`
+	LastRunAt         *time.Time `json:"last_run_at,omitempty"`
+
+	// NextRunAt time of the next run, meaningless once the schedule is not active
+	NextRunAt  time.Time                   `json:"next_run_at"`
+	Recurrence *string                     `json:"recurrence,omitempty"`
+	RunCount   int                         `json:"run_count"`
+	Status     ScheduledDisbursementStatus `json:"status"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+}
+
+// ScheduledDisbursementStatus defines model for ScheduledDisbursement.Status.
+type ScheduledDisbursementStatus string
+
+// ScheduledDisbursementCreatedResponse defines model for ScheduledDisbursementCreatedResponse.
+type ScheduledDisbursementCreatedResponse struct {
+	Message    string             `json:"message"`
+	ScheduleId openapi_types.UUID `json:"schedule_id"`
+}
+
+// UploadDisbursementsRequest defines model for UploadDisbursementsRequest.
+type UploadDisbursementsRequest struct {
+	// File the .csv or .xlsx file, only the first sheet of a XLSX file is read
+	File openapi_types.File `json:"file"`
+}
+
+// CallbackSignature defines model for CallbackSignature.
+type CallbackSignature = string
+
+// Currency defines model for Currency.
+type Currency = string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// MerchantID defines model for MerchantID.
+type MerchantID = string
+
+// ApprovalPolicyBody defines model for ApprovalPolicyBody.
+type ApprovalPolicyBody = ApprovalPolicyRequest
+
+// BeneficiaryBody defines model for BeneficiaryBody.
+type BeneficiaryBody = BeneficiaryRequest
+
+// DisbursementActionBody defines model for DisbursementActionBody.
+type DisbursementActionBody = DisbursementActionRequest
+
+// FeeScheduleBody defines model for FeeScheduleBody.
+type FeeScheduleBody = FeeScheduleRequest
+
+// MerchantLimitsBody a rule left out is not limited
+type MerchantLimitsBody = MerchantLimitsRequest
+
+// PayoutCallbackBody defines model for PayoutCallbackBody.
+type PayoutCallbackBody = PayoutCallbackRequest
+
 // PostDisburseBody defines model for PostDisburseBody.
 type PostDisburseBody = PostDisburseRequest
 
+// PostDisbursementBatchBody defines model for PostDisbursementBatchBody.
+type PostDisbursementBatchBody = PostDisbursementBatchRequest
+
+// PostFXQuoteBody defines model for PostFXQuoteBody.
+type PostFXQuoteBody = PostFXQuoteRequest
+
+// PostScheduledDisbursementBody defines model for PostScheduledDisbursementBody.
+type PostScheduledDisbursementBody = PostScheduledDisbursementRequest
+
+// ListBeneficiariesParams defines parameters for ListBeneficiaries.
+type ListBeneficiariesParams struct {
+	// BankCode only list beneficiaries at the bank
+	BankCode *string `form:"bank_code,omitempty" json:"bank_code,omitempty"`
+
+	// Cursor next_cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit max number of beneficiaries in a page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DisburseParams defines parameters for Disburse.
+type DisburseParams struct {
+	// IdempotencyKey unique key of the request, retrying with the same key returns the original disbursement
+	// instead of creating a new one
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ListDisbursementsParams defines parameters for ListDisbursements.
+type ListDisbursementsParams struct {
+	// Status only list disbursements in one of the statuses
+	Status *[]DisbursementStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedFrom only list disbursements created at or after the time
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo only list disbursements created at or before the time
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MinAmount only list disbursements with amount at least this, requires currency
+	MinAmount *string `form:"min_amount,omitempty" json:"min_amount,omitempty"`
+
+	// MaxAmount only list disbursements with amount at most this, requires currency
+	MaxAmount *string `form:"max_amount,omitempty" json:"max_amount,omitempty"`
+
+	// Currency ISO-4217 currency code of min_amount and max_amount
+	Currency *string `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// Cursor next_cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit max number of disbursements in a page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// CreateDisbursementBatchParams defines parameters for CreateDisbursementBatch.
+type CreateDisbursementBatchParams struct {
+	// IdempotencyKey unique key of the request, retrying with the same key returns the original disbursement
+	// instead of creating a new one
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PreviewFeeParams defines parameters for PreviewFee.
+type PreviewFeeParams struct {
+	// Amount exact decimal amount in major units
+	Amount string `form:"amount" json:"amount"`
+
+	// Currency ISO-4217 currency code of the amount
+	Currency string `form:"currency" json:"currency"`
+
+	// BeneficiaryId beneficiary the disbursement would be paid to
+	BeneficiaryId *openapi_types.UUID `form:"beneficiary_id,omitempty" json:"beneficiary_id,omitempty"`
+
+	// BankCode bank the disbursement would be paid to
+	BankCode *string `form:"bank_code,omitempty" json:"bank_code,omitempty"`
+}
+
+// ReceivePayoutCallbackParams defines parameters for ReceivePayoutCallback.
+type ReceivePayoutCallbackParams struct {
+	// XCallbackSignature hex encoded HMAC-SHA256 of the raw request body, keyed with the secret of the provider
+	XCallbackSignature CallbackSignature `json:"X-Callback-Signature"`
+}
+
+// SetApprovalPolicyJSONRequestBody defines body for SetApprovalPolicy for application/json ContentType.
+type SetApprovalPolicyJSONRequestBody = ApprovalPolicyRequest
+
+// CreateFeeScheduleJSONRequestBody defines body for CreateFeeSchedule for application/json ContentType.
+type CreateFeeScheduleJSONRequestBody = FeeScheduleRequest
+
+// SetMerchantLimitsJSONRequestBody defines body for SetMerchantLimits for application/json ContentType.
+type SetMerchantLimitsJSONRequestBody = MerchantLimitsRequest
+
+// CreateBeneficiaryJSONRequestBody defines body for CreateBeneficiary for application/json ContentType.
+type CreateBeneficiaryJSONRequestBody = BeneficiaryRequest
+
+// UpdateBeneficiaryJSONRequestBody defines body for UpdateBeneficiary for application/json ContentType.
+type UpdateBeneficiaryJSONRequestBody = BeneficiaryRequest
+
 // DisburseJSONRequestBody defines body for Disburse for application/json ContentType.
 type DisburseJSONRequestBody = PostDisburseRequest
+
+// CreateDisbursementBatchJSONRequestBody defines body for CreateDisbursementBatch for application/json ContentType.
+type CreateDisbursementBatchJSONRequestBody = PostDisbursementBatchRequest
+
+// ScheduleDisbursementJSONRequestBody defines body for ScheduleDisbursement for application/json ContentType.
+type ScheduleDisbursementJSONRequestBody = PostScheduledDisbursementRequest
+
+// UploadDisbursementsMultipartRequestBody defines body for UploadDisbursements for multipart/form-data ContentType.
+type UploadDisbursementsMultipartRequestBody = UploadDisbursementsRequest
+
+// CancelDisbursementJSONRequestBody defines body for CancelDisbursement for application/json ContentType.
+type CancelDisbursementJSONRequestBody = DisbursementActionRequest
+
+// RejectDisbursementJSONRequestBody defines body for RejectDisbursement for application/json ContentType.
+type RejectDisbursementJSONRequestBody = DisbursementActionRequest
+
+// ReverseDisbursementJSONRequestBody defines body for ReverseDisbursement for application/json ContentType.
+type ReverseDisbursementJSONRequestBody = DisbursementActionRequest
+
+// CreateFXQuoteJSONRequestBody defines body for CreateFXQuote for application/json ContentType.
+type CreateFXQuoteJSONRequestBody = PostFXQuoteRequest
+
+// ReceivePayoutCallbackJSONRequestBody defines body for ReceivePayoutCallback for application/json ContentType.
+type ReceivePayoutCallbackJSONRequestBody = PayoutCallbackRequest
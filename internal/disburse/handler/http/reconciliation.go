@@ -0,0 +1,66 @@
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/money"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// (GET /reconciliations/{id})
+func (h httpServer) GetReconciliation(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	result, err := h.app.Queries.GetReconciliationRun.Handle(r.Context(), &query.GetReconciliationRunParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	// the run counts cover every merchant, only the discrepancies of the merchant are returned
+	api.RespondWithJSON(w, http.StatusOK, Reconciliation{
+		Id:          result.Run.ID(),
+		Provider:    result.Run.Provider(),
+		PeriodStart: result.Run.PeriodStart(),
+		PeriodEnd:   result.Run.PeriodEnd(),
+		Discrepancies: lo.Map(result.Discrepancies, func(d *disburse.Discrepancy, _ int) ReconciliationDiscrepancy {
+			expectedAmount, expectedCurrency := discrepancyAmount(d.ExpectedAmount())
+			settledAmount, settledCurrency := discrepancyAmount(d.SettledAmount())
+
+			return ReconciliationDiscrepancy{
+				Type:             ReconciliationDiscrepancyType(d.Type().String()),
+				Reference:        d.Reference(),
+				DisbursementId:   lo.EmptyableToPtr(d.DisbursementID()),
+				Line:             lo.EmptyableToPtr(d.LineNumber()),
+				ExpectedAmount:   expectedAmount,
+				ExpectedCurrency: expectedCurrency,
+				SettledAmount:    settledAmount,
+				SettledCurrency:  settledCurrency,
+				Detail:           d.Detail(),
+			}
+		}),
+		CreatedAt: result.Run.CreatedAt(),
+	})
+}
+
+// discrepancyAmount leaves out the zero Money a discrepancy uses for a missing amount
+func discrepancyAmount(m money.Money) (*string, *string) {
+	if !m.Currency().IsValid() {
+		return nil, nil
+	}
+
+	return lo.ToPtr(m.Decimal()), lo.ToPtr(m.Currency().String())
+}
@@ -0,0 +1,129 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/durianpay/dpay-common/dcerrors"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// (POST /disbursements/schedules)
+func (h httpServer) ScheduleDisbursement(w http.ResponseWriter, r *http.Request) {
+	var body PostScheduledDisbursementBody
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		httperr.ResponseWithError(
+			errors.NewIncorrectInputError(
+				dcerrors.ErrReadingRequestBody,
+				dcerrors.ErrReadingRequestBody.Error(),
+				dcerrors.DpayInvalidRequest,
+			),
+			w, r,
+		)
+		return
+	}
+
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	scheduleID := uuid.New()
+
+	err = h.app.Commands.ScheduleDisbursement.Handle(r.Context(), &command.ScheduleDisbursementParam{
+		ID:         scheduleID,
+		MerchantID: merchantID,
+		Amount:     body.Amount,
+		Currency:   body.Currency,
+		FirstRunAt: body.FirstRunAt,
+		Recurrence: lo.FromPtr(body.Recurrence),
+		EndAt:      lo.FromPtr(body.EndAt),
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusCreated, ScheduledDisbursementCreatedResponse{
+		Message:    "Success schedule your disbursement.",
+		ScheduleId: scheduleID,
+	})
+}
+
+// (GET /disbursements/schedules/{id})
+func (h httpServer) GetScheduledDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	schedule, err := h.app.Queries.GetScheduledDisbursement.Handle(r.Context(), &query.GetScheduledDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, toScheduleResponse(schedule))
+}
+
+// (POST /disbursements/schedules/{id}/cancel)
+func (h httpServer) CancelScheduledDisbursement(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	err = h.app.Commands.CancelScheduledDisbursement.Handle(r.Context(), &command.CancelScheduledDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	schedule, err := h.app.Queries.GetScheduledDisbursement.Handle(r.Context(), &query.GetScheduledDisbursementParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, toScheduleResponse(schedule))
+}
+
+func toScheduleResponse(s *disburse.ScheduledDisbursement) ScheduledDisbursement {
+	return ScheduledDisbursement{
+		Id:                s.ID(),
+		Amount:            s.Amount().Decimal(),
+		Currency:          s.Amount().Currency().String(),
+		Recurrence:        lo.EmptyableToPtr(s.Recurrence()),
+		EndAt:             lo.EmptyableToPtr(s.EndAt()),
+		Status:            ScheduledDisbursementStatus(s.Status()),
+		NextRunAt:         s.NextRunAt(),
+		LastRunAt:         lo.EmptyableToPtr(s.LastRunAt()),
+		RunCount:          s.RunCount(),
+		LastFailureReason: lo.EmptyableToPtr(s.LastFailureReason()),
+		CreatedAt:         s.CreatedAt(),
+		UpdatedAt:         s.UpdatedAt(),
+	}
+}
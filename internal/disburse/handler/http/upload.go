@@ -0,0 +1,369 @@
+package httphandler
+
+import (
+	"encoding/csv"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/handler"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/httperr"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/xuri/excelize/v2"
+)
+
+// maxUploadBodySize limits the uploaded file, a XLSX file has to be read whole before its rows can be read
+const maxUploadBodySize = 10 << 20
+
+// maxUploadUnzipSize limits the unzipped XLSX file so a small zip bomb can not exhaust the memory or the disk,
+// a sheet of MaxUploadRows rows is well below it
+const maxUploadUnzipSize = 100 << 20
+
+// maxUploadUnzipXMLSize is the size of a sheet kept in memory when unzipped, a bigger sheet is unzipped to a temp file
+const maxUploadUnzipXMLSize = 16 << 20
+
+// uploadFileField is the multipart form field of the uploaded file
+const uploadFileField = "file"
+
+const (
+	uploadColumnReference     = "reference"
+	uploadColumnAmount        = "amount"
+	uploadColumnCurrency      = "currency"
+	uploadColumnBankCode      = "bank_code"
+	uploadColumnAccountNumber = "account_number"
+)
+
+var uploadColumns = []string{
+	uploadColumnReference,
+	uploadColumnAmount,
+	uploadColumnCurrency,
+	uploadColumnBankCode,
+	uploadColumnAccountNumber,
+}
+
+var (
+	ErrMissingUploadFile       = stderrors.New("missing upload file")
+	ErrUnsupportedUploadFormat = stderrors.New("upload file must be .csv or .xlsx")
+	ErrMissingUploadColumns    = stderrors.New("upload file is missing columns")
+	ErrReadingUploadFile       = stderrors.New("failed to read upload file")
+)
+
+// uploadRowReader reads the uploaded file row by row
+type uploadRowReader interface {
+	// Read returns the cells of the next row, io.EOF after the last row
+	Read() ([]string, error)
+	Close() error
+}
+
+type csvRowReader struct {
+	reader *csv.Reader
+}
+
+func newCSVRowReader(r io.Reader) *csvRowReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return &csvRowReader{
+		reader: reader,
+	}
+}
+
+func (c *csvRowReader) Read() ([]string, error) {
+	return c.reader.Read()
+}
+
+func (c *csvRowReader) Close() error {
+	return nil
+}
+
+// xlsxRowReader reads the first sheet, the raw cell value is read so a formatted amount keeps its precision
+type xlsxRowReader struct {
+	file *excelize.File
+	rows *excelize.Rows
+}
+
+func newXLSXRowReader(r io.Reader) (*xlsxRowReader, error) {
+	file, err := excelize.OpenReader(r, excelize.Options{
+		UnzipSizeLimit:    maxUploadUnzipSize,
+		UnzipXMLSizeLimit: maxUploadUnzipXMLSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := file.Rows(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &xlsxRowReader{
+		file: file,
+		rows: rows,
+	}, nil
+}
+
+func (x *xlsxRowReader) Read() ([]string, error) {
+	if !x.rows.Next() {
+		if err := x.rows.Error(); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}
+
+	return x.rows.Columns(excelize.Options{RawCellValue: true})
+}
+
+func (x *xlsxRowReader) Close() error {
+	return stderrors.Join(x.rows.Close(), x.file.Close())
+}
+
+// (POST /disbursements/uploads)
+func (h httpServer) UploadDisbursements(w http.ResponseWriter, r *http.Request) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBodySize)
+
+	fileName, rows, err := readUploadFile(r)
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	uploadID := uuid.New()
+
+	// an upload of the merchant without user is uploaded by the merchant itself
+	user, _ := handler.UserFromContext(r.Context())
+
+	err = h.app.Commands.UploadDisbursements.Handle(r.Context(), &command.UploadDisbursementsParam{
+		ID:         uploadID,
+		MerchantID: merchantID,
+		FileName:   fileName,
+		Rows:       rows,
+		CreatedBy:  user.ID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusAccepted, DisbursementUploadAcceptedResponse{
+		Message:  "Success receive your disbursement upload.",
+		UploadId: uploadID,
+		RowCount: len(rows),
+	})
+}
+
+// (GET /disbursements/uploads/{id})
+func (h httpServer) GetDisbursementUpload(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	merchantID, err := handler.MerchantIDFromContext(r.Context())
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	progress, err := h.app.Queries.GetDisbursementUpload.Handle(r.Context(), &query.GetDisbursementUploadParam{
+		ID:         id,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		httperr.ResponseWithError(err, w, r)
+		return
+	}
+
+	api.RespondWithJSON(w, http.StatusOK, DisbursementUpload{
+		Id:       progress.Upload.ID(),
+		FileName: progress.Upload.FileName(),
+		Status:   DisbursementUploadStatus(progress.Upload.Status().String()),
+		RowCount: progress.Upload.RowCount(),
+		RowCounts: lo.MapKeys(progress.RowCounts, func(_ int, status disburse.UploadRowStatus) string {
+			return status.String()
+		}),
+		FailedRows: lo.Map(progress.FailedRows, func(row *disburse.UploadRow, _ int) DisbursementUploadFailedRow {
+			return DisbursementUploadFailedRow{
+				Row:           row.Number(),
+				Reference:     row.Reference(),
+				FailureReason: row.FailureReason(),
+			}
+		}),
+		CreatedAt:   progress.Upload.CreatedAt(),
+		CompletedAt: lo.EmptyableToPtr(progress.Upload.CompletedAt()),
+	})
+}
+
+// readUploadFile streams the multipart body up to the file part and reads its rows
+func readUploadFile(r *http.Request) (string, []command.UploadDisbursementRow, error) {
+	multipartReader, err := r.MultipartReader()
+	if err != nil {
+		return "", nil, newReadingUploadFileError(err)
+	}
+
+	for {
+		part, err := multipartReader.NextPart()
+		if stderrors.Is(err, io.EOF) {
+			return "", nil, errors.NewIncorrectInputError(
+				ErrMissingUploadFile,
+				fmt.Sprintf("%s, send the file in the form field %q", ErrMissingUploadFile.Error(), uploadFileField),
+				errors.DpayInvalidRequest,
+			)
+		}
+
+		if err != nil {
+			return "", nil, newReadingUploadFileError(err)
+		}
+
+		if part.FormName() != uploadFileField {
+			part.Close()
+			continue
+		}
+
+		defer part.Close()
+
+		reader, err := newUploadRowReader(part)
+		if err != nil {
+			return "", nil, err
+		}
+
+		defer reader.Close()
+
+		rows, err := readUploadRows(reader)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return filepath.Base(part.FileName()), rows, nil
+	}
+}
+
+func newUploadRowReader(part *multipart.Part) (uploadRowReader, error) {
+	switch strings.ToLower(filepath.Ext(part.FileName())) {
+	case ".csv":
+		return newCSVRowReader(part), nil
+	case ".xlsx":
+		reader, err := newXLSXRowReader(part)
+		if err != nil {
+			return nil, newReadingUploadFileError(err)
+		}
+
+		return reader, nil
+	default:
+		return nil, errors.NewIncorrectInputError(
+			ErrUnsupportedUploadFormat,
+			ErrUnsupportedUploadFormat.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+}
+
+// readUploadRows reads the header then the rows, the row number counts the header as row 1 like a spreadsheet does.
+// Blank rows are skipped, reading stops right after the row limit is passed.
+func readUploadRows(reader uploadRowReader) ([]command.UploadDisbursementRow, error) {
+	header, err := reader.Read()
+	if stderrors.Is(err, io.EOF) {
+		return nil, errors.NewIncorrectInputError(
+			disburse.ErrEmptyUpload,
+			disburse.ErrEmptyUpload.Error(),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	if err != nil {
+		return nil, newReadingUploadFileError(err)
+	}
+
+	columnIndex, err := indexUploadColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []command.UploadDisbursementRow
+
+	for number := 2; ; number++ {
+		cells, err := reader.Read()
+		if stderrors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, newReadingUploadFileError(err)
+		}
+
+		if lo.EveryBy(cells, func(cell string) bool { return strings.TrimSpace(cell) == "" }) {
+			continue
+		}
+
+		// the command rejects the rows over the limit, the extra row is only read to know the limit is passed
+		if len(rows) > disburse.MaxUploadRows {
+			return rows, nil
+		}
+
+		cell := func(column string) string {
+			index := columnIndex[column]
+			if index >= len(cells) {
+				return ""
+			}
+
+			return strings.TrimSpace(cells[index])
+		}
+
+		rows = append(rows, command.UploadDisbursementRow{
+			Number:        number,
+			Reference:     cell(uploadColumnReference),
+			Amount:        cell(uploadColumnAmount),
+			Currency:      cell(uploadColumnCurrency),
+			BankCode:      cell(uploadColumnBankCode),
+			AccountNumber: cell(uploadColumnAccountNumber),
+		})
+	}
+}
+
+// indexUploadColumns finds the position of every column in the header, the header is case insensitive
+func indexUploadColumns(header []string) (map[string]int, error) {
+	columnIndex := make(map[string]int, len(uploadColumns))
+	for i, name := range header {
+		columnIndex[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	missing := lo.Filter(uploadColumns, func(column string, _ int) bool {
+		_, ok := columnIndex[column]
+		return !ok
+	})
+
+	if len(missing) > 0 {
+		return nil, errors.NewIncorrectInputError(
+			ErrMissingUploadColumns,
+			fmt.Sprintf("%s: %s", ErrMissingUploadColumns.Error(), strings.Join(missing, ", ")),
+			errors.DpayInvalidRequest,
+			lo.Map(missing, func(column string, _ int) api.ErrorInfo {
+				return api.ErrorInfo{Field: column, Message: "column is missing in the header"}
+			})...,
+		)
+	}
+
+	return columnIndex, nil
+}
+
+func newReadingUploadFileError(err error) error {
+	return errors.NewIncorrectInputError(
+		err,
+		fmt.Sprintf("%s: %s", ErrReadingUploadFile.Error(), err.Error()),
+		errors.DpayInvalidRequest,
+	)
+}
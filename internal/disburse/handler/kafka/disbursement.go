@@ -2,17 +2,26 @@ package kafkahandler
 
 import (
 	"context"
-	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strings"
 
-	"github.com/durianpay/dpay-common/logger"
+	"github.com/google/uuid"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
 	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
 	schemakafka "github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
-	"github.com/segmentio/kafka-go"
 )
 
+// ErrEmptyMessageID rejects a disburse message without id, the id is its idempotency key so without it
+// every redelivery would create another disbursement
+var ErrEmptyMessageID = stderrors.New("kafka message id is required")
+
+// ErrUnsupportedStatusTarget rejects a status update to anything but the outcome of a payout
+var ErrUnsupportedStatusTarget = stderrors.New("status can only be updated to SUCCESS or FAILED from kafka")
+
 // can use interface if neede
 
 type DisbursementKafkaReader struct {
@@ -25,28 +34,145 @@ func NewDisbursementKafkaReader(apps *app.Application) *DisbursementKafkaReader
 	}
 }
 
-func (r DisbursementKafkaReader) DisburseProcessor(ctx context.Context, message kafka.Message) error {
-	var body commonkafka.ResponseMessage[schemakafka.DisburseKafkaRequest]
+// Register adds the disbursement message handlers to the router
+func (r DisbursementKafkaReader) Register(router *commonkafka.Router) {
+	commonkafka.Handle(
+		router,
+		schemakafka.DisbursementRequestType,
+		schemakafka.DisburseSubType,
+		schemakafka.DisburseKafkaRequestPayload,
+		r.DisburseProcessor,
+	)
+	commonkafka.Handle(
+		router,
+		schemakafka.DisbursementRequestType,
+		schemakafka.CancelDisbursementSubType,
+		schemakafka.CancelDisbursementKafkaRequestPayload,
+		r.CancelProcessor,
+	)
+	commonkafka.Handle(
+		router,
+		schemakafka.DisbursementRequestType,
+		schemakafka.ReverseDisbursementSubType,
+		schemakafka.ReverseDisbursementKafkaRequestPayload,
+		r.ReverseProcessor,
+	)
+	commonkafka.Handle(
+		router,
+		schemakafka.DisbursementRequestType,
+		schemakafka.UpdateDisbursementStatusSubType,
+		schemakafka.UpdateDisbursementStatusKafkaRequestPayload,
+		r.UpdateStatusProcessor,
+	)
 
-	err := json.Unmarshal(message.Value, &body)
-	if err != nil {
-		// TODO: this can moved to not logging on here
-		logger.Errorw(
-			ctx, "error unmarshalling kafka message",
-			"error", err.Error(),
-			"request", string(message.Value),
-			"headers", message.Headers,
+	// the producers from before the router send the disburse request without type and subtype
+	commonkafka.Handle(
+		router,
+		"",
+		"",
+		schemakafka.DisburseKafkaRequestPayload,
+		r.DisburseProcessor,
+	)
+}
+
+func (r DisbursementKafkaReader) DisburseProcessor(
+	ctx context.Context,
+	body commonkafka.ResponseMessage[schemakafka.DisburseKafkaRequest],
+) error {
+	if body.ID == "" {
+		return errors.NewIncorrectInputError(
+			ErrEmptyMessageID,
+			ErrEmptyMessageID.Error(),
+			errors.DpayInvalidRequest,
 		)
+	}
 
-		return errors.NewDpayError(
-			err,
-			"error unmarshalling kafka message",
-			errors.DpayInternalError,
+	// the fx quote id is validated by the payload, an empty one is uuid.Nil
+	fxQuoteID, _ := uuid.Parse(body.Data.FXQuoteID)
+
+	// the message id is stable across redelivery, so it's used as idempotency key
+	err := r.app.Commands.Disburse.Handle(ctx, &command.DisburseParam{
+		ID:             disburse.NewDisbursementID(body.Data.MerchantID, body.ID),
+		MerchantID:     body.Data.MerchantID,
+		IdempotencyKey: body.ID,
+		Amount:         body.Data.Amount.String(),
+		Currency:       body.Data.Currency,
+		FXQuoteID:      fxQuoteID,
+	})
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func (r DisbursementKafkaReader) CancelProcessor(
+	ctx context.Context,
+	body commonkafka.ResponseMessage[schemakafka.CancelDisbursementKafkaRequest],
+) error {
+	id, err := parseDisbursementID(body.Data.ID)
+	if err != nil {
+		return err
+	}
+
+	err = r.app.Commands.CancelDisbursement.Handle(ctx, &command.CancelDisbursementParam{
+		ID:     id,
+		Reason: body.Data.Reason,
+		Actor:  body.Data.RequestedBy,
+		Source: disburse.AuditSourceKafka,
+	})
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func (r DisbursementKafkaReader) ReverseProcessor(
+	ctx context.Context,
+	body commonkafka.ResponseMessage[schemakafka.ReverseDisbursementKafkaRequest],
+) error {
+	id, err := parseDisbursementID(body.Data.ID)
+	if err != nil {
+		return err
+	}
+
+	err = r.app.Commands.ReverseDisbursement.Handle(ctx, &command.ReverseDisbursementParam{
+		ID:     id,
+		Reason: body.Data.Reason,
+		Actor:  body.Data.RequestedBy,
+		Source: disburse.AuditSourceKafka,
+	})
+	if err != nil {
+		return errors.WrapDpayErrTrace(err)
+	}
+
+	return nil
+}
+
+func (r DisbursementKafkaReader) UpdateStatusProcessor(
+	ctx context.Context,
+	body commonkafka.ResponseMessage[schemakafka.UpdateDisbursementStatusKafkaRequest],
+) error {
+	id, err := parseDisbursementID(body.Data.ID)
+	if err != nil {
+		return err
+	}
+
+	// the payout provider only reports the outcome of a payout, the other statuses are set by their own flow
+	target := disburse.Status(strings.ToUpper(strings.TrimSpace(body.Data.Status)))
+	if target != disburse.StatusSuccess && target != disburse.StatusFailed {
+		return errors.NewIncorrectInputError(
+			ErrUnsupportedStatusTarget,
+			fmt.Sprintf("%s: %q", ErrUnsupportedStatusTarget.Error(), body.Data.Status),
+			errors.DpayInvalidRequest,
 		)
 	}
 
-	err = r.app.Commands.Disburse.Handle(ctx, &command.DisburseParam{
-		Amount: body.Data.Amount,
+	err = r.app.Commands.UpdateDisbursementStatus.Handle(ctx, &command.UpdateDisbursementStatusParam{
+		ID:            id,
+		Status:        body.Data.Status,
+		FailureReason: body.Data.FailureReason,
 	})
 	if err != nil {
 		return errors.WrapDpayErrTrace(err)
@@ -54,3 +180,16 @@ func (r DisbursementKafkaReader) DisburseProcessor(ctx context.Context, message
 
 	return nil
 }
+
+func parseDisbursementID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, errors.NewIncorrectInputError(
+			err,
+			"invalid disbursement id",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return id, nil
+}
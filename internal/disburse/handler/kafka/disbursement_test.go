@@ -0,0 +1,151 @@
+package kafkahandler
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
+	schemakafka "github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
+)
+
+func TestDisburseProcessor(t *testing.T) {
+	tests := []struct {
+		name    string
+		msgID   string
+		wantErr error
+	}{
+		{name: "message id as idempotency key", msgID: "msg-1"},
+		{name: "message without id", msgID: "", wantErr: ErrEmptyMessageID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeCommandHandler[*command.DisburseParam]{}
+			reader := NewDisbursementKafkaReader(&app.Application{
+				Commands: app.Commands{Disburse: handler},
+			})
+
+			err := reader.DisburseProcessor(context.Background(), commonkafka.ResponseMessage[schemakafka.DisburseKafkaRequest]{
+				ID: tt.msgID,
+				Data: schemakafka.DisburseKafkaRequest{
+					MerchantID: "merchant-1",
+					Amount:     "10000",
+					Currency:   "IDR",
+				},
+			})
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("DisburseProcessor error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				// a client error is not retried, the message goes to the dead-letter topic
+				if !errors.IsClientError(err) {
+					t.Errorf("DisburseProcessor error = %v, want a client error", err)
+				}
+
+				if len(handler.calls) != 0 {
+					t.Errorf("Disburse called %d times, want 0", len(handler.calls))
+				}
+
+				return
+			}
+
+			if len(handler.calls) != 1 {
+				t.Fatalf("Disburse called %d times, want 1", len(handler.calls))
+			}
+
+			param := handler.calls[0]
+			if param.IdempotencyKey != tt.msgID || param.ID != disburse.NewDisbursementID("merchant-1", tt.msgID) {
+				t.Errorf("Disburse id = %s, idempotency key = %q, want derived from %q", param.ID, param.IdempotencyKey, tt.msgID)
+			}
+		})
+	}
+}
+
+func TestCancelProcessorActor(t *testing.T) {
+	handler := &fakeCommandHandler[*command.CancelDisbursementParam]{}
+	reader := NewDisbursementKafkaReader(&app.Application{
+		Commands: app.Commands{CancelDisbursement: handler},
+	})
+
+	id := uuid.New()
+	err := reader.CancelProcessor(context.Background(), commonkafka.ResponseMessage[schemakafka.CancelDisbursementKafkaRequest]{
+		ID: "msg-1",
+		Data: schemakafka.CancelDisbursementKafkaRequest{
+			ID:          id.String(),
+			Reason:      "duplicate payout",
+			RequestedBy: "ops-user-1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CancelProcessor error = %v", err)
+	}
+
+	if len(handler.calls) != 1 {
+		t.Fatalf("CancelDisbursement called %d times, want 1", len(handler.calls))
+	}
+
+	if param := handler.calls[0]; param.ID != id || param.Actor != "ops-user-1" {
+		t.Errorf("CancelDisbursement id = %s, actor = %q, want %s by ops-user-1", param.ID, param.Actor, id)
+	}
+}
+
+func TestUpdateStatusProcessor(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		wantErr error
+	}{
+		{name: "paid out", status: "SUCCESS"},
+		{name: "failed payout", status: "failed"},
+		{name: "processing", status: "PROCESSING", wantErr: ErrUnsupportedStatusTarget},
+		{name: "cancelled", status: "CANCELLED", wantErr: ErrUnsupportedStatusTarget},
+		{name: "reversed", status: "REVERSED", wantErr: ErrUnsupportedStatusTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeCommandHandler[*command.UpdateDisbursementStatusParam]{}
+			reader := NewDisbursementKafkaReader(&app.Application{
+				Commands: app.Commands{UpdateDisbursementStatus: handler},
+			})
+
+			err := reader.UpdateStatusProcessor(context.Background(), commonkafka.ResponseMessage[schemakafka.UpdateDisbursementStatusKafkaRequest]{
+				ID: "msg-1",
+				Data: schemakafka.UpdateDisbursementStatusKafkaRequest{
+					ID:     uuid.NewString(),
+					Status: tt.status,
+				},
+			})
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateStatusProcessor error = %v, want %v", err, tt.wantErr)
+			}
+
+			calls := 1
+			if tt.wantErr != nil {
+				calls = 0
+			}
+
+			if len(handler.calls) != calls {
+				t.Errorf("UpdateDisbursementStatus called %d times, want %d", len(handler.calls), calls)
+			}
+		})
+	}
+}
+
+// fakeCommandHandler records the commands it handles and answers them with err
+type fakeCommandHandler[C any] struct {
+	calls []C
+	err   error
+}
+
+func (h *fakeCommandHandler[C]) Handle(_ context.Context, cmd C) error {
+	h.calls = append(h.calls, cmd)
+	return h.err
+}
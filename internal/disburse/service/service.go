@@ -2,20 +2,39 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"time"
 
+	commoncfg "github.com/durianpay/dpay-common/config"
 	"github.com/durianpay/dpay-common/logger"
 	"github.com/durianpay/dpay-common/proto/client"
 	"github.com/layarda-durianpay/go-skeleton/internal/config"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/adapter"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/ledger"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/outbox"
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
 	"go.uber.org/zap"
 )
 
-const serviceName = "disbursement_service"
+const (
+	serviceName = "disbursement_service"
+
+	payoutProviderSimulator = "simulator"
+
+	nameInquiryProviderStub = "stub"
+
+	// environmentProduction is the ENV of production, where no stub may answer in place of a bank
+	environmentProduction = commoncfg.Environment("production")
+
+	// limitCacheTTL is how long a change of the merchant limits takes to reach the other instances
+	limitCacheTTL = time.Minute
+)
 
 type closeFn func() error
 
@@ -36,18 +55,90 @@ func NewApplication(zapLogger *zap.SugaredLogger) (app.Application, closeFn) {
 	}
 
 	// repository
-	disburseRepo := adapter.NewPostgresDisbursementRepository(db)
-
-	return newApplication(
-			db,
-			zapLogger,
-			merchantGRPCClient,
-			disburseRepo,
-		), close(
-			db,
-			zapLogger,
-			merchantGRPCClient,
-		)
+	ledgerRepo := adapter.NewPostgresLedgerRepository(db)
+	disburseRepo := adapter.NewPostgresDisbursementRepository(
+		db,
+		sqlwrap.ProvideManager(db),
+		outbox.NewPostgresStore(db),
+		ledgerRepo,
+	)
+	callbackRepo := adapter.NewPostgresCallbackRepository(db)
+	auditRepo := adapter.NewPostgresAuditRepository(db)
+	uploadRepo := adapter.NewPostgresUploadRepository(db, sqlwrap.ProvideManager(db))
+	scheduleRepo := adapter.NewPostgresScheduleRepository(db)
+	reconciliationRepo := adapter.NewPostgresReconciliationRepository(db, sqlwrap.ProvideManager(db))
+	limitRepo := adapter.NewCachedLimitRepository(adapter.NewPostgresLimitRepository(db), limitCacheTTL)
+	beneficiaryRepo := adapter.NewPostgresBeneficiaryRepository(db)
+	approvalRepo := adapter.NewPostgresApprovalRepository(db)
+	feeRepo := adapter.NewPostgresFeeRepository(db)
+	fxQuoteRepo := adapter.NewPostgresFXQuoteRepository(db)
+
+	defaultLimits := adapter.NewConfigDefaultLimits(disbursementConf.GetDisbursementLimitDefaults)
+
+	merchantBalance := adapter.NewGRPCMerchantBalance(&merchantGRPCClient)
+
+	// a beneficiary registered without the name of its bank account could be paid to anyone, so unlike the payout
+	// provider every subcommand refuses to start without name inquiry
+	nameInquiry, err := newNameInquiry(disbursementConf, commoncfg.Env())
+	if err != nil {
+		logger.Errorw(context.Background(), "error initializing name inquiry", "error", err.Error())
+		panic(err)
+	}
+
+	// no rate provider is integrated yet, every environment quotes from the local rate table
+	fxRateProvider, err := adapter.NewFXRateStub(nil)
+	if err != nil {
+		logger.Errorw(context.Background(), "error initializing fx rate stub", "error", err.Error())
+		panic(err)
+	}
+
+	// the payout result callback applies the result through the application built right after the provider
+	var application app.Application
+
+	payoutProvider, err := newPayoutProvider(
+		disbursementConf,
+		func(ctx context.Context, result disburse.PayoutResult) error {
+			return application.Commands.UpdateDisbursementStatus.Handle(ctx, &command.UpdateDisbursementStatusParam{
+				ID:            result.DisbursementID,
+				Status:        result.Status.String(),
+				FailureReason: result.FailureReason,
+			})
+		},
+	)
+	if err != nil {
+		// only the scheduler sends payouts, it refuses to start without a provider while the other subcommands run
+		logger.Warnw(context.Background(), "payouts can not be sent", "error", err.Error())
+	}
+
+	application = newApplication(
+		db,
+		zapLogger,
+		merchantGRPCClient,
+		disburseRepo,
+		callbackRepo,
+		auditRepo,
+		uploadRepo,
+		scheduleRepo,
+		ledgerRepo,
+		reconciliationRepo,
+		limitRepo,
+		defaultLimits,
+		beneficiaryRepo,
+		nameInquiry,
+		approvalRepo,
+		feeRepo,
+		fxQuoteRepo,
+		fxRateProvider,
+		merchantBalance,
+		payoutProvider,
+	)
+
+	return application, close(
+		db,
+		zapLogger,
+		merchantGRPCClient,
+		payoutProvider,
+	)
 }
 
 func newApplication(
@@ -59,7 +150,37 @@ func newApplication(
 
 	// repo related
 	disburseRepository disburse.DisburseRepository,
+	callbackRepository disburse.CallbackRepository,
+	auditRepository disburse.AuditRepository,
+	uploadRepository disburse.UploadRepository,
+	scheduleRepository disburse.ScheduleRepository,
+	ledgerRepository ledger.Repository,
+	reconciliationRepository disburse.ReconciliationRepository,
+	limitRepository disburse.LimitRepository,
+	defaultLimits disburse.DefaultLimits,
+	beneficiaryRepository disburse.BeneficiaryRepository,
+	nameInquiry disburse.NameInquiry,
+	approvalRepository disburse.ApprovalRepository,
+	feeRepository disburse.FeeRepository,
+	fxQuoteRepository disburse.FXQuoteRepository,
+	fxRateProvider disburse.FXRateProvider,
+	merchantBalance disburse.MerchantBalance,
+	payoutProvider disburse.PayoutProvider,
 ) app.Application {
+	disburseHandler := command.NewDisburseHandler(
+		sqlwrap.ProvideManager(db),
+		disburseRepository,
+		beneficiaryRepository,
+		fxQuoteRepository,
+		approvalRepository,
+		feeRepository,
+		merchantBalance,
+		limitRepository,
+		defaultLimits,
+	)
+
+	createBeneficiaryHandler := command.NewCreateBeneficiaryHandler(beneficiaryRepository, nameInquiry)
+
 	return app.Application{
 		Dependencies: app.Dependencies{
 			DB:                 db,
@@ -67,9 +188,157 @@ func newApplication(
 			Logger:             logger,
 		},
 		Commands: app.Commands{
-			Disburse: command.NewDisburseHandler(disburseRepository),
+			Disburse: disburseHandler,
+			DisburseBatch: command.NewDisburseBatchHandler(
+				sqlwrap.ProvideManager(db),
+				disburseRepository,
+				approvalRepository,
+				feeRepository,
+				merchantBalance,
+				limitRepository,
+				defaultLimits,
+			),
+			UploadDisbursements: command.NewUploadDisbursementsHandler(uploadRepository),
+			ProcessUploads: command.NewProcessUploadsHandler(
+				uploadRepository,
+				beneficiaryRepository,
+				createBeneficiaryHandler,
+				disburseHandler,
+			),
+			UpdateDisbursementStatus: command.NewUpdateDisbursementStatusHandler(disburseRepository, merchantBalance),
+			HandlePayoutCallback: command.NewHandlePayoutCallbackHandler(
+				sqlwrap.ProvideManager(db),
+				disburseRepository,
+				callbackRepository,
+				merchantBalance,
+			),
+			DispatchPayouts: command.NewDispatchPayoutsHandler(
+				sqlwrap.ProvideManager(db),
+				disburseRepository,
+				merchantBalance,
+				payoutProvider,
+			),
+
+			CancelDisbursement: command.NewCancelDisbursementHandler(
+				disburseRepository,
+				auditRepository,
+				merchantBalance,
+			),
+			ReverseDisbursement: command.NewReverseDisbursementHandler(
+				disburseRepository,
+				auditRepository,
+				merchantBalance,
+			),
+
+			ApproveDisbursement: command.NewApproveDisbursementHandler(disburseRepository, approvalRepository),
+			RejectDisbursement: command.NewRejectDisbursementHandler(
+				disburseRepository,
+				approvalRepository,
+				merchantBalance,
+			),
+			ExpireApprovals: command.NewExpireApprovalsHandler(
+				sqlwrap.ProvideManager(db),
+				disburseRepository,
+				approvalRepository,
+				merchantBalance,
+			),
+			SetApprovalPolicy: command.NewSetApprovalPolicyHandler(approvalRepository),
+
+			ScheduleDisbursement:        command.NewScheduleDisbursementHandler(scheduleRepository),
+			CancelScheduledDisbursement: command.NewCancelScheduledDisbursementHandler(scheduleRepository),
+			RunDueSchedules:             command.NewRunDueSchedulesHandler(scheduleRepository, disburseHandler),
+
+			ReconcileSettlement: command.NewReconcileSettlementHandler(
+				disburseRepository,
+				reconciliationRepository,
+				newSettlementParsers(),
+			),
+
+			SetMerchantLimits: command.NewSetMerchantLimitsHandler(limitRepository),
+
+			CreateFeeSchedule: command.NewCreateFeeScheduleHandler(feeRepository),
+
+			CreateFXQuote: command.NewCreateFXQuoteHandler(fxQuoteRepository, fxRateProvider),
+
+			CreateBeneficiary: createBeneficiaryHandler,
+			UpdateBeneficiary: command.NewUpdateBeneficiaryHandler(beneficiaryRepository, nameInquiry),
+			DeleteBeneficiary: command.NewDeleteBeneficiaryHandler(beneficiaryRepository),
+		},
+		Queries: app.Queries{
+			GetDisbursement:   query.NewGetDisbursementHandler(disburseRepository),
+			ListDisbursements: query.NewListDisbursementsHandler(disburseRepository),
+
+			GetDisbursementBatch:  query.NewGetDisbursementBatchHandler(disburseRepository),
+			GetDisbursementUpload: query.NewGetDisbursementUploadHandler(uploadRepository),
+
+			GetScheduledDisbursement: query.NewGetScheduledDisbursementHandler(scheduleRepository),
+
+			GetMerchantLedgerBalances: query.NewGetMerchantLedgerBalancesHandler(ledgerRepository),
+			GetLedgerAccountBalances:  query.NewGetLedgerAccountBalancesHandler(ledgerRepository),
+
+			GetReconciliationRun: query.NewGetReconciliationRunHandler(reconciliationRepository),
+
+			GetMerchantLimits: query.NewGetMerchantLimitsHandler(limitRepository, defaultLimits),
+
+			GetFeeSchedule: query.NewGetFeeScheduleHandler(feeRepository),
+			PreviewFee:     query.NewPreviewFeeHandler(feeRepository, beneficiaryRepository),
+
+			GetFXQuote: query.NewGetFXQuoteHandler(fxQuoteRepository),
+
+			GetApprovalPolicy:        query.NewGetApprovalPolicyHandler(approvalRepository),
+			GetDisbursementApprovals: query.NewGetDisbursementApprovalsHandler(disburseRepository, approvalRepository),
+
+			GetBeneficiary:    query.NewGetBeneficiaryHandler(beneficiaryRepository),
+			ListBeneficiaries: query.NewListBeneficiariesHandler(beneficiaryRepository),
 		},
-		Queries: app.Queries{},
+	}
+}
+
+// newPayoutProvider returns disburse.ErrPayoutProviderNotConfigured for an unset or unknown PAYOUT_PROVIDER,
+// there is no fallback on purpose so a missing config does not end up paying out through the simulator
+func newPayoutProvider(
+	conf config.DisbursementServiceConfig,
+	callback disburse.PayoutCallback,
+) (disburse.PayoutProvider, error) {
+	switch conf.GetPayoutProvider() {
+	case payoutProviderSimulator:
+		return adapter.NewPayoutSimulator(
+			adapter.PayoutSimulatorConfig{
+				Latency:       time.Duration(conf.GetPayoutSimulatorLatencyMs()) * time.Millisecond,
+				FailureRate:   float64(conf.GetPayoutSimulatorFailurePercent()) / 100,
+				CallbackDelay: time.Duration(conf.GetPayoutSimulatorCallbackDelayMs()) * time.Millisecond,
+			},
+			callback,
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", disburse.ErrPayoutProviderNotConfigured, conf.GetPayoutProvider())
+	}
+}
+
+// newNameInquiry returns disburse.ErrNameInquiryNotConfigured for an unset or unknown NAME_INQUIRY_PROVIDER,
+// and for the stub in production
+func newNameInquiry(conf config.DisbursementServiceConfig, env commoncfg.Environment) (disburse.NameInquiry, error) {
+	switch conf.GetNameInquiryProvider() {
+	case nameInquiryProviderStub:
+		if env == environmentProduction {
+			return nil, fmt.Errorf("%w: the stub can not run in %s", disburse.ErrNameInquiryNotConfigured, env)
+		}
+
+		return adapter.NewNameInquiryStub(nil), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", disburse.ErrNameInquiryNotConfigured, conf.GetNameInquiryProvider())
+	}
+}
+
+// newSettlementParsers returns the settlement report parser per payout provider,
+// a provider without parser can not be reconciled
+func newSettlementParsers() map[string]disburse.SettlementParser {
+	return map[string]disburse.SettlementParser{
+		payoutProviderSimulator: adapter.NewCSVSettlementParser(adapter.CSVSettlementColumns{
+			Reference: "reference",
+			Amount:    "amount",
+			Currency:  "currency",
+		}),
 	}
 }
 
@@ -79,10 +348,20 @@ func close(
 
 	// grpc related
 	merchantService client.MerchantServiceClient,
+
+	// a provider settling payouts in the background is closed before the db its callback writes to
+	payoutProvider disburse.PayoutProvider,
 ) closeFn {
 	return func() (err error) {
 		var errs = make(map[string]error)
 
+		if closer, ok := payoutProvider.(io.Closer); ok {
+			err = closer.Close()
+			if err != nil {
+				errs["failed to close payout provider"] = err
+			}
+		}
+
 		if zapLogger != nil {
 			err = zapLogger.Sync()
 			if err != nil {
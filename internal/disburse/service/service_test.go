@@ -0,0 +1,70 @@
+package service
+
+import (
+	stderrors "errors"
+	"testing"
+
+	commoncfg "github.com/durianpay/dpay-common/config"
+	"github.com/layarda-durianpay/go-skeleton/internal/config"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+)
+
+// fakeDisbursementConfig answers the name inquiry provider, the methods the tests do not use panic
+type fakeDisbursementConfig struct {
+	config.DisbursementServiceConfig
+
+	nameInquiryProvider string
+}
+
+func (c fakeDisbursementConfig) GetNameInquiryProvider() string {
+	return c.nameInquiryProvider
+}
+
+func TestNewNameInquiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		env      commoncfg.Environment
+		wantErr  bool
+	}{
+		{
+			name:     "stub in development",
+			provider: nameInquiryProviderStub,
+			env:      "development",
+		},
+		{
+			name:     "stub in production",
+			provider: nameInquiryProviderStub,
+			env:      environmentProduction,
+			wantErr:  true,
+		},
+		{
+			name:    "unset provider",
+			env:     "development",
+			wantErr: true,
+		},
+		{
+			name:     "unknown provider",
+			provider: "bank",
+			env:      "development",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nameInquiry, err := newNameInquiry(fakeDisbursementConfig{nameInquiryProvider: tt.provider}, tt.env)
+			if !tt.wantErr {
+				if err != nil || nameInquiry == nil {
+					t.Fatalf("newNameInquiry = %v, %v, want a name inquiry", nameInquiry, err)
+				}
+
+				return
+			}
+
+			if !stderrors.Is(err, disburse.ErrNameInquiryNotConfigured) {
+				t.Errorf("newNameInquiry error = %v, want %v", err, disburse.ErrNameInquiryNotConfigured)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	dpayconstants "github.com/durianpay/dpay-common/constants"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authTokenMetadataKey is the metadata key of the gRPC call carrying the bearer token of the merchant or the user
+// the calling service acts for, the service credential itself is checked by client.AuthServerInterceptor
+const authTokenMetadataKey = "x-auth-token"
+
+// authContextKeys are the identity of the authenticated caller put on the context from the claims of its token
+var authContextKeys = map[string]dpayconstants.ContextKey{
+	"merchant_id": constants.MerchantIDKey,
+	"user_id":     constants.UserIDKey,
+	"user_role":   constants.UserRoleKey,
+}
+
+// authTokenVerifier checks the signature of a bearer token before its claims are trusted as the identity of the caller
+type authTokenVerifier struct {
+	merchantSecrets     [][]byte
+	internalUserSecrets [][]byte
+}
+
+func newAuthTokenVerifier(merchantSecrets [][]byte, internalUserSecrets [][]byte) authTokenVerifier {
+	return authTokenVerifier{
+		merchantSecrets:     merchantSecrets,
+		internalUserSecrets: internalUserSecrets,
+	}
+}
+
+// claims returns the claims of the token when it is signed with one of the secrets and not expired,
+// a token signed with anything else, including the none algorithm, is rejected
+func claims(token string, secrets [][]byte) (jwt.MapClaims, bool) {
+	if token == "" || len(secrets) == 0 {
+		return nil, false
+	}
+
+	keys := make([]jwt.VerificationKey, 0, len(secrets))
+	for _, secret := range secrets {
+		keys = append(keys, secret)
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(
+		token,
+		claims,
+		func(*jwt.Token) (any, error) {
+			return jwt.VerificationKeySet{Keys: keys}, nil
+		},
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodHS384.Alg(),
+			jwt.SigningMethodHS512.Alg(),
+		}),
+	)
+	if err != nil {
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// withIdentity puts the merchant and the user of the verified token on the context, or the internal user when
+// it is signed with the internal user secret. An unverified token gets no identity and is rejected by the handlers
+// needing it.
+func (v authTokenVerifier) withIdentity(ctx context.Context, token string) context.Context {
+	if merchantClaims, ok := claims(token, v.merchantSecrets); ok {
+		for claim, ctxKey := range authContextKeys {
+			if val, ok := merchantClaims[claim].(string); ok && val != "" {
+				ctx = context.WithValue(ctx, ctxKey, val)
+			}
+		}
+
+		return ctx
+	}
+
+	// the merchant claims of an internal user token are ignored, an internal user never acts as a merchant
+	if internalUserClaims, ok := claims(token, v.internalUserSecrets); ok {
+		if val, ok := internalUserClaims["user_id"].(string); ok && val != "" {
+			ctx = context.WithValue(ctx, constants.InternalUserIDKey, val)
+		}
+	}
+
+	return ctx
+}
+
+// httpMiddleware puts the identity of the bearer token on the request context.
+// A request without bearer token, e.g. the payout callbacks, gets no identity.
+func (v authTokenVerifier) httpMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get(dpayconstants.Authorization), "Bearer ")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(v.withIdentity(r.Context(), token)))
+	})
+}
+
+// unaryServerInterceptor puts the identity of the token the calling service forwards on the context, so a service
+// only acts for the merchant and the user that signed in. It must run after client.AuthServerInterceptor.
+func (v authTokenVerifier) unaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	if val := md.Get(authTokenMetadataKey); len(val) == 1 {
+		ctx = v.withIdentity(ctx, val[0])
+	}
+
+	return handler(ctx, req)
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/layarda-durianpay/go-skeleton/internal/constants"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthTokenVerifier(t *testing.T) {
+	secret := []byte("merchant-secret")
+	internalUserSecret := []byte("internal-user-secret")
+	verifier := newAuthTokenVerifier([][]byte{[]byte("snap-secret"), secret}, [][]byte{internalUserSecret})
+
+	claims := jwt.MapClaims{"merchant_id": "merchant-1", "user_id": "user-1", "user_role": "finance"}
+	signed := signTestToken(t, jwt.SigningMethodHS256, claims, secret)
+
+	// the payload is swapped for another merchant while the signature of the original payload is kept
+	parts := strings.Split(signed, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"merchant_id":"merchant-2","user_id":"user-1"}`))
+	forged := strings.Join(parts, ".")
+
+	tests := []struct {
+		name           string
+		authorization  string
+		merchantID     string
+		userID         string
+		internalUserID string
+	}{
+		{
+			name:          "signed token",
+			authorization: "Bearer " + signed,
+			merchantID:    "merchant-1",
+			userID:        "user-1",
+		},
+		{
+			// an internal user token never gets the merchant identity of its claims
+			name:           "internal user token",
+			authorization:  "Bearer " + signTestToken(t, jwt.SigningMethodHS256, claims, internalUserSecret),
+			internalUserID: "user-1",
+		},
+		{
+			name:          "forged payload",
+			authorization: "Bearer " + forged,
+		},
+		{
+			name:          "other secret",
+			authorization: "Bearer " + signTestToken(t, jwt.SigningMethodHS256, claims, []byte("other-secret")),
+		},
+		{
+			name:          "unsigned token",
+			authorization: "Bearer " + signTestToken(t, jwt.SigningMethodNone, claims, jwt.UnsafeAllowNoneSignatureType),
+		},
+		{
+			name: "expired token",
+			authorization: "Bearer " + signTestToken(t, jwt.SigningMethodHS256, jwt.MapClaims{
+				"merchant_id": "merchant-1",
+				"exp":         time.Now().Add(-time.Minute).Unix(),
+			}, secret),
+		},
+		{
+			name:          "not a bearer token",
+			authorization: "Basic " + signed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx context.Context
+
+			handler := verifier.httpMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				ctx = r.Context()
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/disbursements", nil)
+			r.Header.Set("Authorization", tt.authorization)
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			assertIdentity(t, ctx, tt.merchantID, tt.userID)
+
+			if got := utils.GetFromContext[string](ctx, constants.InternalUserIDKey); got != tt.internalUserID {
+				t.Errorf("internal user id = %q, want %q", got, tt.internalUserID)
+			}
+		})
+	}
+}
+
+func TestAuthTokenVerifierUnaryServerInterceptor(t *testing.T) {
+	secret := []byte("merchant-secret")
+	verifier := newAuthTokenVerifier([][]byte{secret}, nil)
+
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		merchantID string
+	}{
+		{
+			name: "forwarded token",
+			md: metadata.Pairs(
+				authTokenMetadataKey,
+				signTestToken(t, jwt.SigningMethodHS256, jwt.MapClaims{"merchant_id": "merchant-1"}, secret),
+			),
+			merchantID: "merchant-1",
+		},
+		{
+			// an authenticated service can no longer name the merchant it acts for without its token
+			name: "plain merchant metadata",
+			md:   metadata.Pairs("merchant_id", "merchant-1", "user_id", "user-1", "user_role", "finance"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx context.Context
+
+			_, err := verifier.unaryServerInterceptor(
+				metadata.NewIncomingContext(context.Background(), tt.md),
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(handlerCtx context.Context, _ any) (any, error) {
+					ctx = handlerCtx
+					return nil, nil
+				},
+			)
+			if err != nil {
+				t.Fatalf("interceptor error = %v", err)
+			}
+
+			assertIdentity(t, ctx, tt.merchantID, "")
+		})
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, claims jwt.MapClaims, key any) string {
+	t.Helper()
+
+	signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return signed
+}
+
+func assertIdentity(t *testing.T, ctx context.Context, merchantID string, userID string) {
+	t.Helper()
+
+	if got := utils.GetFromContext[string](ctx, constants.MerchantIDKey); got != merchantID {
+		t.Errorf("merchant id = %q, want %q", got, merchantID)
+	}
+
+	if got := utils.GetFromContext[string](ctx, constants.UserIDKey); got != userID {
+		t.Errorf("user id = %q, want %q", got, userID)
+	}
+}
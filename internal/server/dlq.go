@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	commoncfg "github.com/durianpay/dpay-common/config"
+	"github.com/durianpay/dpay-common/logger"
+	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
+)
+
+// ReplayDisbursementDLQ moves the disbursement dead-letter messages back to the disbursement topic
+func ReplayDisbursementDLQ(limit int, idleTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// own consumer group so the replay progress doesn't depend on any other dlq consumer
+	dlqReader, err := initKafkaReaderWithGroup(
+		disbursementCfg.GetDisbursementDLQKafkaTopic(),
+		commoncfg.KafkaClientID()+"-dlq-replay",
+	)
+	if err != nil {
+		logger.Errorw(ctx, "error initializing dead-letter kafka reader", "error", err.Error())
+		return err
+	}
+
+	defer dlqReader.Close()
+
+	mainWriter := initKafkaWriter(disbursementCfg.GetDisbursementKafkaTopic())
+	defer mainWriter.Close()
+
+	replayed, err := commonkafka.ReplayDLQ(ctx, dlqReader, mainWriter, limit, idleTimeout)
+	logger.Infow(ctx, "finished replaying dead-letter messages", "replayed", replayed)
+
+	if err != nil {
+		return err
+	}
+
+	return appObjCleanup()
+}
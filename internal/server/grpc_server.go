@@ -38,6 +38,10 @@ func startGRPCServer(server *grpc.Server) (err error) {
 
 func buildGRPCServer(apps *app.Application) *grpc.Server {
 	globalCfg := config.ProvideGlobalConfig()
+	verifier := newAuthTokenVerifier(
+		config.ProvideDisbursementConfig().GetMerchantTokenSecrets(),
+		config.ProvideDisbursementConfig().GetInternalUserTokenSecrets(),
+	)
 
 	// If MaxConnAge is set to 0, the server will have infinite conn age
 	kasp := keepalive.ServerParameters{
@@ -65,6 +69,7 @@ func buildGRPCServer(apps *app.Application) *grpc.Server {
 				),
 				otelgrpc.UnaryServerInterceptor(),
 				client.AuthServerInterceptor,
+				verifier.unaryServerInterceptor,
 			)...,
 		),
 		grpc.ChainStreamInterceptor(
@@ -75,6 +80,8 @@ func buildGRPCServer(apps *app.Application) *grpc.Server {
 					zapLogOpts,
 				),
 				otelgrpc.StreamServerInterceptor(),
+				interceptors.StreamServerInterceptorFromUnary(client.AuthServerInterceptor),
+				interceptors.StreamServerInterceptorFromUnary(verifier.unaryServerInterceptor),
 			)...,
 		),
 	)
@@ -13,11 +13,14 @@ import (
 	"github.com/durianpay/dpay-common/middleware"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/layarda-durianpay/go-skeleton/internal/config"
 	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app"
 	disbursehttphandler "github.com/layarda-durianpay/go-skeleton/internal/disburse/handler/http"
 	"github.com/samber/lo"
 )
 
+const idempotencyKeyHeader = "Idempotency-Key"
+
 func startHTTPServer(server *http.Server) error {
 	logger.Infof(context.TODO(), "starting API server on %s", server.Addr)
 
@@ -35,9 +38,9 @@ func buildHTTPServer(apps *app.Application) *http.Server {
 
 	muxRouter := initRouter(apps)
 
-	headersOk := handlers.AllowedHeaders([]string{constants.ContentType, constants.Authorization, constants.VerificationToken, constants.UserAgent})
+	headersOk := handlers.AllowedHeaders([]string{constants.ContentType, constants.Authorization, constants.VerificationToken, constants.UserAgent, idempotencyKeyHeader})
 	originsOk := handlers.AllowedOrigins([]string{"*"})
-	methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "OPTIONS"})
+	methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})
 
 	corsHandler := handlers.CORS(headersOk, originsOk, methodsOk)(muxRouter)
 	logHandler := middleware.RequestDefaultHandler(corsHandler)
@@ -59,14 +62,21 @@ func initRouter(apps *app.Application) *mux.Router {
 		nil,
 		[]string{
 			"/health",
-			"/disbursements/disburse",
+			// callbacks are authenticated by the provider signature instead of the merchant credential
+			"/webhooks/payouts/{provider}",
 		},
 		middleware.MerchantSnapAPIAuthenticator(apps.Dependencies.MerchantGRPCClient.Client),
 	)
 
 	router.EnableTracing("disbursement-service-http")
 
+	verifier := newAuthTokenVerifier(
+		config.ProvideDisbursementConfig().GetMerchantTokenSecrets(),
+		config.ProvideDisbursementConfig().GetInternalUserTokenSecrets(),
+	)
+
 	for _, route := range getRoutes(apps) {
+		route.HTTPHandler = verifier.httpMiddleware(route.HTTPHandler)
 		router.HandleRoute(route)
 	}
 
@@ -75,7 +85,7 @@ func initRouter(apps *app.Application) *mux.Router {
 
 func getRoutes(apps *app.Application) []dprouter.Route {
 	disburseServer := disbursehttphandler.ServerInterfaceWrapper{
-		Handler: disbursehttphandler.NewHTTPServer(apps),
+		Handler: disbursehttphandler.NewHTTPServer(apps, config.ProvideDisbursementConfig()),
 		ErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, err error) {
 			if _, ok := lo.ErrorsAs[*disbursehttphandler.InvalidParamFormatError](err); ok {
 				w.WriteHeader(http.StatusUnprocessableEntity)
@@ -101,5 +111,185 @@ func getRoutes(apps *app.Application) []dprouter.Route {
 			HTTPHandler: http.HandlerFunc(disburseServer.Disburse),
 			Version:     "v1",
 		},
+		{
+			Path:        "/disbursements",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.ListDisbursements),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/batches",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.CreateDisbursementBatch),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/batches/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetDisbursementBatch),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/uploads",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.UploadDisbursements),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/uploads/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetDisbursementUpload),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/schedules",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.ScheduleDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/schedules/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetScheduledDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/schedules/{id}/cancel",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.CancelScheduledDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/{id}/cancel",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.CancelDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/{id}/reverse",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.ReverseDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/{id}/approve",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.ApproveDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/{id}/reject",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.RejectDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/{id}/approvals",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.ListDisbursementApprovals),
+			Version:     "v1",
+		},
+		{
+			Path:        "/disbursements/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetDisbursement),
+			Version:     "v1",
+		},
+		{
+			Path:        "/beneficiaries",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.ListBeneficiaries),
+			Version:     "v1",
+		},
+		{
+			Path:        "/beneficiaries",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.CreateBeneficiary),
+			Version:     "v1",
+		},
+		{
+			Path:        "/beneficiaries/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetBeneficiary),
+			Version:     "v1",
+		},
+		{
+			Path:        "/beneficiaries/{id}",
+			Method:      http.MethodPut,
+			HTTPHandler: http.HandlerFunc(disburseServer.UpdateBeneficiary),
+			Version:     "v1",
+		},
+		{
+			Path:        "/beneficiaries/{id}",
+			Method:      http.MethodDelete,
+			HTTPHandler: http.HandlerFunc(disburseServer.DeleteBeneficiary),
+			Version:     "v1",
+		},
+		{
+			Path:        "/fees/preview",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.PreviewFee),
+			Version:     "v1",
+		},
+		{
+			Path:        "/fx/quotes",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.CreateFXQuote),
+			Version:     "v1",
+		},
+		{
+			Path:        "/fx/quotes/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetFXQuote),
+			Version:     "v1",
+		},
+		{
+			Path:        "/reconciliations/{id}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetReconciliation),
+			Version:     "v1",
+		},
+		{
+			Path:        "/admin/merchants/{merchant_id}/limits/{currency}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetMerchantLimits),
+			Version:     "v1",
+		},
+		{
+			Path:        "/admin/merchants/{merchant_id}/limits/{currency}",
+			Method:      http.MethodPut,
+			HTTPHandler: http.HandlerFunc(disburseServer.SetMerchantLimits),
+			Version:     "v1",
+		},
+		{
+			Path:        "/admin/merchants/{merchant_id}/approval-policies/{currency}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetApprovalPolicy),
+			Version:     "v1",
+		},
+		{
+			Path:        "/admin/merchants/{merchant_id}/approval-policies/{currency}",
+			Method:      http.MethodPut,
+			HTTPHandler: http.HandlerFunc(disburseServer.SetApprovalPolicy),
+			Version:     "v1",
+		},
+		{
+			Path:        "/admin/merchants/{merchant_id}/fee-schedules/{currency}",
+			Method:      http.MethodGet,
+			HTTPHandler: http.HandlerFunc(disburseServer.GetFeeSchedule),
+			Version:     "v1",
+		},
+		{
+			Path:        "/admin/merchants/{merchant_id}/fee-schedules/{currency}",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.CreateFeeSchedule),
+			Version:     "v1",
+		},
+		{
+			Path:        "/webhooks/payouts/{provider}",
+			Method:      http.MethodPost,
+			HTTPHandler: http.HandlerFunc(disburseServer.ReceivePayoutCallback),
+			Version:     "v1",
+		},
 	}
 }
@@ -13,13 +13,15 @@ import (
 	"github.com/durianpay/dpay-common/logger"
 	kafkahandler "github.com/layarda-durianpay/go-skeleton/internal/disburse/handler/kafka"
 	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/opentelemetry"
 	"github.com/samber/lo"
 	"github.com/segmentio/kafka-go"
 	// "github.com/segmentio/kafka-go"
 )
 
 type readers struct {
-	DisbursementReaders *kafka.Reader
+	DisbursementReaders   *kafka.Reader
+	DisbursementDLQWriter *commonkafka.Publisher
 }
 
 func NewReader(ctx context.Context) (readers, error) {
@@ -30,12 +32,23 @@ func NewReader(ctx context.Context) (readers, error) {
 	}
 
 	return readers{
-		DisbursementReaders: disbursementKafkaReader,
+		DisbursementReaders:   disbursementKafkaReader,
+		DisbursementDLQWriter: initKafkaWriter(disbursementCfg.GetDisbursementDLQKafkaTopic()),
 	}, nil
 }
 
 func StartReaders() error {
 	ctx, cancel := context.WithCancel(context.Background())
+
+	// without it the propagator is a noop and the consumed messages never join the producer trace
+	otelCleanup, err := opentelemetry.InitOTelTrace(ctx, disbursementCfg.GetEnableConfigOpenTelemetry())
+	defer otelCleanup()
+
+	if err != nil {
+		cancel()
+		return err
+	}
+
 	readers, err := NewReader(ctx)
 	if err != nil {
 		cancel()
@@ -51,6 +64,7 @@ func StartReaders() error {
 	runDisbursementReader(
 		ctx,
 		readers.DisbursementReaders,
+		readers.DisbursementDLQWriter,
 		handler,
 		&wg,
 	)
@@ -64,35 +78,47 @@ func StartReaders() error {
 	cancel()
 	wg.Wait()
 
+	if err := readers.DisbursementDLQWriter.Close(); err != nil {
+		logger.Errorw(context.Background(), "error closing dead-letter kafka writer", "error", err.Error())
+	}
+
 	return appObjCleanup()
 }
 
 func runDisbursementReader(
 	ctx context.Context,
 	disbursementKafkaReader *kafka.Reader,
+	dlqWriter *commonkafka.Publisher,
 	handler *kafkahandler.DisbursementKafkaReader,
 	wg *sync.WaitGroup,
 ) error {
 
+	router := commonkafka.NewRouter()
+	handler.Register(router)
+
 	wg.Add(1)
 
 	go func() {
 		defer wg.Done()
 
-		// TODO: improve InitConsumer with fork Handler so it can handle multiple type at once
 		consumer := dckafka.InitConsumer(
 			dckafka.ConsumerEntity("disbursement"),
-			dckafka.InitWorker(3),
+			// a single worker keeps the commits in offset order, a later offset committed by another worker
+			// would skip the message still failing before it
+			dckafka.InitWorker(1),
 			commonkafka.Read(
 				disbursementKafkaReader,
-				commonkafka.WithBeforeFunc(func(ctx context.Context) context.Context {
-					return context.WithValue(ctx, "iseng-aja", "iseng-aja")
-				}),
 				commonkafka.WithAfterFunc(func(ctx context.Context, msg kafka.Message, err error) {
 					logger.Debugw(ctx, "after func log")
 				}),
 			),
-			handler.DisburseProcessor,
+			commonkafka.Commit(
+				commonkafka.Trace(
+					commonkafka.Retry(router.Route, dlqWriter),
+					disbursementKafkaReader.Config().GroupID,
+				),
+				disbursementKafkaReader,
+			),
 			disbursementKafkaReader.Close,
 		)
 
@@ -103,9 +129,13 @@ func runDisbursementReader(
 }
 
 func initKafkaReader(topic string) (reader *kafka.Reader, err error) {
+	return initKafkaReaderWithGroup(topic, commoncfg.KafkaClientID())
+}
+
+func initKafkaReaderWithGroup(topic, groupID string) (reader *kafka.Reader, err error) {
 	readerCfg := kafka.ReaderConfig{
 		Brokers:         commoncfg.KafkaBrokerURLs(),
-		GroupID:         commoncfg.KafkaClientID(),
+		GroupID:         groupID,
 		Topic:           topic,
 		MinBytes:        10e3, // 10KB
 		MaxBytes:        10e6, // 10MB
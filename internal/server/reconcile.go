@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/query"
+)
+
+// ReconcileSettlement reconciles the settlement report of the provider at filePath against the disbursements
+// completed within the UTC day of date, then prints the discrepancies it found
+func ReconcileSettlement(provider string, filePath string, date time.Time) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := os.Open(filePath)
+	if err != nil {
+		logger.Errorw(ctx, "error opening settlement report", "file", filePath, "error", err.Error())
+		return err
+	}
+
+	defer report.Close()
+
+	var (
+		runID       = uuid.New()
+		periodStart = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	)
+
+	err = appObj.Commands.ReconcileSettlement.Handle(ctx, &command.ReconcileSettlementParam{
+		ID:          runID,
+		Provider:    provider,
+		FileName:    filepath.Base(filePath),
+		Report:      report,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		logger.Errorw(ctx, "error reconciling settlement report", "file", filePath, "error", err.Error())
+		return err
+	}
+
+	result, err := appObj.Queries.GetReconciliationRun.Handle(ctx, &query.GetReconciliationRunParam{
+		ID: runID,
+	})
+	if err != nil {
+		logger.Errorw(ctx, "error getting reconciliation run", "reconciliation_id", runID.String(), "error", err.Error())
+		return err
+	}
+
+	logger.Infow(
+		ctx,
+		"finished reconciling settlement report",
+		"reconciliation_id", runID.String(),
+		"lines", result.Run.LineCount(),
+		"matched", result.Run.MatchedCount(),
+		"discrepancies", result.Run.DiscrepancyCount(),
+	)
+
+	for _, discrepancy := range result.Discrepancies {
+		fmt.Printf(
+			"%s\tline %d\t%s\t%s\n",
+			discrepancy.Type(), discrepancy.LineNumber(), discrepancy.Reference(), discrepancy.Detail(),
+		)
+	}
+
+	return appObjCleanup()
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	commoncfg "github.com/durianpay/dpay-common/config"
+	"github.com/durianpay/dpay-common/logger"
+	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/outbox"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+	"github.com/segmentio/kafka-go"
+)
+
+func StartOutboxRelay() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	writer := initKafkaWriter(disbursementCfg.GetDisbursementEventKafkaTopic())
+
+	relay := outbox.NewRelay(
+		outbox.NewPostgresStore(appObj.Dependencies.DB),
+		sqlwrap.ProvideManager(appObj.Dependencies.DB),
+		writer,
+	)
+
+	serveHelperServer(ctx)
+
+	err := relay.Run(ctx)
+	logger.Infof(context.Background(), "shutting down outbox relay")
+
+	if errClose := writer.Close(); errClose != nil {
+		logger.Errorw(context.Background(), "error closing kafka writer", "error", errClose.Error())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return appObjCleanup()
+}
+
+func initKafkaWriter(topic string) *commonkafka.Publisher {
+	return commonkafka.Write(&kafka.Writer{
+		Addr:  kafka.TCP(commoncfg.KafkaBrokerURLs()...),
+		Topic: topic,
+		// same key goes to the same partition, so the events of a disbursement keep their order
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	})
+}
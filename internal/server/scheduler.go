@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/app/command"
+	"github.com/layarda-durianpay/go-skeleton/internal/disburse/domain/disburse"
+)
+
+// StartScheduler runs the due scheduled disbursements, disburses the rows of the uploads, rejects the disbursements
+// past their approval deadline and sends the pending disbursements and the payouts left unsent to the payout provider every pollInterval
+// until the process is stopped.
+// All of them are claimed with SKIP LOCKED, so any number of schedulers can run side by side without a leader.
+func StartScheduler(pollInterval time.Duration, batchSize int, claimTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveHelperServer(ctx)
+
+	for {
+		err := appObj.Commands.RunDueSchedules.Handle(ctx, &command.RunDueSchedulesParam{
+			Limit:        batchSize,
+			ClaimTimeout: claimTimeout,
+		})
+		if err != nil {
+			logger.Errorw(ctx, "error running due scheduled disbursements", "error", err.Error())
+		}
+
+		err = appObj.Commands.ProcessUploads.Handle(ctx, &command.ProcessUploadsParam{
+			Limit:        batchSize,
+			ClaimTimeout: claimTimeout,
+		})
+		if err != nil {
+			logger.Errorw(ctx, "error processing disbursement uploads", "error", err.Error())
+		}
+
+		err = appObj.Commands.ExpireApprovals.Handle(ctx, &command.ExpireApprovalsParam{
+			Limit: batchSize,
+		})
+		if err != nil {
+			logger.Errorw(ctx, "error expiring disbursement approvals", "error", err.Error())
+		}
+
+		err = appObj.Commands.DispatchPayouts.Handle(ctx, &command.DispatchPayoutsParam{
+			Limit:        batchSize,
+			ClaimTimeout: claimTimeout,
+		})
+		if stderrors.Is(err, disburse.ErrPayoutProviderNotConfigured) {
+			// nothing could ever be paid out, stop rather than leave every disbursement in PENDING unnoticed
+			return stderrors.Join(err, appObjCleanup())
+		}
+
+		if err != nil {
+			logger.Errorw(ctx, "error dispatching disbursement payouts", "error", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Infof(context.Background(), "shutting down scheduler")
+			return appObjCleanup()
+		case <-time.After(pollInterval):
+		}
+	}
+}
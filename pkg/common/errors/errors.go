@@ -18,6 +18,7 @@ var (
 	ErrorTypeUnprocessableEntity = ErrorType("unprocessable-entity")
 	ErrorTypeNotFound            = ErrorType("not-found")
 	ErrorTypeForbidden           = ErrorType("forbidden")
+	ErrorTypeConflict            = ErrorType("conflict")
 	ErrorTypeContextCancelled    = ErrorType("context-cancelled")
 )
 
@@ -27,6 +28,20 @@ const (
 	DpayInternalError  ErrorCode = ErrorCode("DPAY_INTERNAL_ERROR")
 	DpayInvalidRequest ErrorCode = ErrorCode("DPAY_INVALID_REQUEST")
 	DpayCancelled      ErrorCode = ErrorCode("DPAY_CANCELLED")
+	DpayNotFound       ErrorCode = ErrorCode("DPAY_NOT_FOUND")
+	DpayUnauthorized   ErrorCode = ErrorCode("DPAY_UNAUTHORIZED")
+
+	DpayInvalidStatusTransition ErrorCode = ErrorCode("DPAY_INVALID_STATUS_TRANSITION")
+	DpayIdempotencyKeyReused    ErrorCode = ErrorCode("DPAY_IDEMPOTENCY_KEY_REUSED")
+	DpayMessageBrokerError      ErrorCode = ErrorCode("DPAY_MESSAGE_BROKER_ERROR")
+	DpayInsufficientBalance     ErrorCode = ErrorCode("DPAY_INSUFFICIENT_BALANCE")
+	DpayMerchantServiceError    ErrorCode = ErrorCode("DPAY_MERCHANT_SERVICE_ERROR")
+	DpayPayoutRejected          ErrorCode = ErrorCode("DPAY_PAYOUT_REJECTED")
+	DpayActionNotAllowed        ErrorCode = ErrorCode("DPAY_ACTION_NOT_ALLOWED")
+	DpayLimitExceeded           ErrorCode = ErrorCode("DPAY_LIMIT_EXCEEDED")
+	DpayBankAccountNotFound     ErrorCode = ErrorCode("DPAY_BANK_ACCOUNT_NOT_FOUND")
+	DpayHolderNameMismatch      ErrorCode = ErrorCode("DPAY_HOLDER_NAME_MISMATCH")
+	DpayConcurrentUpdate        ErrorCode = ErrorCode("DPAY_CONCURRENT_UPDATE")
 )
 
 // mapClientErrorType mapping the 4xx error as true
@@ -36,6 +51,7 @@ var mapClientErrorType = map[ErrorType]bool{
 	ErrorTypeUnprocessableEntity: true,
 	ErrorTypeNotFound:            true,
 	ErrorTypeForbidden:           true,
+	ErrorTypeConflict:            true,
 	ErrorTypeUnknown:             false,
 	ErrorTypeDatabase:            false,
 	ErrorTypeContextCancelled:    true,
@@ -92,6 +108,7 @@ func WrapDpayErrTrace(err error) error {
 		dpayErr.message,
 		dpayErr.errorCode,
 		dpayErr.errorType,
+		dpayErr.errorInfos...,
 	)
 }
 
@@ -146,6 +163,17 @@ func IsClientError(err error) bool {
 	return isClientErr
 }
 
+// IsConflictError checks if an error is a conflict error, the request lost a race against a concurrent one
+// so unlike the other client errors it can be retried as is
+func IsConflictError(err error) bool {
+	dpayErr, ok := lo.ErrorsAs[DpayError](err)
+	if !ok {
+		return false
+	}
+
+	return dpayErr.ErrorType() == ErrorTypeConflict
+}
+
 // unwrapError recursively unwraps errors that implement the Unwrap method.
 // If the error does not implement the Unwrap method, it is returned as is.
 func unwrapError(err error) error {
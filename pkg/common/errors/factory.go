@@ -11,16 +11,18 @@ func NewCustomDpayError(
 	message string,
 	errCode ErrorCode,
 	errType ErrorType,
+	errInfos ...api.ErrorInfo,
 ) DpayError {
 	if _, ok := lo.ErrorsAs[tracerr.Error](err); !ok {
 		err = tracerr.Wrap(err)
 	}
 
 	return DpayError{
-		err:       err,
-		message:   message,
-		errorCode: errCode,
-		errorType: errType,
+		err:        err,
+		message:    message,
+		errorCode:  errCode,
+		errorType:  errType,
+		errorInfos: errInfos,
 	}
 }
 
@@ -54,16 +56,18 @@ func NewIncorrectInputError(
 	err error,
 	message string,
 	errCode ErrorCode,
+	errInfos ...api.ErrorInfo,
 ) DpayError {
 	if _, ok := lo.ErrorsAs[tracerr.Error](err); !ok {
 		err = tracerr.Wrap(err)
 	}
 
 	return DpayError{
-		err:       err,
-		message:   message,
-		errorCode: errCode,
-		errorType: ErrorTypeIncorrectInput,
+		err:        err,
+		message:    message,
+		errorCode:  errCode,
+		errorType:  ErrorTypeIncorrectInput,
+		errorInfos: errInfos,
 	}
 }
 
@@ -125,6 +129,19 @@ func NewForbiddenError(err error, message string, errCode ErrorCode) DpayError {
 	}
 }
 
+func NewConflictError(err error, message string, errCode ErrorCode) DpayError {
+	if _, ok := lo.ErrorsAs[tracerr.Error](err); !ok {
+		err = tracerr.Wrap(err)
+	}
+
+	return DpayError{
+		err:       err,
+		message:   message,
+		errorCode: errCode,
+		errorType: ErrorTypeConflict,
+	}
+}
+
 func NewContextCancelledError(err error, message string, errCode ErrorCode) DpayError {
 	if _, ok := lo.ErrorsAs[tracerr.Error](err); !ok {
 		err = tracerr.Wrap(err)
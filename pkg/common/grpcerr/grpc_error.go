@@ -2,6 +2,7 @@ package grpcerr
 
 import (
 	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -12,6 +13,7 @@ var errorCodeMap = map[errors.ErrorType]codes.Code{
 	errors.ErrorTypeUnprocessableEntity: codes.InvalidArgument,
 	errors.ErrorTypeNotFound:            codes.NotFound,
 	errors.ErrorTypeForbidden:           codes.PermissionDenied,
+	errors.ErrorTypeConflict:            codes.Aborted,
 	errors.ErrorTypeContextCancelled:    codes.Canceled,
 	errors.ErrorTypeUnknown:             codes.Internal,
 }
@@ -21,6 +23,7 @@ var codeErrorMap = map[codes.Code]errors.ErrorType{
 	codes.InvalidArgument:   errors.ErrorTypeIncorrectInput,
 	codes.NotFound:          errors.ErrorTypeNotFound,
 	codes.ResourceExhausted: errors.ErrorTypeForbidden,
+	codes.Aborted:           errors.ErrorTypeConflict,
 	codes.Canceled:          errors.ErrorTypeContextCancelled,
 	codes.Internal:          errors.ErrorTypeUnknown,
 }
@@ -41,7 +44,28 @@ func TransformToGRPCErr(err error) error {
 		)
 	}
 
-	return status.Error(code, err.Error())
+	errInfos := dpayErr.ErrorInfos()
+	if len(errInfos) == 0 {
+		return status.Error(code, err.Error())
+	}
+
+	// the failed fields are sent as details so the client can point out each of them
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(errInfos))
+	for _, errInfo := range errInfos {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       errInfo.Field,
+			Description: errInfo.Message,
+		})
+	}
+
+	st, detailErr := status.New(code, err.Error()).WithDetails(&errdetails.BadRequest{
+		FieldViolations: violations,
+	})
+	if detailErr != nil {
+		return status.Error(code, err.Error())
+	}
+
+	return st.Err()
 }
 
 func GetErrorType(err error) errors.ErrorType {
@@ -17,6 +17,7 @@ var errorMap = map[errors.ErrorType]int{
 	errors.ErrorTypeUnprocessableEntity: http.StatusUnprocessableEntity,
 	errors.ErrorTypeNotFound:            http.StatusNotFound,
 	errors.ErrorTypeForbidden:           http.StatusForbidden,
+	errors.ErrorTypeConflict:            http.StatusConflict,
 	errors.ErrorTypeContextCancelled:    499, // client close connection
 
 }
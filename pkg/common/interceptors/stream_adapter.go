@@ -0,0 +1,29 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/utils"
+)
+
+// StreamServerInterceptorFromUnary runs the unary interceptor once when the stream is opened,
+// the stream continues with the context the unary interceptor passes to its handler.
+// It is meant for interceptors that only look at the incoming metadata, e.g. authentication.
+func StreamServerInterceptorFromUnary(unary grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(
+		func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			unaryInfo := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: info.FullMethod,
+			}
+
+			_, err := unary(stream.Context(), nil, unaryInfo, func(ctx context.Context, _ any) (any, error) {
+				return nil, handler(srv, utils.NewServerStreamWrapper(stream, ctx))
+			})
+
+			return err
+		},
+	)
+}
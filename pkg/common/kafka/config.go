@@ -1,5 +1,19 @@
 package kafka
 
+import (
+	"context"
+	"time"
+
+	"github.com/durianpay/dpay-common/dckafka"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
 type configReader struct {
 	beforeFunc BeforeFunc
 	afterFunc  AfterFunc
@@ -16,7 +30,10 @@ func (o optionFunc) apply(c *configReader) {
 }
 
 func newConfig(opts ...Option) *configReader {
-	conf := &configReader{}
+	conf := &configReader{
+		beforeFunc: func(ctx context.Context) context.Context { return ctx },
+		afterFunc:  func(context.Context, kafka.Message, error) {},
+	}
 
 	for _, opt := range opts {
 		opt.apply(conf)
@@ -36,3 +53,132 @@ func WithBeforeFunc(bf BeforeFunc) Option {
 		cr.beforeFunc = bf
 	})
 }
+
+type configRetry struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+type RetryOption interface {
+	apply(c *configRetry)
+}
+
+type retryOptionFunc func(*configRetry)
+
+func (o retryOptionFunc) apply(c *configRetry) {
+	o(c)
+}
+
+func newRetryConfig(opts ...RetryOption) *configRetry {
+	conf := &configRetry{
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+	}
+
+	for _, opt := range opts {
+		opt.apply(conf)
+	}
+
+	return conf
+}
+
+// WithMaxAttempts sets how many times the handler is called for a transient error, including the first call
+func WithMaxAttempts(attempts int) RetryOption {
+	return retryOptionFunc(func(cr *configRetry) {
+		if attempts > 0 {
+			cr.maxAttempts = attempts
+		}
+	})
+}
+
+// WithBackoff sets the wait before the first retry, doubled on every next retry up to max
+func WithBackoff(initial, maxBackoff time.Duration) RetryOption {
+	return retryOptionFunc(func(cr *configRetry) {
+		if initial > 0 {
+			cr.initialBackoff = initial
+		}
+
+		if maxBackoff > 0 {
+			cr.maxBackoff = maxBackoff
+		}
+
+		cr.maxBackoff = max(cr.maxBackoff, cr.initialBackoff)
+	})
+}
+
+type configRouter struct {
+	fallback dckafka.Handler
+}
+
+type RouterOption interface {
+	apply(c *configRouter)
+}
+
+type routerOptionFunc func(*configRouter)
+
+func (o routerOptionFunc) apply(c *configRouter) {
+	o(c)
+}
+
+func newRouterConfig(opts ...RouterOption) *configRouter {
+	conf := &configRouter{
+		fallback: rejectUnknownType,
+	}
+
+	for _, opt := range opts {
+		opt.apply(conf)
+	}
+
+	return conf
+}
+
+// WithFallback sets the handler for the messages without registered type and subtype
+func WithFallback(fallback dckafka.Handler) RouterOption {
+	return routerOptionFunc(func(cr *configRouter) {
+		if fallback != nil {
+			cr.fallback = fallback
+		}
+	})
+}
+
+type configWriter struct {
+	beforeFunc WriterBeforeFunc
+	afterFunc  WriterAfterFunc
+}
+
+type WriterOption interface {
+	apply(c *configWriter)
+}
+
+type writerOptionFunc func(*configWriter)
+
+func (o writerOptionFunc) apply(c *configWriter) {
+	o(c)
+}
+
+func newWriterConfig(opts ...WriterOption) *configWriter {
+	conf := &configWriter{
+		beforeFunc: func(ctx context.Context, _ []kafka.Message) context.Context { return ctx },
+		afterFunc:  func(context.Context, []kafka.Message, error) {},
+	}
+
+	for _, opt := range opts {
+		opt.apply(conf)
+	}
+
+	return conf
+}
+
+func WithWriterBeforeFunc(bf WriterBeforeFunc) WriterOption {
+	return writerOptionFunc(func(cw *configWriter) {
+		cw.beforeFunc = bf
+	})
+}
+
+func WithWriterAfterFunc(af WriterAfterFunc) WriterOption {
+	return writerOptionFunc(func(cw *configWriter) {
+		cw.afterFunc = af
+	})
+}
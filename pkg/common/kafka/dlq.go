@@ -0,0 +1,124 @@
+package kafka
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+// headers added to the dead-letter message, the original headers are kept as is
+const (
+	dlqHeaderPrefix = "dlq-"
+
+	DLQHeaderOriginalTopic     = dlqHeaderPrefix + "original-topic"
+	DLQHeaderOriginalPartition = dlqHeaderPrefix + "original-partition"
+	DLQHeaderOriginalOffset    = dlqHeaderPrefix + "original-offset"
+	DLQHeaderErrorCode         = dlqHeaderPrefix + "error-code"
+	DLQHeaderErrorMessage      = dlqHeaderPrefix + "error-message"
+	DLQHeaderAttempts          = dlqHeaderPrefix + "attempts"
+	DLQHeaderFailedAt          = dlqHeaderPrefix + "failed-at"
+)
+
+// newDeadLetterMessage keeps the original key, payload and headers, and describes the failure in the dlq- headers
+func newDeadLetterMessage(msg kafka.Message, err error, attempts int) kafka.Message {
+	errorCode := errors.DpayInternalError
+	if dpayErr, ok := errors.GetDPayError(err); ok {
+		errorCode = dpayErr.ErrorCode()
+	}
+
+	headers := make([]kafka.Header, 0, len(msg.Headers)+7)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: DLQHeaderOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: DLQHeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: DLQHeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: DLQHeaderErrorCode, Value: []byte(errorCode)},
+		kafka.Header{Key: DLQHeaderErrorMessage, Value: []byte(err.Error())},
+		kafka.Header{Key: DLQHeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: DLQHeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	)
+
+	return kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+// ReplayDLQ moves the dead-letter messages back to the main topic writer, without the dlq- headers.
+// It stops when the ctx is done, limit messages were replayed (0 means no limit),
+// or no message arrives within idleTimeout. Every message is committed only after it's written.
+func ReplayDLQ(
+	ctx context.Context,
+	dlqReader *kafka.Reader,
+	mainWriter Writer,
+	limit int,
+	idleTimeout time.Duration,
+) (int, error) {
+	replayed := 0
+
+	for limit == 0 || replayed < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, idleTimeout)
+		msg, err := dlqReader.FetchMessage(fetchCtx)
+		cancel()
+
+		if stderrors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			logger.Infow(ctx, "no more dead-letter message to replay", "replayed", replayed)
+			return replayed, nil
+		}
+
+		if err != nil {
+			return replayed, errors.NewDpayError(
+				err,
+				"failed to fetch dead-letter message",
+				errors.DpayInternalError,
+			)
+		}
+
+		err = mainWriter.WriteMessages(ctx, kafka.Message{
+			Key:     msg.Key,
+			Value:   msg.Value,
+			Headers: withoutDLQHeaders(msg.Headers),
+		})
+		if err != nil {
+			return replayed, errors.NewDpayError(
+				err,
+				"failed to replay dead-letter message",
+				errors.DpayInternalError,
+			)
+		}
+
+		err = dlqReader.CommitMessages(ctx, msg)
+		if err != nil {
+			return replayed, errors.NewDpayError(
+				err,
+				"failed to commit replayed dead-letter message",
+				errors.DpayInternalError,
+			)
+		}
+
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func withoutDLQHeaders(headers []kafka.Header) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers))
+
+	for _, header := range headers {
+		if strings.HasPrefix(header.Key, dlqHeaderPrefix) {
+			continue
+		}
+
+		result = append(result, header)
+	}
+
+	return result
+}
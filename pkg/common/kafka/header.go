@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// HeaderRequestID carries the request id of the producer to the consumer
+	HeaderRequestID = "request_id"
+
+	// HeaderContentType is the encoding of the message, application/json when absent
+	HeaderContentType = "content-type"
+
+	// HeaderSchemaVersion is the schema version of the message Data, schema.DefaultVersion when absent
+	HeaderSchemaVersion = "schema-version"
+)
+
+// check HeaderCarrier implements propagation.TextMapCarrier
+var _ propagation.TextMapCarrier = &HeaderCarrier{}
+
+// HeaderCarrier adapts the kafka message headers to inject and extract the W3C trace context
+type HeaderCarrier struct {
+	headers *[]kafka.Header
+}
+
+func NewHeaderCarrier(headers *[]kafka.Header) *HeaderCarrier {
+	return &HeaderCarrier{
+		headers: headers,
+	}
+}
+
+func (c *HeaderCarrier) Get(key string) string {
+	for _, header := range *c.headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set replaces the header with the same key, so a republished message doesn't carry two trace contexts
+func (c *HeaderCarrier) Set(key, value string) {
+	for i, header := range *c.headers {
+		if header.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c *HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, header := range *c.headers {
+		keys = append(keys, header.Key)
+	}
+
+	return keys
+}
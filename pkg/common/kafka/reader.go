@@ -13,6 +13,13 @@ type (
 	AfterFunc  func(ctx context.Context, msg kafka.Message, err error)
 )
 
+// Committer is satisfied by *kafka.Reader
+type Committer interface {
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Read fetches the messages without committing them, the offset is committed by Commit
+// once the message is handled so a crash in between consumes the message again
 func Read(
 	reader *kafka.Reader,
 	opts ...Option,
@@ -21,9 +28,11 @@ func Read(
 
 	return func(ctx context.Context) (msg kafka.Message, err error) {
 		ctx = cr.beforeFunc(ctx)
-		defer cr.afterFunc(ctx, msg, err)
+		defer func() {
+			cr.afterFunc(ctx, msg, err)
+		}()
 
-		msg, err = reader.ReadMessage(ctx)
+		msg, err = reader.FetchMessage(ctx)
 		if err != nil {
 			logger.Errorw(
 				ctx,
@@ -37,3 +46,32 @@ func Read(
 		return
 	}
 }
+
+// Commit wraps the handler to commit the offset of the message only when the handler succeeds,
+// with Retry it means the message was handled or written to the dead-letter topic
+func Commit(
+	handler dckafka.Handler,
+	committer Committer,
+) dckafka.Handler {
+	return func(ctx context.Context, msg kafka.Message) error {
+		err := handler(ctx, msg)
+		if err != nil {
+			return err
+		}
+
+		err = committer.CommitMessages(ctx, msg)
+		if err != nil {
+			logger.Errorw(
+				ctx,
+				"error committing kafka message",
+				"error", err.Error(),
+				"topic", msg.Topic,
+				"partition", msg.Partition,
+				"offset", msg.Offset,
+			)
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+package kafka
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/durianpay/dpay-common/dckafka"
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+// Writer is satisfied by *kafka.Writer
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Retry wraps the handler with the retry policy:
+//   - client errors (see errors.IsClientError) are permanent, the message goes straight to the dead-letter topic,
+//     except conflict errors which lost a race against a concurrent update and are retried like the transient ones
+//   - other errors are transient, the handler is retried with exponential backoff
+//     and the message goes to the dead-letter topic once the attempts are exhausted
+//
+// The returned handler only fails when the dead-letter topic can't be written or the ctx is done,
+// so a poison message never blocks the partition. Wrap it with Commit so a failure leaves the offset uncommitted.
+func Retry(
+	handler dckafka.Handler,
+	dlqWriter Writer,
+	opts ...RetryOption,
+) dckafka.Handler {
+	cr := newRetryConfig(opts...)
+
+	return func(ctx context.Context, msg kafka.Message) error {
+		var (
+			err     error
+			attempt int
+		)
+
+		for attempt = 1; attempt <= cr.maxAttempts; attempt++ {
+			err = handler(ctx, msg)
+			if err == nil {
+				return nil
+			}
+
+			// shutting down, leave the message uncommitted so it's consumed again
+			if ctx.Err() != nil {
+				return err
+			}
+
+			if (errors.IsClientError(err) && !errors.IsConflictError(err)) || attempt == cr.maxAttempts {
+				break
+			}
+
+			backoff := cr.backoff(attempt)
+			logger.Warnw(
+				ctx, "retrying kafka message",
+				"error", err.Error(),
+				"attempt", attempt,
+				"backoff", backoff.String(),
+			)
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+		}
+
+		logger.Errorw(
+			ctx, "sending kafka message to dead-letter topic",
+			"error", err.Error(),
+			"attempt", attempt,
+			"topic", msg.Topic,
+			"partition", msg.Partition,
+			"offset", msg.Offset,
+		)
+
+		dlqErr := dlqWriter.WriteMessages(ctx, newDeadLetterMessage(msg, err, attempt))
+		if dlqErr != nil {
+			return errors.NewDpayError(
+				dlqErr,
+				"failed to write message to dead-letter topic",
+				errors.DpayInternalError,
+			)
+		}
+
+		return nil
+	}
+}
+
+// backoff returns the wait before the next attempt, doubling from the initial backoff up to the max backoff
+func (c configRetry) backoff(attempt int) time.Duration {
+	backoff := float64(c.initialBackoff) * math.Pow(2, float64(attempt-1))
+	if backoff > float64(c.maxBackoff) {
+		return c.maxBackoff
+	}
+
+	return time.Duration(backoff)
+}
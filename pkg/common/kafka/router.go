@@ -0,0 +1,179 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"mime"
+
+	"github.com/durianpay/dpay-common/dckafka"
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrUnknownMessageType = stderrors.New("unknown kafka message type")
+
+// TypedHandler handles a message whose Data is already decoded into T
+type TypedHandler[T any] func(ctx context.Context, msg ResponseMessage[T]) error
+
+// PayloadDecoder decodes the Data of a message by its content type and schema version, see schema.Payload
+type PayloadDecoder[T any] interface {
+	Decode(contentType, version string, data []byte) (T, error)
+}
+
+type routeKey struct {
+	msgType string
+	subType string
+}
+
+// envelope is the decoded ResponseMessage with the Data still encoded
+type envelope struct {
+	ID          string
+	Type        string
+	SubType     string
+	Data        []byte
+	ContentType string
+	Version     string
+}
+
+// routeFunc decodes the Data of the envelope and calls the typed handler
+type routeFunc func(ctx context.Context, env envelope) error
+
+// Router decodes the ResponseMessage envelope once and dispatches it to the handler registered for its
+// Type and SubType. Register the handlers with Handle before the router starts consuming.
+//
+// The envelope and the Data are JSON, or protobuf (protogen.KafkaEnvelope) when the content-type header
+// is application/x-protobuf. The schema-version header picks the schema version of the Data.
+type Router struct {
+	routes   map[routeKey]routeFunc
+	fallback dckafka.Handler
+}
+
+func NewRouter(opts ...RouterOption) *Router {
+	cr := newRouterConfig(opts...)
+
+	return &Router{
+		routes:   make(map[routeKey]routeFunc),
+		fallback: cr.fallback,
+	}
+}
+
+// Handle registers the handler for the message type and subtype, the Data is decoded with the payload decoder.
+// An empty subType matches every subtype of the message type that has no handler of its own.
+func Handle[T any](r *Router, msgType, subType string, payload PayloadDecoder[T], handler TypedHandler[T]) {
+	key := routeKey{msgType: msgType, subType: subType}
+	if _, ok := r.routes[key]; ok {
+		panic(fmt.Sprintf("kafka router: handler for type %q subtype %q is already registered", msgType, subType))
+	}
+
+	r.routes[key] = func(ctx context.Context, env envelope) error {
+		data, err := payload.Decode(env.ContentType, env.Version, env.Data)
+		if err != nil {
+			logger.Warnw(
+				ctx, "invalid kafka message payload",
+				"error", err.Error(),
+				"type", env.Type,
+				"subtype", env.SubType,
+			)
+
+			return err
+		}
+
+		return handler(ctx, ResponseMessage[T]{
+			ID:      env.ID,
+			Type:    env.Type,
+			SubType: env.SubType,
+			Data:    data,
+		})
+	}
+}
+
+// Route is the dckafka.Handler of the router
+func (r *Router) Route(ctx context.Context, msg kafka.Message) error {
+	env, err := decodeEnvelope(msg)
+	if err != nil {
+		logger.Errorw(
+			ctx, "error unmarshalling kafka message",
+			"error", err.Error(),
+			"request", string(msg.Value),
+			"headers", msg.Headers,
+		)
+
+		return errors.NewIncorrectInputError(
+			err,
+			"error unmarshalling kafka message",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	route, ok := r.routes[routeKey{msgType: env.Type, subType: env.SubType}]
+	if !ok {
+		route, ok = r.routes[routeKey{msgType: env.Type}]
+	}
+
+	if !ok {
+		return r.fallback(ctx, msg)
+	}
+
+	return route(ctx, env)
+}
+
+func decodeEnvelope(msg kafka.Message) (envelope, error) {
+	carrier := NewHeaderCarrier(&msg.Headers)
+
+	env := envelope{
+		ContentType: schema.ContentTypeJSON,
+		Version:     carrier.Get(HeaderSchemaVersion),
+	}
+
+	if contentType := carrier.Get(HeaderContentType); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return env, err
+		}
+
+		env.ContentType = mediaType
+	}
+
+	switch env.ContentType {
+	case schema.ContentTypeJSON:
+		var body ResponseMessage[json.RawMessage]
+		if err := json.Unmarshal(msg.Value, &body); err != nil {
+			return env, err
+		}
+
+		env.ID, env.Type, env.SubType, env.Data = body.ID, body.Type, body.SubType, body.Data
+	case schema.ContentTypeProtobuf:
+		var body protogen.KafkaEnvelope
+		if err := proto.Unmarshal(msg.Value, &body); err != nil {
+			return env, err
+		}
+
+		env.ID, env.Type, env.SubType, env.Data = body.GetId(), body.GetType(), body.GetSubtype(), body.GetData()
+	default:
+		return env, fmt.Errorf("%w: %q", schema.ErrUnsupportedContentType, env.ContentType)
+	}
+
+	return env, nil
+}
+
+// rejectUnknownType is the default fallback, the message is treated as incorrect input
+// so the retry policy sends it to the dead-letter topic without retrying
+func rejectUnknownType(ctx context.Context, msg kafka.Message) error {
+	logger.Warnw(
+		ctx, "no handler for kafka message",
+		"request", string(msg.Value),
+		"headers", msg.Headers,
+	)
+
+	return errors.NewIncorrectInputError(
+		ErrUnknownMessageType,
+		ErrUnknownMessageType.Error(),
+		errors.DpayInvalidRequest,
+	)
+}
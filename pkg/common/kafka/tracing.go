@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/durianpay/dpay-common/constants"
+	"github.com/durianpay/dpay-common/dckafka"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/opentelemetry"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Trace wraps the handler so every message continues the trace of its producer.
+// The trace context and baggage are extracted from the headers with the configured otel propagator,
+// the request id header is put into the ctx (a new one is generated when absent),
+// and the handler runs inside a consumer span.
+func Trace(handler dckafka.Handler, groupID string) dckafka.Handler {
+	return func(ctx context.Context, msg kafka.Message) error {
+		carrier := NewHeaderCarrier(&msg.Headers)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+
+		requestID := carrier.Get(HeaderRequestID)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx = context.WithValue(ctx, constants.RequestIDKey, requestID)
+
+		ctx, span := opentelemetry.StartSpan(
+			ctx,
+			"kafka.process "+msg.Topic,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystemKafka,
+				semconv.MessagingOperationTypeDeliver,
+				semconv.MessagingDestinationName(msg.Topic),
+				semconv.MessagingDestinationPartitionID(strconv.Itoa(msg.Partition)),
+				semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+				semconv.MessagingKafkaConsumerGroup(groupID),
+				semconv.MessagingKafkaMessageKey(string(msg.Key)),
+				attribute.String(opentelemetry.RequestIDKey, requestID),
+			),
+		)
+		defer span.End()
+
+		err := handler(ctx, msg)
+		if err != nil {
+			span.RecordError(err, trace.WithStackTrace(true))
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,220 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"time"
+
+	"github.com/durianpay/dpay-common/constants"
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/opentelemetry"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/utils"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	WriterBeforeFunc func(ctx context.Context, msgs []kafka.Message) context.Context
+	WriterAfterFunc  func(ctx context.Context, msgs []kafka.Message, err error)
+)
+
+// check Publisher implements Writer
+var _ Writer = &Publisher{}
+
+// Publisher wraps kafka.Writer, every written message carries the trace context and request id of the ctx
+type Publisher struct {
+	writer *kafka.Writer
+	conf   *configWriter
+
+	publishedCounter metric.Int64Counter
+	publishDuration  metric.Float64Histogram
+}
+
+func Write(
+	writer *kafka.Writer,
+	opts ...WriterOption,
+) *Publisher {
+	meter := otel.Meter(opentelemetry.Name)
+
+	// the instruments only fail on invalid name, a noop instrument is returned in that case
+	publishedCounter, _ := meter.Int64Counter(
+		"kafka.producer.messages",
+		metric.WithDescription("number of messages written to kafka"),
+	)
+	publishDuration, _ := meter.Float64Histogram(
+		"kafka.producer.duration",
+		metric.WithDescription("duration of writing a batch of messages to kafka"),
+		metric.WithUnit("ms"),
+	)
+
+	return &Publisher{
+		writer:           writer,
+		conf:             newWriterConfig(opts...),
+		publishedCounter: publishedCounter,
+		publishDuration:  publishDuration,
+	}
+}
+
+// Publish wraps data into ResponseMessage with a new id and writes it with the key
+func Publish[T any](
+	ctx context.Context,
+	p *Publisher,
+	key string,
+	msgType string,
+	subType string,
+	data T,
+) error {
+	value, err := json.Marshal(ResponseMessage[T]{
+		ID:      uuid.NewString(),
+		Type:    msgType,
+		SubType: subType,
+		Data:    data,
+	})
+	if err != nil {
+		return errors.NewIncorrectInputError(
+			err,
+			"error marshalling kafka message",
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	return p.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(key),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: HeaderContentType, Value: []byte(schema.ContentTypeJSON)},
+		},
+	})
+}
+
+func (p *Publisher) WriteMessages(ctx context.Context, msgs ...kafka.Message) (err error) {
+	now := time.Now()
+	topic := p.writer.Topic
+
+	ctx, span := opentelemetry.StartSpan(
+		ctx,
+		"kafka.publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+	)
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.MessagingSystemKafka,
+		semconv.MessagingOperationTypePublish,
+		semconv.MessagingDestinationName(topic),
+		semconv.MessagingBatchMessageCount(len(msgs)),
+		attribute.String(opentelemetry.RequestIDKey, utils.GetFromContext[string](ctx, constants.RequestIDKey)),
+	)
+
+	ctx = p.conf.beforeFunc(ctx, msgs)
+	defer func() {
+		p.conf.afterFunc(ctx, msgs, err)
+	}()
+
+	requestID := utils.GetFromContext[string](ctx, constants.RequestIDKey)
+	for i := range msgs {
+		carrier := NewHeaderCarrier(&msgs[i].Headers)
+		otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+		if requestID != "" {
+			carrier.Set(HeaderRequestID, requestID)
+		}
+	}
+
+	err = p.writer.WriteMessages(ctx, msgs...)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+		err = mapWriteError(err)
+
+		span.RecordError(err, trace.WithStackTrace(true))
+		span.SetStatus(codes.Error, err.Error())
+
+		logger.Errorw(
+			ctx,
+			"error writing to kafka",
+			"error", err.Error(),
+			"topic", topic,
+		)
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("topic", topic),
+		attribute.String("status", status),
+	)
+	p.publishedCounter.Add(ctx, int64(len(msgs)), attrs)
+	p.publishDuration.Record(ctx, float64(time.Since(now).Microseconds())/1000, attrs)
+
+	return err
+}
+
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
+
+// mapWriteError maps the broker error into DpayError, so the caller can tell a permanent failure
+// (client error) from a transient one
+func mapWriteError(err error) error {
+	var writeErrs kafka.WriteErrors
+	if stderrors.As(err, &writeErrs) {
+		for _, writeErr := range writeErrs {
+			if writeErr != nil {
+				err = writeErr
+				break
+			}
+		}
+	}
+
+	if stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+		return errors.NewContextCancelledError(
+			err,
+			"writing to kafka is cancelled",
+			errors.DpayCancelled,
+		)
+	}
+
+	var kafkaErr kafka.Error
+	if !stderrors.As(err, &kafkaErr) {
+		return errors.NewDpayError(
+			err,
+			"failed to write to kafka",
+			errors.DpayMessageBrokerError,
+		)
+	}
+
+	switch kafkaErr {
+	case kafka.TopicAuthorizationFailed,
+		kafka.ClusterAuthorizationFailed,
+		kafka.SASLAuthenticationFailed:
+		return errors.NewAuthorizationError(
+			err,
+			"not authorized to write to kafka",
+			errors.DpayMessageBrokerError,
+		)
+	case kafka.MessageSizeTooLarge,
+		kafka.InvalidMessage,
+		kafka.InvalidMessageSize,
+		kafka.InvalidTopic:
+		return errors.NewIncorrectInputError(
+			err,
+			"kafka rejected the message",
+			errors.DpayMessageBrokerError,
+		)
+	default:
+		return errors.NewDpayError(
+			err,
+			"failed to write to kafka",
+			errors.DpayMessageBrokerError,
+		)
+	}
+}
@@ -0,0 +1,54 @@
+package outbox
+
+import "time"
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+)
+
+type configRelay struct {
+	batchSize    int
+	pollInterval time.Duration
+}
+
+type Option interface {
+	apply(c *configRelay)
+}
+
+type optionFunc func(*configRelay)
+
+func (o optionFunc) apply(c *configRelay) {
+	o(c)
+}
+
+func newConfig(opts ...Option) *configRelay {
+	conf := &configRelay{
+		batchSize:    defaultBatchSize,
+		pollInterval: defaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt.apply(conf)
+	}
+
+	return conf
+}
+
+// WithBatchSize sets the max number of messages published in one transaction
+func WithBatchSize(size int) Option {
+	return optionFunc(func(cr *configRelay) {
+		if size > 0 {
+			cr.batchSize = size
+		}
+	})
+}
+
+// WithPollInterval sets how long the relay waits when the outbox is empty
+func WithPollInterval(interval time.Duration) Option {
+	return optionFunc(func(cr *configRelay) {
+		if interval > 0 {
+			cr.pollInterval = interval
+		}
+	})
+}
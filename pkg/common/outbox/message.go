@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
+)
+
+// Message is an event waiting in the outbox to be published to kafka
+type Message struct {
+	ID uuid.UUID
+
+	// Key is the kafka message key, events of the same aggregate should share the key to keep their order
+	Key     string
+	Type    string
+	SubType string
+
+	// Payload is the kafka message value, a ResponseMessage encoded as JSON
+	Payload []byte
+
+	CreatedAt time.Time
+}
+
+// NewMessage wraps data into a ResponseMessage, the message id is also the ResponseMessage id
+// so consumers can deduplicate the redelivered event
+func NewMessage[T any](key, eventType, subType string, data T) (Message, error) {
+	id := uuid.New()
+
+	payload, err := json.Marshal(commonkafka.ResponseMessage[T]{
+		ID:      id.String(),
+		Type:    eventType,
+		SubType: subType,
+		Data:    data,
+	})
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		ID:        id,
+		Key:       key,
+		Type:      eventType,
+		SubType:   subType,
+		Payload:   payload,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/durianpay/dpay-common/logger"
+	"github.com/google/uuid"
+	commonkafka "github.com/layarda-durianpay/go-skeleton/pkg/common/kafka"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/schema"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+	"github.com/samber/lo"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	headerType    = "type"
+	headerSubType = "subtype"
+)
+
+// Publisher is satisfied by *kafka.Writer
+type Publisher interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Relay publishes the outbox messages to kafka with at-least-once delivery.
+// A message is marked as published only after kafka acknowledged it, so a crash in between
+// publishes the message again and consumers must be idempotent on the message id
+type Relay struct {
+	store     Store
+	manager   sqlwrap.ManagerInterface
+	publisher Publisher
+	conf      *configRelay
+}
+
+func NewRelay(
+	store Store,
+	manager sqlwrap.ManagerInterface,
+	publisher Publisher,
+	opts ...Option,
+) *Relay {
+	return &Relay{
+		store:     store,
+		manager:   manager,
+		publisher: publisher,
+		conf:      newConfig(opts...),
+	}
+}
+
+// Run relays the messages until the ctx is done
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		published, err := r.relayBatch(ctx)
+		if err != nil {
+			logger.Errorw(ctx, "error relaying outbox messages", "error", err.Error())
+		}
+
+		// a full batch means there may be more messages waiting, don't sleep
+		if err == nil && published == r.conf.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.conf.pollInterval):
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) (published int, err error) {
+	err = r.manager.RunInTransaction(ctx, func(ctx context.Context) error {
+		msgs, err := r.store.FetchUnpublished(ctx, r.conf.batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(msgs) == 0 {
+			return nil
+		}
+
+		err = r.publisher.WriteMessages(ctx, lo.Map(msgs, func(msg Message, _ int) kafka.Message {
+			return toKafkaMessage(msg)
+		})...)
+		if err != nil {
+			return err
+		}
+
+		err = r.store.MarkPublished(ctx, lo.Map(msgs, func(msg Message, _ int) uuid.UUID {
+			return msg.ID
+		}))
+		if err != nil {
+			return err
+		}
+
+		published = len(msgs)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if published > 0 {
+		logger.Infow(ctx, "successfully relayed outbox messages", "count", published)
+	}
+
+	return published, nil
+}
+
+func toKafkaMessage(msg Message) kafka.Message {
+	return kafka.Message{
+		Key:   []byte(msg.Key),
+		Value: msg.Payload,
+		Headers: []kafka.Header{
+			{Key: commonkafka.HeaderContentType, Value: []byte(schema.ContentTypeJSON)},
+			{Key: headerType, Value: []byte(msg.Type)},
+			{Key: headerSubType, Value: []byte(msg.SubType)},
+		},
+		Time: msg.CreatedAt,
+	}
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/sqlwrap"
+)
+
+// Store keeps the outbox messages. Every method joins the transaction in the context,
+// so Add must be called inside the same RunInTransaction as the aggregate change
+type Store interface {
+	Add(ctx context.Context, msgs ...Message) error
+
+	// FetchUnpublished locks the oldest unpublished messages until the transaction ends,
+	// other relays skip the locked messages
+	FetchUnpublished(ctx context.Context, limit int) ([]Message, error)
+	MarkPublished(ctx context.Context, ids []uuid.UUID) error
+}
+
+var addMessageQuery = `INSERT INTO outbox_events (
+	id, message_key, type, subtype, payload, created_at
+) VALUES (
+	:id, :message_key, :type, :subtype, :payload, :created_at
+)`
+
+var fetchUnpublishedQuery = `SELECT id, message_key, type, subtype, payload, created_at
+FROM outbox_events
+WHERE published_at IS NULL
+ORDER BY created_at
+LIMIT $1
+FOR UPDATE SKIP LOCKED`
+
+var markPublishedQuery = `UPDATE outbox_events SET
+	published_at = ?
+WHERE id IN (?)`
+
+type messageModel struct {
+	ID        uuid.UUID `db:"id"`
+	Key       string    `db:"message_key"`
+	Type      string    `db:"type"`
+	SubType   string    `db:"subtype"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type postgresStore struct {
+	db sqlwrap.Database
+}
+
+func NewPostgresStore(db sqlwrap.Database) Store {
+	return &postgresStore{
+		db: db,
+	}
+}
+
+func (p *postgresStore) Add(ctx context.Context, msgs ...Message) error {
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	for _, msg := range msgs {
+		qry, args, err := executor.BindNamed(addMessageQuery, messageModel(msg))
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to bind named for insert outbox query",
+				errors.DpayInternalError,
+			)
+		}
+
+		_, err = executor.ExecContext(ctx, qry, args...)
+		if err != nil {
+			return errors.NewDatabaseError(
+				err,
+				"failed to insert outbox message",
+				errors.DpayInternalError,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (p *postgresStore) FetchUnpublished(ctx context.Context, limit int) ([]Message, error) {
+	var models []messageModel
+
+	err := sqlx.SelectContext(ctx, sqlwrap.ExecutorFromContext(ctx, p.db), &models, fetchUnpublishedQuery, limit)
+	if err != nil {
+		return nil, errors.NewDatabaseError(
+			err,
+			"failed to fetch unpublished outbox messages",
+			errors.DpayInternalError,
+		)
+	}
+
+	msgs := make([]Message, 0, len(models))
+	for _, model := range models {
+		msgs = append(msgs, Message(model))
+	}
+
+	return msgs, nil
+}
+
+func (p *postgresStore) MarkPublished(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	executor := sqlwrap.ExecutorFromContext(ctx, p.db)
+
+	qry, args, err := sqlx.In(markPublishedQuery, time.Now().UTC(), ids)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to build mark published query",
+			errors.DpayInternalError,
+		)
+	}
+
+	_, err = executor.ExecContext(ctx, executor.Rebind(qry), args...)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			"failed to mark outbox messages as published",
+			errors.DpayInternalError,
+		)
+	}
+
+	return nil
+}
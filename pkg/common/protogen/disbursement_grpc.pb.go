@@ -20,14 +20,86 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	DisbursementService_Disburse_FullMethodName = "/DisbursementService/Disburse"
+	DisbursementService_Disburse_FullMethodName                  = "/DisbursementService/Disburse"
+	DisbursementService_GetDisbursement_FullMethodName           = "/DisbursementService/GetDisbursement"
+	DisbursementService_ListDisbursements_FullMethodName         = "/DisbursementService/ListDisbursements"
+	DisbursementService_CancelDisbursement_FullMethodName        = "/DisbursementService/CancelDisbursement"
+	DisbursementService_ReverseDisbursement_FullMethodName       = "/DisbursementService/ReverseDisbursement"
+	DisbursementService_ApproveDisbursement_FullMethodName       = "/DisbursementService/ApproveDisbursement"
+	DisbursementService_RejectDisbursement_FullMethodName        = "/DisbursementService/RejectDisbursement"
+	DisbursementService_ListDisbursementApprovals_FullMethodName = "/DisbursementService/ListDisbursementApprovals"
+	DisbursementService_DisburseBatch_FullMethodName             = "/DisbursementService/DisburseBatch"
+	DisbursementService_StreamDisburseBatch_FullMethodName       = "/DisbursementService/StreamDisburseBatch"
+	DisbursementService_GetDisbursementBatch_FullMethodName      = "/DisbursementService/GetDisbursementBatch"
+	DisbursementService_PreviewFee_FullMethodName                = "/DisbursementService/PreviewFee"
+	DisbursementService_CreateFXQuote_FullMethodName             = "/DisbursementService/CreateFXQuote"
+	DisbursementService_GetFXQuote_FullMethodName                = "/DisbursementService/GetFXQuote"
+	DisbursementService_CreateBeneficiary_FullMethodName         = "/DisbursementService/CreateBeneficiary"
+	DisbursementService_GetBeneficiary_FullMethodName            = "/DisbursementService/GetBeneficiary"
+	DisbursementService_ListBeneficiaries_FullMethodName         = "/DisbursementService/ListBeneficiaries"
+	DisbursementService_UpdateBeneficiary_FullMethodName         = "/DisbursementService/UpdateBeneficiary"
+	DisbursementService_DeleteBeneficiary_FullMethodName         = "/DisbursementService/DeleteBeneficiary"
+	DisbursementService_GetMerchantLimits_FullMethodName         = "/DisbursementService/GetMerchantLimits"
+	DisbursementService_SetMerchantLimits_FullMethodName         = "/DisbursementService/SetMerchantLimits"
+	DisbursementService_GetApprovalPolicy_FullMethodName         = "/DisbursementService/GetApprovalPolicy"
+	DisbursementService_SetApprovalPolicy_FullMethodName         = "/DisbursementService/SetApprovalPolicy"
+	DisbursementService_GetFeeSchedule_FullMethodName            = "/DisbursementService/GetFeeSchedule"
+	DisbursementService_CreateFeeSchedule_FullMethodName         = "/DisbursementService/CreateFeeSchedule"
 )
 
 // DisbursementServiceClient is the client API for DisbursementService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DisbursementServiceClient interface {
-	Disburse(ctx context.Context, in *DisburseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Disburse(ctx context.Context, in *DisburseRequest, opts ...grpc.CallOption) (*DisburseResponse, error)
+	GetDisbursement(ctx context.Context, in *GetDisbursementRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	ListDisbursements(ctx context.Context, in *ListDisbursementsRequest, opts ...grpc.CallOption) (*ListDisbursementsResponse, error)
+	// CancelDisbursement cancels a PENDING or AWAITING_APPROVAL disbursement, it returns the cancelled disbursement
+	CancelDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	// ReverseDisbursement reverses a SUCCESS disbursement and refunds it to the merchant, it returns the reversed disbursement
+	ReverseDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	// ApproveDisbursement approves an AWAITING_APPROVAL disbursement as the authenticated user,
+	// it is sent for payout once it has all the approvals it needs
+	ApproveDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	// RejectDisbursement rejects an AWAITING_APPROVAL disbursement as the authenticated user and releases its balance
+	RejectDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error)
+	// ListDisbursementApprovals returns the approval chain of the disbursement from its first step
+	ListDisbursementApprovals(ctx context.Context, in *GetDisbursementRequest, opts ...grpc.CallOption) (*ListDisbursementApprovalsResponse, error)
+	DisburseBatch(ctx context.Context, in *DisburseBatchRequest, opts ...grpc.CallOption) (*DisburseBatchResponse, error)
+	// StreamDisburseBatch receives the items of a large batch one by one,
+	// the batch is created once the client closes the stream. The idempotency-key metadata is the batch key.
+	StreamDisburseBatch(ctx context.Context, opts ...grpc.CallOption) (DisbursementService_StreamDisburseBatchClient, error)
+	GetDisbursementBatch(ctx context.Context, in *GetDisbursementBatchRequest, opts ...grpc.CallOption) (*DisbursementBatch, error)
+	// PreviewFee calculates the fee of a disbursement of the amount without disbursing
+	PreviewFee(ctx context.Context, in *PreviewFeeRequest, opts ...grpc.CallOption) (*FeePreview, error)
+	// CreateFXQuote locks the rate from the funding currency into the disbursement currency for a few minutes
+	CreateFXQuote(ctx context.Context, in *CreateFXQuoteRequest, opts ...grpc.CallOption) (*FXQuote, error)
+	GetFXQuote(ctx context.Context, in *GetFXQuoteRequest, opts ...grpc.CallOption) (*FXQuote, error)
+	// CreateBeneficiary registers a bank account to disburse to, the holder name is inquired at the bank
+	CreateBeneficiary(ctx context.Context, in *BeneficiaryRequest, opts ...grpc.CallOption) (*Beneficiary, error)
+	GetBeneficiary(ctx context.Context, in *GetBeneficiaryRequest, opts ...grpc.CallOption) (*Beneficiary, error)
+	ListBeneficiaries(ctx context.Context, in *ListBeneficiariesRequest, opts ...grpc.CallOption) (*ListBeneficiariesResponse, error)
+	// UpdateBeneficiary replaces the bank account of the beneficiary, the holder name of the new account is inquired again
+	UpdateBeneficiary(ctx context.Context, in *BeneficiaryRequest, opts ...grpc.CallOption) (*Beneficiary, error)
+	// DeleteBeneficiary removes the beneficiary, the disbursements already paid to it keep referencing it
+	DeleteBeneficiary(ctx context.Context, in *DeleteBeneficiaryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetMerchantLimits returns the limits the disbursements of the merchant in the currency are checked against,
+	// the default limits of the currency when the merchant has none of its own. Internal users only.
+	GetMerchantLimits(ctx context.Context, in *GetMerchantLimitsRequest, opts ...grpc.CallOption) (*MerchantLimits, error)
+	// SetMerchantLimits replaces the limits of the merchant in the currency, it returns the limits. Internal users only.
+	SetMerchantLimits(ctx context.Context, in *SetMerchantLimitsRequest, opts ...grpc.CallOption) (*MerchantLimits, error)
+	// GetApprovalPolicy returns the maker-checker policy of the merchant in the currency,
+	// NOT_FOUND when its disbursements in the currency need no approval. Internal users only.
+	GetApprovalPolicy(ctx context.Context, in *GetApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error)
+	// SetApprovalPolicy replaces the maker-checker policy of the merchant in the currency, it returns the policy.
+	// Internal users only.
+	SetApprovalPolicy(ctx context.Context, in *SetApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error)
+	// GetFeeSchedule returns the fee schedule of the merchant in the currency effective now,
+	// NOT_FOUND when its disbursements in the currency are charged nothing. Internal users only.
+	GetFeeSchedule(ctx context.Context, in *GetFeeScheduleRequest, opts ...grpc.CallOption) (*FeeSchedule, error)
+	// CreateFeeSchedule creates the next version of the fee schedule of the merchant in the currency,
+	// the older versions are kept. Internal users only.
+	CreateFeeSchedule(ctx context.Context, in *CreateFeeScheduleRequest, opts ...grpc.CallOption) (*CreateFeeScheduleResponse, error)
 }
 
 type disbursementServiceClient struct {
@@ -38,8 +110,8 @@ func NewDisbursementServiceClient(cc grpc.ClientConnInterface) DisbursementServi
 	return &disbursementServiceClient{cc}
 }
 
-func (c *disbursementServiceClient) Disburse(ctx context.Context, in *DisburseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *disbursementServiceClient) Disburse(ctx context.Context, in *DisburseRequest, opts ...grpc.CallOption) (*DisburseResponse, error) {
+	out := new(DisburseResponse)
 	err := c.cc.Invoke(ctx, DisbursementService_Disburse_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -47,20 +119,381 @@ func (c *disbursementServiceClient) Disburse(ctx context.Context, in *DisburseRe
 	return out, nil
 }
 
+func (c *disbursementServiceClient) GetDisbursement(ctx context.Context, in *GetDisbursementRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_GetDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) ListDisbursements(ctx context.Context, in *ListDisbursementsRequest, opts ...grpc.CallOption) (*ListDisbursementsResponse, error) {
+	out := new(ListDisbursementsResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_ListDisbursements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) CancelDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_CancelDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) ReverseDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_ReverseDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) ApproveDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_ApproveDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) RejectDisbursement(ctx context.Context, in *DisbursementActionRequest, opts ...grpc.CallOption) (*Disbursement, error) {
+	out := new(Disbursement)
+	err := c.cc.Invoke(ctx, DisbursementService_RejectDisbursement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) ListDisbursementApprovals(ctx context.Context, in *GetDisbursementRequest, opts ...grpc.CallOption) (*ListDisbursementApprovalsResponse, error) {
+	out := new(ListDisbursementApprovalsResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_ListDisbursementApprovals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) DisburseBatch(ctx context.Context, in *DisburseBatchRequest, opts ...grpc.CallOption) (*DisburseBatchResponse, error) {
+	out := new(DisburseBatchResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_DisburseBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) StreamDisburseBatch(ctx context.Context, opts ...grpc.CallOption) (DisbursementService_StreamDisburseBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DisbursementService_ServiceDesc.Streams[0], DisbursementService_StreamDisburseBatch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disbursementServiceStreamDisburseBatchClient{stream}
+	return x, nil
+}
+
+type DisbursementService_StreamDisburseBatchClient interface {
+	Send(*DisburseBatchItem) error
+	CloseAndRecv() (*DisburseBatchResponse, error)
+	grpc.ClientStream
+}
+
+type disbursementServiceStreamDisburseBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *disbursementServiceStreamDisburseBatchClient) Send(m *DisburseBatchItem) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disbursementServiceStreamDisburseBatchClient) CloseAndRecv() (*DisburseBatchResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(DisburseBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disbursementServiceClient) GetDisbursementBatch(ctx context.Context, in *GetDisbursementBatchRequest, opts ...grpc.CallOption) (*DisbursementBatch, error) {
+	out := new(DisbursementBatch)
+	err := c.cc.Invoke(ctx, DisbursementService_GetDisbursementBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) PreviewFee(ctx context.Context, in *PreviewFeeRequest, opts ...grpc.CallOption) (*FeePreview, error) {
+	out := new(FeePreview)
+	err := c.cc.Invoke(ctx, DisbursementService_PreviewFee_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) CreateFXQuote(ctx context.Context, in *CreateFXQuoteRequest, opts ...grpc.CallOption) (*FXQuote, error) {
+	out := new(FXQuote)
+	err := c.cc.Invoke(ctx, DisbursementService_CreateFXQuote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) GetFXQuote(ctx context.Context, in *GetFXQuoteRequest, opts ...grpc.CallOption) (*FXQuote, error) {
+	out := new(FXQuote)
+	err := c.cc.Invoke(ctx, DisbursementService_GetFXQuote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) CreateBeneficiary(ctx context.Context, in *BeneficiaryRequest, opts ...grpc.CallOption) (*Beneficiary, error) {
+	out := new(Beneficiary)
+	err := c.cc.Invoke(ctx, DisbursementService_CreateBeneficiary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) GetBeneficiary(ctx context.Context, in *GetBeneficiaryRequest, opts ...grpc.CallOption) (*Beneficiary, error) {
+	out := new(Beneficiary)
+	err := c.cc.Invoke(ctx, DisbursementService_GetBeneficiary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) ListBeneficiaries(ctx context.Context, in *ListBeneficiariesRequest, opts ...grpc.CallOption) (*ListBeneficiariesResponse, error) {
+	out := new(ListBeneficiariesResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_ListBeneficiaries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) UpdateBeneficiary(ctx context.Context, in *BeneficiaryRequest, opts ...grpc.CallOption) (*Beneficiary, error) {
+	out := new(Beneficiary)
+	err := c.cc.Invoke(ctx, DisbursementService_UpdateBeneficiary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) DeleteBeneficiary(ctx context.Context, in *DeleteBeneficiaryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DisbursementService_DeleteBeneficiary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) GetMerchantLimits(ctx context.Context, in *GetMerchantLimitsRequest, opts ...grpc.CallOption) (*MerchantLimits, error) {
+	out := new(MerchantLimits)
+	err := c.cc.Invoke(ctx, DisbursementService_GetMerchantLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) SetMerchantLimits(ctx context.Context, in *SetMerchantLimitsRequest, opts ...grpc.CallOption) (*MerchantLimits, error) {
+	out := new(MerchantLimits)
+	err := c.cc.Invoke(ctx, DisbursementService_SetMerchantLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) GetApprovalPolicy(ctx context.Context, in *GetApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error) {
+	out := new(ApprovalPolicy)
+	err := c.cc.Invoke(ctx, DisbursementService_GetApprovalPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) SetApprovalPolicy(ctx context.Context, in *SetApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error) {
+	out := new(ApprovalPolicy)
+	err := c.cc.Invoke(ctx, DisbursementService_SetApprovalPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) GetFeeSchedule(ctx context.Context, in *GetFeeScheduleRequest, opts ...grpc.CallOption) (*FeeSchedule, error) {
+	out := new(FeeSchedule)
+	err := c.cc.Invoke(ctx, DisbursementService_GetFeeSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disbursementServiceClient) CreateFeeSchedule(ctx context.Context, in *CreateFeeScheduleRequest, opts ...grpc.CallOption) (*CreateFeeScheduleResponse, error) {
+	out := new(CreateFeeScheduleResponse)
+	err := c.cc.Invoke(ctx, DisbursementService_CreateFeeSchedule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisbursementServiceServer is the server API for DisbursementService service.
 // All implementations should embed UnimplementedDisbursementServiceServer
 // for forward compatibility
 type DisbursementServiceServer interface {
-	Disburse(context.Context, *DisburseRequest) (*emptypb.Empty, error)
+	Disburse(context.Context, *DisburseRequest) (*DisburseResponse, error)
+	GetDisbursement(context.Context, *GetDisbursementRequest) (*Disbursement, error)
+	ListDisbursements(context.Context, *ListDisbursementsRequest) (*ListDisbursementsResponse, error)
+	// CancelDisbursement cancels a PENDING or AWAITING_APPROVAL disbursement, it returns the cancelled disbursement
+	CancelDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error)
+	// ReverseDisbursement reverses a SUCCESS disbursement and refunds it to the merchant, it returns the reversed disbursement
+	ReverseDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error)
+	// ApproveDisbursement approves an AWAITING_APPROVAL disbursement as the authenticated user,
+	// it is sent for payout once it has all the approvals it needs
+	ApproveDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error)
+	// RejectDisbursement rejects an AWAITING_APPROVAL disbursement as the authenticated user and releases its balance
+	RejectDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error)
+	// ListDisbursementApprovals returns the approval chain of the disbursement from its first step
+	ListDisbursementApprovals(context.Context, *GetDisbursementRequest) (*ListDisbursementApprovalsResponse, error)
+	DisburseBatch(context.Context, *DisburseBatchRequest) (*DisburseBatchResponse, error)
+	// StreamDisburseBatch receives the items of a large batch one by one,
+	// the batch is created once the client closes the stream. The idempotency-key metadata is the batch key.
+	StreamDisburseBatch(DisbursementService_StreamDisburseBatchServer) error
+	GetDisbursementBatch(context.Context, *GetDisbursementBatchRequest) (*DisbursementBatch, error)
+	// PreviewFee calculates the fee of a disbursement of the amount without disbursing
+	PreviewFee(context.Context, *PreviewFeeRequest) (*FeePreview, error)
+	// CreateFXQuote locks the rate from the funding currency into the disbursement currency for a few minutes
+	CreateFXQuote(context.Context, *CreateFXQuoteRequest) (*FXQuote, error)
+	GetFXQuote(context.Context, *GetFXQuoteRequest) (*FXQuote, error)
+	// CreateBeneficiary registers a bank account to disburse to, the holder name is inquired at the bank
+	CreateBeneficiary(context.Context, *BeneficiaryRequest) (*Beneficiary, error)
+	GetBeneficiary(context.Context, *GetBeneficiaryRequest) (*Beneficiary, error)
+	ListBeneficiaries(context.Context, *ListBeneficiariesRequest) (*ListBeneficiariesResponse, error)
+	// UpdateBeneficiary replaces the bank account of the beneficiary, the holder name of the new account is inquired again
+	UpdateBeneficiary(context.Context, *BeneficiaryRequest) (*Beneficiary, error)
+	// DeleteBeneficiary removes the beneficiary, the disbursements already paid to it keep referencing it
+	DeleteBeneficiary(context.Context, *DeleteBeneficiaryRequest) (*emptypb.Empty, error)
+	// GetMerchantLimits returns the limits the disbursements of the merchant in the currency are checked against,
+	// the default limits of the currency when the merchant has none of its own. Internal users only.
+	GetMerchantLimits(context.Context, *GetMerchantLimitsRequest) (*MerchantLimits, error)
+	// SetMerchantLimits replaces the limits of the merchant in the currency, it returns the limits. Internal users only.
+	SetMerchantLimits(context.Context, *SetMerchantLimitsRequest) (*MerchantLimits, error)
+	// GetApprovalPolicy returns the maker-checker policy of the merchant in the currency,
+	// NOT_FOUND when its disbursements in the currency need no approval. Internal users only.
+	GetApprovalPolicy(context.Context, *GetApprovalPolicyRequest) (*ApprovalPolicy, error)
+	// SetApprovalPolicy replaces the maker-checker policy of the merchant in the currency, it returns the policy.
+	// Internal users only.
+	SetApprovalPolicy(context.Context, *SetApprovalPolicyRequest) (*ApprovalPolicy, error)
+	// GetFeeSchedule returns the fee schedule of the merchant in the currency effective now,
+	// NOT_FOUND when its disbursements in the currency are charged nothing. Internal users only.
+	GetFeeSchedule(context.Context, *GetFeeScheduleRequest) (*FeeSchedule, error)
+	// CreateFeeSchedule creates the next version of the fee schedule of the merchant in the currency,
+	// the older versions are kept. Internal users only.
+	CreateFeeSchedule(context.Context, *CreateFeeScheduleRequest) (*CreateFeeScheduleResponse, error)
 }
 
 // UnimplementedDisbursementServiceServer should be embedded to have forward compatible implementations.
 type UnimplementedDisbursementServiceServer struct {
 }
 
-func (UnimplementedDisbursementServiceServer) Disburse(context.Context, *DisburseRequest) (*emptypb.Empty, error) {
+func (UnimplementedDisbursementServiceServer) Disburse(context.Context, *DisburseRequest) (*DisburseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Disburse not implemented")
 }
+func (UnimplementedDisbursementServiceServer) GetDisbursement(context.Context, *GetDisbursementRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) ListDisbursements(context.Context, *ListDisbursementsRequest) (*ListDisbursementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisbursements not implemented")
+}
+func (UnimplementedDisbursementServiceServer) CancelDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) ReverseDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) ApproveDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) RejectDisbursement(context.Context, *DisbursementActionRequest) (*Disbursement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectDisbursement not implemented")
+}
+func (UnimplementedDisbursementServiceServer) ListDisbursementApprovals(context.Context, *GetDisbursementRequest) (*ListDisbursementApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisbursementApprovals not implemented")
+}
+func (UnimplementedDisbursementServiceServer) DisburseBatch(context.Context, *DisburseBatchRequest) (*DisburseBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisburseBatch not implemented")
+}
+func (UnimplementedDisbursementServiceServer) StreamDisburseBatch(DisbursementService_StreamDisburseBatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDisburseBatch not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetDisbursementBatch(context.Context, *GetDisbursementBatchRequest) (*DisbursementBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDisbursementBatch not implemented")
+}
+func (UnimplementedDisbursementServiceServer) PreviewFee(context.Context, *PreviewFeeRequest) (*FeePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewFee not implemented")
+}
+func (UnimplementedDisbursementServiceServer) CreateFXQuote(context.Context, *CreateFXQuoteRequest) (*FXQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFXQuote not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetFXQuote(context.Context, *GetFXQuoteRequest) (*FXQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFXQuote not implemented")
+}
+func (UnimplementedDisbursementServiceServer) CreateBeneficiary(context.Context, *BeneficiaryRequest) (*Beneficiary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBeneficiary not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetBeneficiary(context.Context, *GetBeneficiaryRequest) (*Beneficiary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBeneficiary not implemented")
+}
+func (UnimplementedDisbursementServiceServer) ListBeneficiaries(context.Context, *ListBeneficiariesRequest) (*ListBeneficiariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBeneficiaries not implemented")
+}
+func (UnimplementedDisbursementServiceServer) UpdateBeneficiary(context.Context, *BeneficiaryRequest) (*Beneficiary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBeneficiary not implemented")
+}
+func (UnimplementedDisbursementServiceServer) DeleteBeneficiary(context.Context, *DeleteBeneficiaryRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBeneficiary not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetMerchantLimits(context.Context, *GetMerchantLimitsRequest) (*MerchantLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerchantLimits not implemented")
+}
+func (UnimplementedDisbursementServiceServer) SetMerchantLimits(context.Context, *SetMerchantLimitsRequest) (*MerchantLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMerchantLimits not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetApprovalPolicy(context.Context, *GetApprovalPolicyRequest) (*ApprovalPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApprovalPolicy not implemented")
+}
+func (UnimplementedDisbursementServiceServer) SetApprovalPolicy(context.Context, *SetApprovalPolicyRequest) (*ApprovalPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetApprovalPolicy not implemented")
+}
+func (UnimplementedDisbursementServiceServer) GetFeeSchedule(context.Context, *GetFeeScheduleRequest) (*FeeSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeSchedule not implemented")
+}
+func (UnimplementedDisbursementServiceServer) CreateFeeSchedule(context.Context, *CreateFeeScheduleRequest) (*CreateFeeScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFeeSchedule not implemented")
+}
 
 // UnsafeDisbursementServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DisbursementServiceServer will
@@ -91,18 +524,556 @@ func _DisbursementService_Disburse_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-// DisbursementService_ServiceDesc is the grpc.ServiceDesc for DisbursementService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var DisbursementService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "DisbursementService",
-	HandlerType: (*DisbursementServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Disburse",
-			Handler:    _DisbursementService_Disburse_Handler,
+func _DisbursementService_GetDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDisbursementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetDisbursement(ctx, req.(*GetDisbursementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_ListDisbursements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisbursementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).ListDisbursements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_ListDisbursements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).ListDisbursements(ctx, req.(*ListDisbursementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_CancelDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisbursementActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).CancelDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_CancelDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).CancelDisbursement(ctx, req.(*DisbursementActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_ReverseDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisbursementActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).ReverseDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_ReverseDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).ReverseDisbursement(ctx, req.(*DisbursementActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_ApproveDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisbursementActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).ApproveDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_ApproveDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).ApproveDisbursement(ctx, req.(*DisbursementActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_RejectDisbursement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisbursementActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).RejectDisbursement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_RejectDisbursement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).RejectDisbursement(ctx, req.(*DisbursementActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_ListDisbursementApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDisbursementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).ListDisbursementApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_ListDisbursementApprovals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).ListDisbursementApprovals(ctx, req.(*GetDisbursementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_DisburseBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisburseBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).DisburseBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_DisburseBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).DisburseBatch(ctx, req.(*DisburseBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_StreamDisburseBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisbursementServiceServer).StreamDisburseBatch(&disbursementServiceStreamDisburseBatchServer{stream})
+}
+
+type DisbursementService_StreamDisburseBatchServer interface {
+	SendAndClose(*DisburseBatchResponse) error
+	Recv() (*DisburseBatchItem, error)
+	grpc.ServerStream
+}
+
+type disbursementServiceStreamDisburseBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *disbursementServiceStreamDisburseBatchServer) SendAndClose(m *DisburseBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disbursementServiceStreamDisburseBatchServer) Recv() (*DisburseBatchItem, error) {
+	m := new(DisburseBatchItem)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DisbursementService_GetDisbursementBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDisbursementBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetDisbursementBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetDisbursementBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetDisbursementBatch(ctx, req.(*GetDisbursementBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_PreviewFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).PreviewFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_PreviewFee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).PreviewFee(ctx, req.(*PreviewFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_CreateFXQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFXQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).CreateFXQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_CreateFXQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).CreateFXQuote(ctx, req.(*CreateFXQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_GetFXQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFXQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetFXQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetFXQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetFXQuote(ctx, req.(*GetFXQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_CreateBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).CreateBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_CreateBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).CreateBeneficiary(ctx, req.(*BeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_GetBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetBeneficiary(ctx, req.(*GetBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_ListBeneficiaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeneficiariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).ListBeneficiaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_ListBeneficiaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).ListBeneficiaries(ctx, req.(*ListBeneficiariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_UpdateBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).UpdateBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_UpdateBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).UpdateBeneficiary(ctx, req.(*BeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_DeleteBeneficiary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBeneficiaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).DeleteBeneficiary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_DeleteBeneficiary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).DeleteBeneficiary(ctx, req.(*DeleteBeneficiaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_GetMerchantLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMerchantLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetMerchantLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetMerchantLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetMerchantLimits(ctx, req.(*GetMerchantLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_SetMerchantLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMerchantLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).SetMerchantLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_SetMerchantLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).SetMerchantLimits(ctx, req.(*SetMerchantLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_GetApprovalPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApprovalPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetApprovalPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetApprovalPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetApprovalPolicy(ctx, req.(*GetApprovalPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_SetApprovalPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetApprovalPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).SetApprovalPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_SetApprovalPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).SetApprovalPolicy(ctx, req.(*SetApprovalPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_GetFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).GetFeeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_GetFeeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).GetFeeSchedule(ctx, req.(*GetFeeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisbursementService_CreateFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFeeScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisbursementServiceServer).CreateFeeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisbursementService_CreateFeeSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisbursementServiceServer).CreateFeeSchedule(ctx, req.(*CreateFeeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DisbursementService_ServiceDesc is the grpc.ServiceDesc for DisbursementService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DisbursementService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "DisbursementService",
+	HandlerType: (*DisbursementServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Disburse",
+			Handler:    _DisbursementService_Disburse_Handler,
+		},
+		{
+			MethodName: "GetDisbursement",
+			Handler:    _DisbursementService_GetDisbursement_Handler,
+		},
+		{
+			MethodName: "ListDisbursements",
+			Handler:    _DisbursementService_ListDisbursements_Handler,
+		},
+		{
+			MethodName: "CancelDisbursement",
+			Handler:    _DisbursementService_CancelDisbursement_Handler,
+		},
+		{
+			MethodName: "ReverseDisbursement",
+			Handler:    _DisbursementService_ReverseDisbursement_Handler,
+		},
+		{
+			MethodName: "ApproveDisbursement",
+			Handler:    _DisbursementService_ApproveDisbursement_Handler,
+		},
+		{
+			MethodName: "RejectDisbursement",
+			Handler:    _DisbursementService_RejectDisbursement_Handler,
+		},
+		{
+			MethodName: "ListDisbursementApprovals",
+			Handler:    _DisbursementService_ListDisbursementApprovals_Handler,
+		},
+		{
+			MethodName: "DisburseBatch",
+			Handler:    _DisbursementService_DisburseBatch_Handler,
+		},
+		{
+			MethodName: "GetDisbursementBatch",
+			Handler:    _DisbursementService_GetDisbursementBatch_Handler,
+		},
+		{
+			MethodName: "PreviewFee",
+			Handler:    _DisbursementService_PreviewFee_Handler,
+		},
+		{
+			MethodName: "CreateFXQuote",
+			Handler:    _DisbursementService_CreateFXQuote_Handler,
+		},
+		{
+			MethodName: "GetFXQuote",
+			Handler:    _DisbursementService_GetFXQuote_Handler,
+		},
+		{
+			MethodName: "CreateBeneficiary",
+			Handler:    _DisbursementService_CreateBeneficiary_Handler,
+		},
+		{
+			MethodName: "GetBeneficiary",
+			Handler:    _DisbursementService_GetBeneficiary_Handler,
+		},
+		{
+			MethodName: "ListBeneficiaries",
+			Handler:    _DisbursementService_ListBeneficiaries_Handler,
+		},
+		{
+			MethodName: "UpdateBeneficiary",
+			Handler:    _DisbursementService_UpdateBeneficiary_Handler,
+		},
+		{
+			MethodName: "DeleteBeneficiary",
+			Handler:    _DisbursementService_DeleteBeneficiary_Handler,
+		},
+		{
+			MethodName: "GetMerchantLimits",
+			Handler:    _DisbursementService_GetMerchantLimits_Handler,
+		},
+		{
+			MethodName: "SetMerchantLimits",
+			Handler:    _DisbursementService_SetMerchantLimits_Handler,
+		},
+		{
+			MethodName: "GetApprovalPolicy",
+			Handler:    _DisbursementService_GetApprovalPolicy_Handler,
+		},
+		{
+			MethodName: "SetApprovalPolicy",
+			Handler:    _DisbursementService_SetApprovalPolicy_Handler,
+		},
+		{
+			MethodName: "GetFeeSchedule",
+			Handler:    _DisbursementService_GetFeeSchedule_Handler,
+		},
+		{
+			MethodName: "CreateFeeSchedule",
+			Handler:    _DisbursementService_CreateFeeSchedule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamDisburseBatch",
+			Handler:       _DisbursementService_StreamDisburseBatch_Handler,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "disbursement.proto",
 }
@@ -0,0 +1,37 @@
+package schema
+
+import "time"
+
+const (
+	DisbursementEventType = "disbursement"
+
+	DisbursementCreatedSubType       = "disbursement.created"
+	DisbursementStatusChangedSubType = "disbursement.status_changed"
+)
+
+// DisbursementEvent is the data of the disbursement events published to kafka
+type DisbursementEvent struct {
+	ID         string `json:"id"`
+	MerchantID string `json:"merchant_id"`
+	BatchID    string `json:"batch_id,omitempty"`
+
+	// Amount is the exact decimal amount in major units, e.g. "10000.50"
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+
+	// Fee is charged to the merchant on top of Amount, in the same currency and format
+	Fee string `json:"fee"`
+
+	// FundingCurrency is the currency charged from the merchant balance, FXRate the exact decimal worth of
+	// 1 unit of it in Currency, empty when the disbursement is funded in its own currency
+	FundingCurrency string `json:"funding_currency"`
+	FXRate          string `json:"fx_rate,omitempty"`
+
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
@@ -1,5 +1,192 @@
 package schema
 
+import (
+	"embed"
+	"encoding/json"
+	"regexp"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/google/uuid"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DisbursementRequestType = "disbursement"
+
+	DisburseSubType                 = "disbursement.create"
+	CancelDisbursementSubType       = "disbursement.cancel"
+	ReverseDisbursementSubType      = "disbursement.reverse"
+	UpdateDisbursementStatusSubType = "disbursement.update_status"
+)
+
+// jsonSchemas are JSON Schema draft 2020-12 documents
+//
+//go:embed jsonschema
+var jsonSchemas embed.FS
+
+// the currency is only checked for its shape, the command rejects the currencies the domain doesn't support
+var (
+	amountPattern   = regexp.MustCompile(`^\d+(\.\d+)?$`)
+	currencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+)
+
 type DisburseKafkaRequest struct {
-	Amount float32 `json:"amount"`
+	MerchantID string `json:"merchant_id"`
+
+	// Amount accepts both JSON number and string, json.Number keeps the exact literal so no float rounding happens
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+
+	// FXQuoteID funds the disbursement in the source currency of the quote, empty funds it in its own currency
+	FXQuoteID string `json:"fx_quote_id,omitempty"`
+}
+
+var DisburseKafkaRequestPayload = NewPayload[DisburseKafkaRequest](DisburseSubType).
+	WithVersion(DefaultVersion, PayloadVersion[DisburseKafkaRequest]{
+		JSONSchema: mustLoadJSONSchema(jsonSchemas, "jsonschema/disburse_kafka_request.v1.json"),
+		NewProto:   func() proto.Message { return &protogen.DisburseKafkaRequest{} },
+		FromProto: func(msg proto.Message) (DisburseKafkaRequest, error) {
+			req, err := protoAs[*protogen.DisburseKafkaRequest](msg)
+			if err != nil {
+				return DisburseKafkaRequest{}, err
+			}
+
+			return DisburseKafkaRequest{
+				MerchantID: req.GetMerchantId(),
+				Amount:     json.Number(req.GetAmount()),
+				Currency:   req.GetCurrency(),
+				FXQuoteID:  req.GetFxQuoteId(),
+			}, nil
+		},
+		Validate: func(req DisburseKafkaRequest) (errInfos []api.ErrorInfo) {
+			if req.MerchantID == "" {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "merchant_id", Message: "is required"})
+			}
+
+			if !amountPattern.MatchString(req.Amount.String()) {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "amount", Message: "must be a positive decimal number"})
+			}
+
+			if !currencyPattern.MatchString(req.Currency) {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "currency", Message: "must be a 3 letters ISO-4217 code"})
+			}
+
+			if req.FXQuoteID != "" {
+				if _, err := uuid.Parse(req.FXQuoteID); err != nil {
+					errInfos = append(errInfos, api.ErrorInfo{Field: "fx_quote_id", Message: "must be a uuid"})
+				}
+			}
+
+			return errInfos
+		},
+	})
+
+type CancelDisbursementKafkaRequest struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+
+	// RequestedBy is who asked for the action, it is the actor of the audit entry
+	RequestedBy string `json:"requested_by"`
 }
+
+var CancelDisbursementKafkaRequestPayload = NewPayload[CancelDisbursementKafkaRequest](CancelDisbursementSubType).
+	WithVersion(DefaultVersion, PayloadVersion[CancelDisbursementKafkaRequest]{
+		JSONSchema: mustLoadJSONSchema(jsonSchemas, "jsonschema/cancel_disbursement_kafka_request.v1.json"),
+		NewProto:   func() proto.Message { return &protogen.CancelDisbursementKafkaRequest{} },
+		FromProto: func(msg proto.Message) (CancelDisbursementKafkaRequest, error) {
+			req, err := protoAs[*protogen.CancelDisbursementKafkaRequest](msg)
+			if err != nil {
+				return CancelDisbursementKafkaRequest{}, err
+			}
+
+			return CancelDisbursementKafkaRequest{
+				ID:          req.GetId(),
+				Reason:      req.GetReason(),
+				RequestedBy: req.GetRequestedBy(),
+			}, nil
+		},
+		Validate: func(req CancelDisbursementKafkaRequest) (errInfos []api.ErrorInfo) {
+			if _, err := uuid.Parse(req.ID); err != nil {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "id", Message: "must be a uuid"})
+			}
+
+			if req.RequestedBy == "" {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "requested_by", Message: "is required"})
+			}
+
+			return errInfos
+		},
+	})
+
+type ReverseDisbursementKafkaRequest struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+
+	// RequestedBy is who asked for the action, it is the actor of the audit entry
+	RequestedBy string `json:"requested_by"`
+}
+
+var ReverseDisbursementKafkaRequestPayload = NewPayload[ReverseDisbursementKafkaRequest](ReverseDisbursementSubType).
+	WithVersion(DefaultVersion, PayloadVersion[ReverseDisbursementKafkaRequest]{
+		JSONSchema: mustLoadJSONSchema(jsonSchemas, "jsonschema/reverse_disbursement_kafka_request.v1.json"),
+		NewProto:   func() proto.Message { return &protogen.ReverseDisbursementKafkaRequest{} },
+		FromProto: func(msg proto.Message) (ReverseDisbursementKafkaRequest, error) {
+			req, err := protoAs[*protogen.ReverseDisbursementKafkaRequest](msg)
+			if err != nil {
+				return ReverseDisbursementKafkaRequest{}, err
+			}
+
+			return ReverseDisbursementKafkaRequest{
+				ID:          req.GetId(),
+				Reason:      req.GetReason(),
+				RequestedBy: req.GetRequestedBy(),
+			}, nil
+		},
+		Validate: func(req ReverseDisbursementKafkaRequest) (errInfos []api.ErrorInfo) {
+			if _, err := uuid.Parse(req.ID); err != nil {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "id", Message: "must be a uuid"})
+			}
+
+			if req.RequestedBy == "" {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "requested_by", Message: "is required"})
+			}
+
+			return errInfos
+		},
+	})
+
+type UpdateDisbursementStatusKafkaRequest struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+var UpdateDisbursementStatusKafkaRequestPayload = NewPayload[UpdateDisbursementStatusKafkaRequest](UpdateDisbursementStatusSubType).
+	WithVersion(DefaultVersion, PayloadVersion[UpdateDisbursementStatusKafkaRequest]{
+		JSONSchema: mustLoadJSONSchema(jsonSchemas, "jsonschema/update_disbursement_status_kafka_request.v1.json"),
+		NewProto:   func() proto.Message { return &protogen.UpdateDisbursementStatusKafkaRequest{} },
+		FromProto: func(msg proto.Message) (UpdateDisbursementStatusKafkaRequest, error) {
+			req, err := protoAs[*protogen.UpdateDisbursementStatusKafkaRequest](msg)
+			if err != nil {
+				return UpdateDisbursementStatusKafkaRequest{}, err
+			}
+
+			return UpdateDisbursementStatusKafkaRequest{
+				ID:            req.GetId(),
+				Status:        req.GetStatus(),
+				FailureReason: req.GetFailureReason(),
+			}, nil
+		},
+		Validate: func(req UpdateDisbursementStatusKafkaRequest) (errInfos []api.ErrorInfo) {
+			if _, err := uuid.Parse(req.ID); err != nil {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "id", Message: "must be a uuid"})
+			}
+
+			if req.Status == "" {
+				errInfos = append(errInfos, api.ErrorInfo{Field: "status", Message: "is required"})
+			}
+
+			return errInfos
+		},
+	})
@@ -0,0 +1,259 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/durianpay/dpay-common/api"
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+
+	// DefaultVersion is the schema version of the messages that don't carry one
+	DefaultVersion = "1"
+)
+
+var (
+	ErrInvalidPayload            = stderrors.New("invalid payload")
+	ErrUnsupportedContentType    = stderrors.New("unsupported payload content type")
+	ErrUnsupportedSchemaVersion  = stderrors.New("unsupported payload schema version")
+	errUnexpectedProtobufMessage = stderrors.New("unexpected protobuf message")
+)
+
+// PayloadVersion is the definition of one schema version of a payload
+type PayloadVersion[T any] struct {
+	// JSONSchema validates the JSON payload before it's decoded, nil skips the schema validation.
+	// The format keyword is asserted, e.g. a "uuid" format rejects a string that is not a uuid.
+	JSONSchema *jsonschema.Schema
+
+	// NewProto returns an empty protobuf message to decode into, nil means protobuf is not supported.
+	// FromProto converts the decoded protobuf message into the payload.
+	NewProto  func() proto.Message
+	FromProto func(msg proto.Message) (T, error)
+
+	// Validate checks the fields of the decoded payload in both encodings, nil skips the field validation
+	Validate func(payload T) []api.ErrorInfo
+}
+
+// Payload decodes and validates a versioned payload, either JSON or protobuf.
+// JSON is decoded strictly, a field not known by T is rejected.
+type Payload[T any] struct {
+	name     string
+	versions map[string]PayloadVersion[T]
+}
+
+func NewPayload[T any](name string) *Payload[T] {
+	return &Payload[T]{
+		name:     name,
+		versions: make(map[string]PayloadVersion[T]),
+	}
+}
+
+// WithVersion registers the definition of the schema version, it's meant to be chained on initialization
+func (p *Payload[T]) WithVersion(version string, def PayloadVersion[T]) *Payload[T] {
+	p.versions[version] = def
+	return p
+}
+
+// Decode decodes the data by the content type and validates it against the schema version.
+// Empty content type means JSON and empty version means DefaultVersion.
+func (p *Payload[T]) Decode(contentType, version string, data []byte) (T, error) {
+	var result T
+
+	if version == "" {
+		version = DefaultVersion
+	}
+
+	def, ok := p.versions[version]
+	if !ok {
+		return result, errors.NewIncorrectInputError(
+			ErrUnsupportedSchemaVersion,
+			fmt.Sprintf("%s: %s version %q", ErrUnsupportedSchemaVersion.Error(), p.name, version),
+			errors.DpayInvalidRequest,
+		)
+	}
+
+	var err error
+
+	switch contentType {
+	case "", ContentTypeJSON:
+		result, err = p.decodeJSON(def, data)
+	case ContentTypeProtobuf:
+		result, err = p.decodeProtobuf(def, data)
+	default:
+		err = p.unsupportedContentType(contentType)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if def.Validate != nil {
+		if errInfos := def.Validate(result); len(errInfos) > 0 {
+			return result, p.invalidPayload(ErrInvalidPayload, errInfos...)
+		}
+	}
+
+	return result, nil
+}
+
+func (p *Payload[T]) decodeJSON(def PayloadVersion[T], data []byte) (T, error) {
+	var result T
+
+	if def.JSONSchema != nil {
+		var raw any
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&raw); err != nil {
+			return result, p.invalidPayload(err)
+		}
+
+		if err := def.JSONSchema.Validate(raw); err != nil {
+			return result, p.invalidPayload(err, toErrorInfos(err)...)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&result); err != nil {
+		return result, p.invalidPayload(err, api.ErrorInfo{Message: err.Error()})
+	}
+
+	return result, nil
+}
+
+func (p *Payload[T]) decodeProtobuf(def PayloadVersion[T], data []byte) (T, error) {
+	var result T
+
+	if def.NewProto == nil || def.FromProto == nil {
+		return result, p.unsupportedContentType(ContentTypeProtobuf)
+	}
+
+	msg := def.NewProto()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return result, p.invalidPayload(err)
+	}
+
+	// protobuf keeps the unknown fields silently, reject them to be as strict as the JSON decoding
+	if len(msg.ProtoReflect().GetUnknown()) > 0 {
+		return result, p.invalidPayload(
+			ErrInvalidPayload,
+			api.ErrorInfo{Message: "payload has unknown protobuf fields"},
+		)
+	}
+
+	result, err := def.FromProto(msg)
+	if err != nil {
+		return result, p.invalidPayload(err)
+	}
+
+	return result, nil
+}
+
+func (p *Payload[T]) invalidPayload(err error, errInfos ...api.ErrorInfo) error {
+	return errors.NewIncorrectInputError(
+		err,
+		fmt.Sprintf("%s: %s", ErrInvalidPayload.Error(), p.name),
+		errors.DpayInvalidRequest,
+		errInfos...,
+	)
+}
+
+func (p *Payload[T]) unsupportedContentType(contentType string) error {
+	return errors.NewIncorrectInputError(
+		ErrUnsupportedContentType,
+		fmt.Sprintf("%s: %s does not support %q", ErrUnsupportedContentType.Error(), p.name, contentType),
+		errors.DpayInvalidRequest,
+	)
+}
+
+// toErrorInfos turns every schema violation into an error info, the field is the JSON pointer joined by dot
+func toErrorInfos(err error) []api.ErrorInfo {
+	var validationErr *jsonschema.ValidationError
+	if !stderrors.As(err, &validationErr) {
+		return []api.ErrorInfo{{Message: err.Error()}}
+	}
+
+	var errInfos []api.ErrorInfo
+
+	// the violations are the leaves, the parents only tell which keyword their causes failed
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+
+			return
+		}
+
+		errInfos = append(errInfos, api.ErrorInfo{
+			Field:   jsonPointerField(e.InstanceLocation),
+			Message: e.Message,
+		})
+	}
+
+	walk(validationErr)
+
+	return errInfos
+}
+
+// jsonPointerField joins the tokens of the JSON pointer by dot, the root pointer is an empty field
+func jsonPointerField(pointer string) string {
+	if pointer == "" || pointer == "/" {
+		return ""
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return strings.Join(tokens, ".")
+}
+
+// mustLoadJSONSchema compiles the JSON schema from the file system, it panics since the schemas are embedded
+// and a broken one is a programming error
+func mustLoadJSONSchema(fsys fs.FS, path string) *jsonschema.Schema {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		panic(fmt.Sprintf("schema: read %s: %s", path, err.Error()))
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.AssertFormat = true
+
+	if err := compiler.AddResource(path, bytes.NewReader(data)); err != nil {
+		panic(fmt.Sprintf("schema: parse %s: %s", path, err.Error()))
+	}
+
+	schema, err := compiler.Compile(path)
+	if err != nil {
+		panic(fmt.Sprintf("schema: compile %s: %s", path, err.Error()))
+	}
+
+	return schema
+}
+
+// protoAs casts the decoded protobuf message into its concrete type
+func protoAs[P proto.Message](msg proto.Message) (P, error) {
+	typed, ok := msg.(P)
+	if !ok {
+		return typed, errUnexpectedProtobufMessage
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,88 @@
+package schema
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/layarda-durianpay/go-skeleton/pkg/common/errors"
+)
+
+func TestPayloadDecodeJSONSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		fields []string
+	}{
+		{
+			name: "valid string amount",
+			data: `{"merchant_id":"merchant-1","amount":"10000.50","currency":"IDR"}`,
+		},
+		{
+			name: "valid number amount",
+			data: `{"merchant_id":"merchant-1","amount":10000,"currency":"IDR"}`,
+		},
+		{
+			// exclusiveMinimum is a number in JSON Schema, the OpenAPI 3.0 boolean form would not compile
+			name:   "zero number amount",
+			data:   `{"merchant_id":"merchant-1","amount":0,"currency":"IDR"}`,
+			fields: []string{"amount"},
+		},
+		{
+			name:   "format is asserted",
+			data:   `{"merchant_id":"merchant-1","amount":"10000","currency":"IDR","fx_quote_id":"not-a-uuid"}`,
+			fields: []string{"fx_quote_id"},
+		},
+		{
+			name:   "missing and unknown properties",
+			data:   `{"amount":"10000","currency":"IDR","extra":true}`,
+			fields: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DisburseKafkaRequestPayload.Decode(ContentTypeJSON, DefaultVersion, []byte(tt.data))
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("Decode error = %v", err)
+				}
+
+				return
+			}
+
+			var dpayErr errors.DpayError
+			if !stderrors.As(err, &dpayErr) {
+				t.Fatalf("Decode error = %v, want an invalid payload", err)
+			}
+
+			got := make(map[string]bool)
+			for _, info := range dpayErr.ErrorInfos() {
+				got[info.Field] = true
+			}
+
+			for _, field := range tt.fields {
+				if !got[field] {
+					t.Errorf("error infos = %+v, want one on %q", dpayErr.ErrorInfos(), field)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONPointerField(t *testing.T) {
+	tests := []struct {
+		pointer string
+		expect  string
+	}{
+		{pointer: "", expect: ""},
+		{pointer: "/amount", expect: "amount"},
+		{pointer: "/items/0/amount", expect: "items.0.amount"},
+		{pointer: "/a~1b/c~0d", expect: "a/b.c~d"},
+	}
+
+	for _, tt := range tests {
+		if got := jsonPointerField(tt.pointer); got != tt.expect {
+			t.Errorf("jsonPointerField(%q) = %q, want %q", tt.pointer, got, tt.expect)
+		}
+	}
+}
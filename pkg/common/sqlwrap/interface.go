@@ -34,3 +34,9 @@ type Transaction interface {
 	Rollback() error
 	Commit() error
 }
+
+// Executor is the common part of Database and Transaction to run the queries
+type Executor interface {
+	sqlx.Ext
+	sqlx.ExtContext
+}
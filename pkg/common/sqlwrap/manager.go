@@ -39,24 +39,24 @@ func NewManager(db Database) *Manager {
 	}
 }
 
-// RunInTransaction runs the f with the transaction queryable inside the context
+// RunInTransaction runs the f with the transaction queryable inside the context.
+// When the context already carries a transaction f joins it, and the outermost call commits or rolls back.
 func (m *Manager) RunInTransaction(ctx context.Context, f func(ctx context.Context) error) (err error) {
-	tx := TransactionFromContext(ctx)
-
-	if tx == nil {
-		tx, err = m.db.BeginTx(ctx, nil)
-		if err != nil {
-			return errors.NewDatabaseError(
-				err,
-				fmt.Sprintf("error begin transaction: %s", err.Error()),
-				errors.DpayInternalError,
-			)
-		}
+	if TransactionFromContext(ctx) != nil {
+		return f(ctx)
+	}
 
-		// only assign ctx with tx if new tx was created
-		ctx = ContextWithTx(ctx, tx)
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.NewDatabaseError(
+			err,
+			fmt.Sprintf("error begin transaction: %s", err.Error()),
+			errors.DpayInternalError,
+		)
 	}
 
+	ctx = ContextWithTx(ctx, tx)
+
 	defer func() {
 		if r := recover(); r != nil {
 			err = tracerr.Errorf("panic error: %v", r)
@@ -16,3 +16,13 @@ func TransactionFromContext(ctx context.Context) Transaction {
 func ContextWithTx(parentContext context.Context, tx Transaction) context.Context {
 	return context.WithValue(parentContext, txKey, tx)
 }
+
+// ExecutorFromContext returns the transaction from the context, or the db if the context has no transaction.
+// Repositories use it so the queries join the transaction started by Manager.RunInTransaction
+func ExecutorFromContext(ctx context.Context, db Database) Executor {
+	if tx := TransactionFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	return db
+}
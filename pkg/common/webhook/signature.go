@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload keyed with the secret
+func Sign(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the hex encoded HMAC-SHA256 signature of the payload in constant time,
+// an empty secret never verifies so a provider without secret can not be called
+func VerifySignature(secret []byte, payload []byte, signature string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hmac.Equal(mac.Sum(nil), decoded)
+}
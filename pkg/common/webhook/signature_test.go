@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test case 2
+	got := Sign([]byte("Jefe"), []byte("what do ya want for nothing?"))
+
+	expect := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != expect {
+		t.Errorf("Sign() = %s, want %s", got, expect)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("callback-secret")
+	payload := []byte(`{"event_id":"evt-1","status":"SUCCESS"}`)
+	signature := Sign(secret, payload)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		payload   []byte
+		signature string
+		expect    bool
+	}{
+		{name: "valid signature", secret: secret, payload: payload, signature: signature, expect: true},
+		{name: "upper case hex", secret: secret, payload: payload, signature: strings.ToUpper(signature), expect: true},
+		{name: "other secret", secret: []byte("other-secret"), payload: payload, signature: signature},
+		{name: "tampered payload", secret: secret, payload: []byte(`{"event_id":"evt-1","status":"FAILED"}`), signature: signature},
+		{name: "truncated signature", secret: secret, payload: payload, signature: signature[:len(signature)-2]},
+		{name: "not hex", secret: secret, payload: payload, signature: "not-a-signature"},
+		{name: "empty signature", secret: secret, payload: payload, signature: ""},
+		{name: "empty secret", secret: nil, payload: payload, signature: Sign(nil, payload)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, tt.payload, tt.signature); got != tt.expect {
+				t.Errorf("VerifySignature() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}